@@ -0,0 +1,125 @@
+//
+// Copyright 2023 The GoEurofxref Authors. All rights reserved.
+// Use of this source code is governed by a MIT License
+// license that can be found in the LICENSE file.
+// Last Modification: 2026-10-15 10:45:00
+//
+
+package eurofxref
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+)
+
+// awaitMinInterval and awaitMaxInterval bound the wait between the polls
+// of AwaitNextPublication, which grows by half after each of them.
+var (
+	awaitMinInterval = 30 * time.Second
+	awaitMaxInterval = 10 * time.Minute
+)
+
+// AwaitNextPublication blocks until the ECB publishes rates newer than
+// those of the daily file when it is called, and returns them. It sleeps
+// until the next publication is expected, then polls the daily file with
+// a growing interval, downloading it only when it changed, until the new
+// date appears or ctx is done. The download errors are retried.
+//
+// The new rates are cached and delivered to the subscribers like those of
+// DailyRates.
+func (efr EuroFxRef) AwaitNextPublication(ctx context.Context) (*RateTable, error) {
+
+	if efr.Offline {
+		return nil, errors.New("offline mode: the next publication cannot be awaited")
+	}
+
+	logger := efr.logger()
+
+	var poll func() (*RateTable, error)
+	if efr.Provider != nil || efr.UseZip {
+		poll = func() (*RateTable, error) {
+			return efr.fetchDailyRates(ctx, true)
+		}
+	} else {
+		var previous *validators
+		var known *RateTable
+		poll = func() (*RateTable, error) {
+			contentBytes, current, err := efr.fetchConditional(ctx, efr.Url, true, previous)
+			if err != nil {
+				return nil, err
+			}
+			previous = current
+			if contentBytes == nil {
+				return known, nil
+			}
+			if known, err = efr.decodeDaily(ctx, contentBytes); err != nil {
+				return nil, err
+			}
+			return known, nil
+		}
+	}
+
+	var baseline *RateTable
+	interval := awaitMinInterval
+	wait := time.Duration(0)
+	for {
+		if wait > 0 {
+			timer := time.NewTimer(wait)
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return nil, ctx.Err()
+			case <-timer.C:
+			}
+		}
+
+		table, err := poll()
+		switch {
+		case err != nil:
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+			logger.Warn("polling the next publication failed", slog.Any("error", err))
+		case baseline == nil:
+			baseline = table
+			efr.debug(DebugRequests, "awaiting the next publication",
+				slog.String("after", baseline.Date.Format("2006-01-02")))
+		case table != nil && table.Date.After(baseline.Date):
+			return table.clone(), nil
+		}
+
+		if baseline != nil {
+			if next := baseline.NextUpdate(); next.After(efr.Now()) {
+				wait, interval = next.Sub(efr.Now()), awaitMinInterval
+				continue
+			}
+		}
+		wait = interval
+		interval = min(interval*3/2, awaitMaxInterval)
+	}
+}
+
+// decodeDaily parses the content of the daily file downloaded, and
+// cached, by fetchConditional and records its table.
+func (efr EuroFxRef) decodeDaily(ctx context.Context, contentBytes []byte) (*RateTable, error) {
+
+	envelope, err := efr.decodeEnvelope(ctx, efr.Url, contentBytes)
+	if err != nil {
+		return nil, err
+	}
+	if len(envelope.Cube.Cube) == 0 {
+		return nil, errors.New("the envelope has no reference rates")
+	}
+
+	date, rates, err := envelope.Cube.Cube[0].rates()
+	if err != nil {
+		return nil, err
+	}
+
+	table := &RateTable{Date: date, Rates: rates}
+	efr.record(table)
+
+	return table, nil
+}
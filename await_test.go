@@ -0,0 +1,142 @@
+//
+// Copyright 2023 The GoEurofxref Authors. All rights reserved.
+// Use of this source code is governed by a MIT License
+// license that can be found in the LICENSE file.
+// Last Modification: 2026-10-15 10:45:00
+//
+
+package eurofxref
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestAwaitNextPublication(t *testing.T) {
+
+	defer func(interval time.Duration) { awaitMinInterval = interval }(awaitMinInterval)
+	awaitMinInterval = time.Millisecond
+
+	friday, err := os.ReadFile("testdata/eurofxref-daily.xml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	monday := bytes.Replace(friday, []byte("2024-03-01"), []byte("2024-03-04"), 1)
+
+	var mu sync.Mutex
+	requests, notModified := 0, 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		requests++
+		content, etag := friday, `"friday"`
+		if requests > 3 {
+			content, etag = monday, `"monday"`
+		}
+		w.Header().Set("ETag", etag)
+		if r.Header.Get("If-None-Match") == etag {
+			notModified++
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Write(content)
+	}))
+	defer ts.Close()
+
+	query := New(t.TempDir(), false)
+	query.Url = ts.URL + "/eurofxref-daily.xml"
+	// Monday evening, the rates of Friday are superseded
+	query.Clock = FixedClock(time.Date(2024, 3, 4, 16, 30, 0, 0, CET))
+
+	table, err := query.AwaitNextPublication(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !table.Date.Equal(PublicationDate(2024, 3, 4)) {
+		t.Errorf("got the publication of %v, want 2024-03-04", table.Date)
+	}
+	if notModified != 2 {
+		t.Errorf("got %d unchanged responses in %d requests, want 2", notModified, requests)
+	}
+
+	// cached for the next lookups
+	ts.Close()
+	if cached, ok := query.CachedRates(context.Background()); !ok || !cached.Date.Equal(table.Date) {
+		t.Errorf("got the cached rates %v (%v), want those of 2024-03-04", cached, ok)
+	}
+}
+
+func TestAwaitNextPublicationMirrors(t *testing.T) {
+
+	primary := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "maintenance", http.StatusServiceUnavailable)
+	}))
+	defer primary.Close()
+
+	friday, err := os.ReadFile("testdata/eurofxref-daily.xml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	monday := bytes.Replace(friday, []byte("2024-03-01"), []byte("2024-03-04"), 1)
+	var mu sync.Mutex
+	requests := 0
+	mirror := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		requests++
+		if requests > 1 {
+			w.Write(monday)
+			return
+		}
+		w.Write(friday)
+	}))
+	defer mirror.Close()
+
+	query := New(t.TempDir(), false)
+	query.Url = primary.URL + "/eurofxref-daily.xml"
+	query.Mirrors = []string{mirror.URL}
+	query.Clock = FixedClock(time.Date(2024, 3, 4, 16, 30, 0, 0, CET))
+
+	defer func(interval time.Duration) { awaitMinInterval = interval }(awaitMinInterval)
+	awaitMinInterval = time.Millisecond
+
+	table, err := query.AwaitNextPublication(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !table.Date.Equal(PublicationDate(2024, 3, 4)) {
+		t.Errorf("got the publication of %v, want 2024-03-04", table.Date)
+	}
+
+	// cached through the regular path
+	if cached, err := os.ReadFile(query.CachePath(query.Url)); err != nil || !bytes.Equal(cached, monday) {
+		t.Errorf("the new daily file was not cached: %v", err)
+	}
+}
+
+func TestAwaitNextPublicationCancel(t *testing.T) {
+
+	_, query := newTestServer(t)
+	// Saturday, the next publication is on Monday
+	query.Clock = FixedClock(time.Date(2024, 3, 2, 12, 0, 0, 0, CET))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	if _, err := query.AwaitNextPublication(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("got %v, want the deadline exceeded", err)
+	}
+
+	query.Offline = true
+	if _, err := query.AwaitNextPublication(context.Background()); err == nil {
+		t.Error("expected an error in the offline mode")
+	}
+}
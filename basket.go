@@ -0,0 +1,172 @@
+//
+// Copyright 2023 The GoEurofxref Authors. All rights reserved.
+// Use of this source code is governed by a MIT License
+// license that can be found in the LICENSE file.
+// Last Modification: 2026-10-14 19:18:55
+//
+
+package eurofxref
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Basket is a custom basket of currencies, such as 50% USD, 30% GBP and
+// 20% CHF, valued against the euro with the reference rates of Source.
+// The Weights are relative, so {"USD": 50, "GBP": 30, "CHF": 20} and
+// {"USD": 0.5, "GBP": 0.3, "CHF": 0.2} are the same basket.
+//
+// Its value is the geometric weighted average of the rates, in units of
+// the basket per euro: the percentage changes of the value are the
+// weighted average of those of the rates, as for the effective exchange
+// rates of the ECB, whatever the magnitude of each rate.
+type Basket struct {
+	// Name is the name of the series of History.
+	Name    string
+	Weights map[string]float64
+	Source  EuroFxRef
+}
+
+// BasketValue is the value of a basket on Date, the publication whose
+// Rates were used, which is Requested or, on the dates without a
+// publication, the most recent one before.
+type BasketValue struct {
+	Requested time.Time
+	Date      time.Time
+	Value     float64
+	Rates     map[string]float64 // of the currencies of the basket
+}
+
+// validate checks the weights of the basket.
+func (basket *Basket) validate() error {
+
+	if len(basket.Weights) == 0 {
+		return errors.New("the basket has no currency")
+	}
+
+	for currencyCode, weight := range basket.Weights {
+		if !strings.EqualFold(currencyCode, "EUR") {
+			if err := basket.Source.ValidateCurrencyCode(currencyCode); err != nil {
+				return err
+			}
+		}
+		if weight <= 0 || math.IsInf(weight, 0) || math.IsNaN(weight) {
+			return fmt.Errorf("the weight of \"%s\" in the basket is not positive", currencyCode)
+		}
+	}
+
+	return nil
+}
+
+// ValueIn returns the value of the basket with the rates of table.
+func (basket *Basket) ValueIn(table *RateTable) (float64, error) {
+
+	if err := basket.validate(); err != nil {
+		return 0, err
+	}
+
+	total, logSum := 0.0, 0.0
+	for currencyCode, weight := range basket.Weights {
+		rate, ok := table.Get(currencyCode)
+		if !ok || rate <= 0 {
+			return 0, fmt.Errorf("no rate of \"%s\" was published on %s",
+				strings.ToUpper(currencyCode), table.Date.Format("2006-01-02"))
+		}
+		total += weight
+		logSum += weight * math.Log(rate)
+	}
+
+	return math.Exp(logSum / total), nil
+}
+
+// Value returns the value of the basket on date, with the rates of
+// Source.RatesOn.
+func (basket *Basket) Value(date time.Time) (*BasketValue, error) {
+	return basket.ValueContext(context.Background(), date)
+}
+
+// ValueContext is like Value, with the requests bound to ctx.
+func (basket *Basket) ValueContext(ctx context.Context, date time.Time) (*BasketValue, error) {
+
+	if err := basket.validate(); err != nil {
+		return nil, err
+	}
+
+	table, err := basket.Source.RatesOnContext(ctx, date)
+	if err != nil {
+		return nil, err
+	}
+
+	value, err := basket.ValueIn(table)
+	if err != nil {
+		return nil, err
+	}
+
+	rates := make(map[string]float64, len(basket.Weights))
+	for currencyCode := range basket.Weights {
+		rates[strings.ToUpper(currencyCode)], _ = table.Get(currencyCode)
+	}
+
+	return &BasketValue{
+		Requested: truncateDay(date),
+		Date:      table.Date,
+		Value:     value,
+		Rates:     rates,
+	}, nil
+}
+
+// History returns the values of the basket between from and to, both
+// inclusive, on the publications quoting all its currencies, from the
+// oldest to the most recent, as a series named Name.
+func (basket *Basket) History(from, to time.Time) (*Series, error) {
+	return basket.HistoryContext(context.Background(), from, to)
+}
+
+// HistoryContext is like History, with the requests bound to ctx.
+func (basket *Basket) HistoryContext(ctx context.Context, from, to time.Time) (*Series, error) {
+
+	if err := basket.validate(); err != nil {
+		return nil, err
+	}
+
+	// the rates of every currency of the basket, by publication
+	tables := map[time.Time]*RateTable{}
+	for currencyCode := range basket.Weights {
+		if strings.EqualFold(currencyCode, "EUR") {
+			continue
+		}
+		series, err := basket.Source.HistoryRangeContext(ctx, currencyCode, from, to)
+		if err != nil {
+			return nil, err
+		}
+		for _, point := range series.Points {
+			table, ok := tables[point.Date]
+			if !ok {
+				table = &RateTable{Date: point.Date, Rates: map[string]float64{}}
+				tables[point.Date] = table
+			}
+			table.Rates[series.Currency] = point.Rate
+		}
+	}
+
+	series := &Series{Currency: basket.Name, Points: []Point{}}
+	for date, table := range tables {
+		value, err := basket.ValueIn(table)
+		if err != nil {
+			// a currency of the basket was not quoted then
+			continue
+		}
+		series.Points = append(series.Points, Point{Date: date, Rate: value})
+	}
+	sort.Slice(series.Points, func(i, j int) bool {
+		return series.Points[i].Date.Before(series.Points[j].Date)
+	})
+
+	return series, nil
+}
@@ -0,0 +1,82 @@
+//
+// Copyright 2023 The GoEurofxref Authors. All rights reserved.
+// Use of this source code is governed by a MIT License
+// license that can be found in the LICENSE file.
+// Last Modification: 2026-10-14 19:32:21
+//
+
+package eurofxref
+
+import (
+	"math"
+	"testing"
+)
+
+func TestBasketValue(t *testing.T) {
+
+	_, query := newTestServer(t)
+	basket := &Basket{
+		Weights: map[string]float64{"USD": 50, "GBP": 30, "chf": 20},
+		Source:  query,
+	}
+
+	date, _ := parseDate("2024-02-29")
+	value, err := basket.Value(date)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := math.Pow(1.0796, 0.5) * math.Pow(0.8549, 0.3) * math.Pow(0.9618, 0.2)
+	if math.Abs(value.Value-want) > 1e-12 || !value.Date.Equal(date) || value.Rates["CHF"] != 0.9618 {
+		t.Errorf("got %+v, want the value %v on %v", value, want, date)
+	}
+
+	// the same basket with normalized weights, on a Saturday
+	basket.Weights = map[string]float64{"USD": 0.5, "GBP": 0.3, "CHF": 0.2}
+	saturday := date.AddDate(0, 0, -5)
+	value, err = basket.Value(saturday)
+	if err != nil || value.Date.Format("2006-01-02") != "2024-02-23" || !value.Requested.Equal(saturday) {
+		t.Errorf("got %+v (%v), want the value of 2024-02-23", value, err)
+	}
+
+	for name, weights := range map[string]map[string]float64{
+		"empty":    {},
+		"unknown":  {"XXX": 1},
+		"negative": {"USD": 1, "GBP": -1},
+	} {
+		basket.Weights = weights
+		if _, err := basket.Value(date); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
+
+func TestBasketHistory(t *testing.T) {
+
+	_, query := newTestServer(t)
+	basket := &Basket{
+		Name:    "treasury",
+		Weights: map[string]float64{"USD": 0.5, "EUR": 0.5},
+		Source:  query,
+	}
+
+	from, _ := parseDate("2024-02-23")
+	to, _ := parseDate("2024-02-29")
+	series, err := basket.History(from, to)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	usd, err := query.HistoryRange("USD", from, to)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if series.Currency != "treasury" || len(series.Points) != len(usd.Points) {
+		t.Fatalf("got %+v, want a point per publication of %v", series, usd.Points)
+	}
+	for i, point := range series.Points {
+		if want := math.Sqrt(usd.Points[i].Rate); !point.Date.Equal(usd.Points[i].Date) ||
+			math.Abs(point.Rate-want) > 1e-12 {
+			t.Errorf("got %+v, want %v on %v", point, want, usd.Points[i].Date)
+		}
+	}
+}
@@ -0,0 +1,91 @@
+//
+// Copyright 2023 The GoEurofxref Authors. All rights reserved.
+// Use of this source code is governed by a MIT License
+// license that can be found in the LICENSE file.
+// Last Modification: 2026-10-14 18:49:52
+//
+
+package eurofxref
+
+import (
+	"fmt"
+	"time"
+)
+
+// DefaultBreakerCooldown is the time the circuit stays open when
+// BreakerCooldown is zero.
+const DefaultBreakerCooldown = time.Minute
+
+// CircuitOpenError is returned without any request while the circuit
+// breaker is open and no cached copy of the file is available.
+type CircuitOpenError struct {
+	Url   string
+	Until time.Time // when requests are allowed again
+}
+
+func (e *CircuitOpenError) Error() string {
+	return fmt.Sprintf("the circuit breaker is open until %s, \"%s\" was not requested",
+		e.Until.Format(time.RFC3339), e.Url)
+}
+
+// breaker counts the consecutive download failures of a client.
+type breaker struct {
+	failures int
+	until    time.Time // open until then
+}
+
+// circuitOpen returns the time until which the circuit is open, if it is.
+func (s *state) circuitOpen(now time.Time) (time.Time, bool) {
+
+	if s == nil {
+		return time.Time{}, false
+	}
+
+	s.breakerMu.Lock()
+	defer s.breakerMu.Unlock()
+
+	return s.breaker.until, now.Before(s.breaker.until)
+}
+
+// downloadFailed records a failure, opening the circuit for cooldown at
+// the threshold-th consecutive one. It reports whether it opened.
+func (s *state) downloadFailed(threshold int, cooldown time.Duration, now time.Time) bool {
+
+	if s == nil || threshold <= 0 {
+		return false
+	}
+
+	s.breakerMu.Lock()
+	defer s.breakerMu.Unlock()
+
+	s.breaker.failures++
+	if s.breaker.failures < threshold {
+		return false
+	}
+
+	// after the cooldown a single failed attempt opens it again
+	s.breaker.until = now.Add(cooldown)
+
+	return true
+}
+
+func (s *state) downloadSucceeded() {
+
+	if s == nil {
+		return
+	}
+
+	s.breakerMu.Lock()
+	s.breaker = breaker{}
+	s.breakerMu.Unlock()
+}
+
+// breakerCooldown returns BreakerCooldown or its default.
+func (efr EuroFxRef) breakerCooldown() time.Duration {
+
+	if efr.BreakerCooldown > 0 {
+		return efr.BreakerCooldown
+	}
+
+	return DefaultBreakerCooldown
+}
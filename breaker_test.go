@@ -0,0 +1,91 @@
+//
+// Copyright 2023 The GoEurofxref Authors. All rights reserved.
+// Use of this source code is governed by a MIT License
+// license that can be found in the LICENSE file.
+// Last Modification: 2026-10-15 13:00:00
+//
+
+package eurofxref
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestCircuitBreaker(t *testing.T) {
+
+	var requests, failing atomic.Int32
+	failing.Store(1)
+	files := http.FileServer(http.Dir("testdata"))
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		if failing.Load() == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		files.ServeHTTP(w, r)
+	}))
+	defer ts.Close()
+
+	query := New(t.TempDir(), false)
+	query.Url = ts.URL + "/eurofxref-daily.xml"
+	query.Hist90Url = ts.URL + "/eurofxref-hist-90d.xml"
+	query.BreakerThreshold = 2
+	query.BreakerCooldown = time.Hour
+
+	for i := 0; i < 2; i++ {
+		if _, err := query.Daily("USD"); err == nil {
+			t.Fatal("expected an error from the failing server")
+		}
+	}
+	_, err := query.Daily("USD")
+	var open *CircuitOpenError
+	if !errors.As(err, &open) || open.Until.Before(time.Now().Add(59*time.Minute)) {
+		t.Fatalf("got %v, want a *CircuitOpenError", err)
+	}
+	if requests.Load() != 2 {
+		t.Errorf("the server was requested %d times, want 2", requests.Load())
+	}
+
+	// an expired copy is served while the circuit is open
+	data, err := os.ReadFile("testdata/eurofxref-daily.xml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	cached := query.CachePath(query.Url)
+	if err := os.WriteFile(cached, data, 0644); err != nil {
+		t.Fatal(err)
+	}
+	old := time.Now().AddDate(0, 0, -2)
+	os.Chtimes(cached, old, old)
+	if result, err := query.Daily("USD"); err != nil || result.RateValue != 1.0876 {
+		t.Errorf("got %v, %v from the expired cache", result, err)
+	}
+	if requests.Load() != 2 {
+		t.Errorf("the server was requested %d times with the circuit open", requests.Load())
+	}
+
+	// after the cooldown a success closes it
+	query.state.breaker.until = time.Now()
+	failing.Store(0)
+	if _, err := query.History("USD"); err != nil {
+		t.Fatal(err)
+	}
+	if query.state.breaker.failures != 0 {
+		t.Errorf("got %d failures after a success", query.state.breaker.failures)
+	}
+
+	// the expired copy was not kept in memory, the daily file is fetched
+	before := requests.Load()
+	if _, err := query.Daily("USD"); err != nil {
+		t.Fatal(err)
+	}
+	if requests.Load() == before {
+		t.Error("the expired copy served with the circuit open was memoized")
+	}
+}
@@ -0,0 +1,85 @@
+//
+// Copyright 2023 The GoEurofxref Authors. All rights reserved.
+// Use of this source code is governed by a MIT License
+// license that can be found in the LICENSE file.
+// Last Modification: 2026-10-14 18:50:26
+//
+
+package eurofxref
+
+import (
+	"fmt"
+	"time"
+)
+
+// BudgetExceededError is returned without any request when the fetch
+// budget of the period is spent and no cached copy of the file is
+// available.
+type BudgetExceededError struct {
+	Url     string
+	Limit   int
+	Period  time.Duration
+	RetryAt time.Time // when the oldest fetch of the period expires
+}
+
+func (e *BudgetExceededError) Error() string {
+	return fmt.Sprintf("the budget of %d fetches per %v is spent until %s, \"%s\" was not requested",
+		e.Limit, e.Period, e.RetryAt.Format(time.RFC3339), e.Url)
+}
+
+// budgetPeriods are the periods of the fetch budgets.
+const (
+	budgetHour = time.Hour
+	budgetDay  = 24 * time.Hour
+)
+
+// spendBudget records a fetch at now, unless it would exceed one of the
+// limits of fetches per hour and per day, which are unlimited when zero.
+func (s *state) spendBudget(fileUrl string, perHour, perDay int, now time.Time) error {
+
+	if s == nil || (perHour <= 0 && perDay <= 0) {
+		return nil
+	}
+
+	s.budgetMu.Lock()
+	defer s.budgetMu.Unlock()
+
+	// the fetches of the last day, the oldest first
+	kept := s.fetches[:0]
+	for _, t := range s.fetches {
+		if now.Sub(t) < budgetDay {
+			kept = append(kept, t)
+		}
+	}
+	s.fetches = kept
+
+	lastHour := 0
+	for _, t := range s.fetches {
+		if now.Sub(t) < budgetHour {
+			lastHour++
+		}
+	}
+
+	if perHour > 0 && lastHour >= perHour {
+		oldest := s.fetches[len(s.fetches)-lastHour]
+		return &BudgetExceededError{fileUrl, perHour, budgetHour, oldest.Add(budgetHour)}
+	}
+	if perDay > 0 && len(s.fetches) >= perDay {
+		return &BudgetExceededError{fileUrl, perDay, budgetDay, s.fetches[0].Add(budgetDay)}
+	}
+
+	s.fetches = append(s.fetches, now)
+
+	return nil
+}
+
+// allowFetch returns an error when the file must not be downloaded now,
+// because the circuit breaker is open or the fetch budget is spent.
+func (efr EuroFxRef) allowFetch(fileUrl string, now time.Time) error {
+
+	if until, open := efr.state.circuitOpen(now); open {
+		return &CircuitOpenError{Url: fileUrl, Until: until}
+	}
+
+	return efr.state.spendBudget(fileUrl, efr.MaxFetchesPerHour, efr.MaxFetchesPerDay, now)
+}
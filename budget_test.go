@@ -0,0 +1,67 @@
+//
+// Copyright 2023 The GoEurofxref Authors. All rights reserved.
+// Use of this source code is governed by a MIT License
+// license that can be found in the LICENSE file.
+// Last Modification: 2026-10-14 18:50:26
+//
+
+package eurofxref
+
+import (
+	"errors"
+	"os"
+	"testing"
+	"time"
+)
+
+func TestFetchBudget(t *testing.T) {
+
+	_, query := newTestServer(t)
+	query.CacheDir = t.TempDir()
+	query.CreateCacheDir = true
+	query.MaxFetchesPerHour = 2
+
+	// two downloads, then the cache of the daily file is still fresh
+	for i := 0; i < 3; i++ {
+		if _, err := query.Daily("USD"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := query.History("USD"); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err := query.RateOn("USD", time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC))
+	var exceeded *BudgetExceededError
+	if !errors.As(err, &exceeded) || exceeded.Limit != 2 || exceeded.Period != time.Hour ||
+		exceeded.Url != query.HistUrl {
+		t.Fatalf("got %v, want a *BudgetExceededError", err)
+	}
+
+	// the expired copy is served once the budget is spent
+	old := time.Now().AddDate(0, 0, -2)
+	os.Chtimes(query.CachePath(query.Url), old, old)
+	if result, err := query.Daily("USD"); err != nil || result.RateValue != 1.0876 {
+		t.Errorf("got %v, %v from the expired cache", result, err)
+	}
+}
+
+func TestSpendBudget(t *testing.T) {
+
+	s := &state{}
+	now := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	for i := 0; i < 3; i++ {
+		if err := s.spendBudget("u", 0, 3, now.Add(time.Duration(i)*time.Hour)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	err := s.spendBudget("u", 0, 3, now.Add(5*time.Hour))
+	var exceeded *BudgetExceededError
+	if !errors.As(err, &exceeded) || !exceeded.RetryAt.Equal(now.Add(24*time.Hour)) {
+		t.Fatalf("got %v, want the budget spent until the next day", err)
+	}
+	if err := s.spendBudget("u", 0, 3, now.Add(24*time.Hour)); err != nil {
+		t.Errorf("the oldest fetch did not expire: %v", err)
+	}
+}
@@ -0,0 +1,318 @@
+//
+// Copyright 2023 The GoEurofxref Authors. All rights reserved.
+// Use of this source code is governed by a MIT License
+// license that can be found in the LICENSE file.
+// Last Modification: 2026-10-15 14:15:00
+//
+
+package eurofxref
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io/fs"
+	"log/slog"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// CacheFS stores the cached files, by their base name at its root. The
+// cache directory is one, and an in-memory file system lets the tests or
+// the environments without a writable disk cache the files elsewhere.
+//
+// The cached files expire by their modification time. When the CacheFS
+// also has a SetModTime(name string, modTime time.Time) error method, the
+// files written are dated by the Clock of the client.
+type CacheFS interface {
+	fs.FS
+	// WriteFile creates or replaces the file name with data, so that the
+	// readers never see it partially written.
+	WriteFile(name string, data []byte) error
+	// Remove deletes the file name.
+	Remove(name string) error
+}
+
+// DirFS returns the CacheFS of the directory dir, the one of CacheDir.
+func DirFS(dir string) CacheFS {
+	return dirFS{FS: os.DirFS(dir), dir: dir}
+}
+
+type dirFS struct {
+	fs.FS
+	dir string
+}
+
+func (d dirFS) WriteFile(name string, data []byte) error {
+	return writeFileAtomic(filepath.Join(d.dir, filepath.FromSlash(name)), data)
+}
+
+func (d dirFS) Remove(name string) error {
+	return os.Remove(filepath.Join(d.dir, filepath.FromSlash(name)))
+}
+
+func (d dirFS) SetModTime(name string, modTime time.Time) error {
+	return os.Chtimes(filepath.Join(d.dir, filepath.FromSlash(name)), modTime, modTime)
+}
+
+// modTimeSetter is the CacheFS able to date its files.
+type modTimeSetter interface {
+	SetModTime(name string, modTime time.Time) error
+}
+
+// writeCache writes the file name to the cache, dated by the Clock of the
+// client when the cache can, so that it expires by that clock.
+func (efr EuroFxRef) writeCache(cache CacheFS, name string, data []byte) error {
+
+	if err := cache.WriteFile(name, data); err != nil {
+		return err
+	}
+	if setter, ok := cache.(modTimeSetter); ok && efr.Clock != nil {
+		return setter.SetModTime(name, efr.Now())
+	}
+
+	return nil
+}
+
+// cacheFS returns the storage of the cached files, CacheFS or else the
+// cache directory, or nil without a cache.
+func (efr EuroFxRef) cacheFS() CacheFS {
+
+	if efr.CacheFS != nil {
+		return efr.CacheFS
+	}
+	if efr.CacheDir == "" {
+		return nil
+	}
+
+	return DirFS(efr.CacheDir)
+}
+
+// readCached returns the content of the cached file name, decompressed.
+func readCached(cache CacheFS, name string) ([]byte, error) {
+
+	data, err := fs.ReadFile(cache, name)
+	if err != nil {
+		return nil, err
+	}
+
+	return gunzip(data)
+}
+
+// cacheFilename returns the name of the cached copy of the file at
+// fileUrl: its base name, which tells the feed, followed by a hash of the
+// whole URL, so that the same file name at different URLs is cached
+// separately ("eurofxref-daily-3f2a9c0d1b7e.xml").
+func cacheFilename(fileUrl *url.URL) string {
+
+	sum := sha256.Sum256([]byte(fileUrl.String()))
+	base := path.Base(fileUrl.Path)
+	ext := path.Ext(base)
+
+	return strings.TrimSuffix(base, ext) + "-" + hex.EncodeToString(sum[:6]) + ext
+}
+
+// cacheName returns the name in the cache of the file at fileUrl, or an
+// empty string if it is not a URL.
+func cacheName(fileUrl string) string {
+
+	u, err := url.Parse(fileUrl)
+	if err != nil {
+		return ""
+	}
+
+	return cacheFilename(u)
+}
+
+// CachePath returns the path of the cached copy of the file at fileUrl,
+// e.g. to seed the cache of an Offline client, its name in CacheFS when
+// set, or an empty string without a cache directory.
+func (efr EuroFxRef) CachePath(fileUrl string) string {
+
+	u, err := url.Parse(fileUrl)
+	if efr.cacheFS() == nil || err != nil {
+		return ""
+	}
+	if efr.CacheFS != nil {
+		return cacheFilename(u)
+	}
+
+	return filepath.Join(efr.CacheDir, cacheFilename(u))
+}
+
+// CacheEntry is a cached file of a client.
+type CacheEntry struct {
+	// Url is the address of the file, and Name the one of its copy in the
+	// cache, ending with ".gob" for the parsed copy of a historical file.
+	Url  string
+	Name string
+	Size int64
+	// Age is the time since the file was downloaded or parsed, and
+	// Expired tells it is downloaded again on the next read.
+	Age     time.Duration
+	Expired bool
+}
+
+// CacheStats describes the cache of a client: the cached files and the
+// reads answered from the cache (Hits), from a cached file or from the
+// rates kept in memory, or downloaded (Misses) since it was created.
+type CacheStats struct {
+	Entries []CacheEntry
+	Size    int64 // of the entries
+	Hits    int64
+	Misses  int64
+}
+
+// cacheUrls returns the addresses of the files cached by the client.
+func (efr EuroFxRef) cacheUrls() []string {
+
+	urls := []string{}
+	seen := map[string]bool{}
+	for _, fileUrl := range []string{efr.Url, efr.Hist90Url, efr.HistUrl, efr.ZipUrl, efr.HistZipUrl} {
+		if fileUrl != "" && !seen[fileUrl] {
+			seen[fileUrl] = true
+			urls = append(urls, fileUrl)
+		}
+	}
+
+	return urls
+}
+
+// CacheStats returns the files of the client in its cache and the hits
+// and misses of the cache, e.g. for the operators or the tests.
+func (efr EuroFxRef) CacheStats() CacheStats {
+
+	stats := CacheStats{Entries: []CacheEntry{}}
+	stats.Hits, stats.Misses = efr.state.cacheCounters()
+
+	cache := efr.cacheFS()
+	if cache == nil {
+		return stats
+	}
+
+	now := efr.Now()
+	for _, fileUrl := range efr.cacheUrls() {
+		name := cacheName(fileUrl)
+		for _, name := range []string{name, name + ".gob"} {
+			info, err := fs.Stat(cache, name)
+			if err != nil {
+				continue
+			}
+			stats.Entries = append(stats.Entries, CacheEntry{
+				Url:     fileUrl,
+				Name:    name,
+				Size:    info.Size(),
+				Age:     now.Sub(info.ModTime()),
+				Expired: !sameLocalDay(info.ModTime(), now),
+			})
+			stats.Size += info.Size()
+		}
+	}
+
+	return stats
+}
+
+// Purge deletes the files of the client from its cache, and the rates
+// kept in memory, so that the next reads download them again. The files
+// cached for other URLs in the same directory are kept.
+func (efr EuroFxRef) Purge() error {
+	return efr.removeCached(func(fileUrl string, content []byte) bool { return true })
+}
+
+// Invalidate is Purge for the cached files holding the publication of
+// date, e.g. after the ECB corrected its rates. A cached file that can no
+// longer be parsed is deleted as well.
+func (efr EuroFxRef) Invalidate(date time.Time) error {
+
+	day := truncateDay(date).Format("2006-01-02")
+
+	return efr.removeCached(func(fileUrl string, content []byte) bool {
+		found := false
+		var err error
+		if fileUrl == efr.ZipUrl || fileUrl == efr.HistZipUrl {
+			err = decodeZipCSV(content, func(date time.Time, rates map[string]float64) error {
+				found = date.Format("2006-01-02") == day
+				if found {
+					return errStopIteration
+				}
+				return nil
+			})
+		} else {
+			err = decodeCubes(bytes.NewReader(content), func(cube TimeCube) error {
+				found = cube.Time == day
+				if found {
+					return errStopIteration
+				}
+				return nil
+			})
+		}
+		return found || (err != nil && !errors.Is(err, errStopIteration))
+	})
+}
+
+// removeCached deletes the cached files of the client, and their parsed
+// copies, whose content matches.
+func (efr EuroFxRef) removeCached(match func(fileUrl string, content []byte) bool) error {
+
+	cache := efr.cacheFS()
+	if cache == nil {
+		return nil
+	}
+
+	var errs []error
+	for _, fileUrl := range efr.cacheUrls() {
+		name := cacheName(fileUrl)
+		content, err := readCached(cache, name)
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		if err == nil && !match(fileUrl, content) {
+			continue
+		}
+		for _, name := range []string{name, name + ".gob"} {
+			if err := cache.Remove(name); err != nil && !errors.Is(err, fs.ErrNotExist) {
+				errs = append(errs, err)
+			}
+		}
+		efr.debug(DebugCache, "cache purged", slog.String("file", name))
+	}
+	efr.state.forget(efr.memoKey())
+	// nor are the rates kept by the background refresh answered
+	efr.state.dropTable()
+
+	return errors.Join(errs...)
+}
+
+// countCache counts a read answered from the cache, or downloaded.
+func (s *state) countCache(hit bool) {
+
+	if s == nil {
+		return
+	}
+
+	s.cacheMu.Lock()
+	defer s.cacheMu.Unlock()
+
+	if hit {
+		s.cacheHits++
+	} else {
+		s.cacheMisses++
+	}
+}
+
+func (s *state) cacheCounters() (hits, misses int64) {
+
+	if s == nil {
+		return 0, 0
+	}
+
+	s.cacheMu.Lock()
+	defer s.cacheMu.Unlock()
+
+	return s.cacheHits, s.cacheMisses
+}
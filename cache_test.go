@@ -0,0 +1,184 @@
+//
+// Copyright 2023 The GoEurofxref Authors. All rights reserved.
+// Use of this source code is governed by a MIT License
+// license that can be found in the LICENSE file.
+// Last Modification: 2026-10-15 14:15:00
+//
+
+package eurofxref
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCachePath(t *testing.T) {
+
+	query := New("/var/cache/eurofxref", false)
+
+	ecb := query.CachePath("https://www.ecb.europa.eu/stats/eurofxref/eurofxref-daily.xml")
+	mirror := query.CachePath("https://mirror.example.com/ecb/eurofxref-daily.xml")
+
+	if ecb == mirror {
+		t.Errorf("the same file name at two URLs shares the cache path %s", ecb)
+	}
+	name := filepath.Base(ecb)
+	if filepath.Dir(ecb) != "/var/cache/eurofxref" || !strings.HasPrefix(name, "eurofxref-daily-") ||
+		!strings.HasSuffix(name, ".xml") {
+		t.Errorf("unexpected cache path %s", ecb)
+	}
+
+	query.CacheDir = ""
+	if got := query.CachePath(query.Url); got != "" {
+		t.Errorf("got %q without a cache directory", got)
+	}
+}
+
+func TestCacheKeysDoNotCollide(t *testing.T) {
+
+	serve := func(file string) *httptest.Server {
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			http.ServeFile(w, r, file)
+		}))
+		t.Cleanup(ts.Close)
+		return ts
+	}
+	daily := serve("testdata/eurofxref-daily.xml")
+	other := serve("testdata/eurofxref-hist-90d.xml")
+
+	cacheDir := t.TempDir()
+
+	first := New(cacheDir, false)
+	first.Url = daily.URL + "/eurofxref-daily.xml"
+	second := New(cacheDir, false)
+	second.Url = other.URL + "/eurofxref-daily.xml"
+
+	if table, err := first.DailyRates(); err != nil || table.Date.Format("2006-01-02") != "2024-03-01" {
+		t.Fatalf("got %v, %v", table, err)
+	}
+	// the most recent publication of the 90-day file is the same date, but
+	// it has to be downloaded, not read from the cache of the first client
+	ts := other.Config.Handler
+	hits := 0
+	other.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits++
+		ts.ServeHTTP(w, r)
+	})
+	if _, err := second.DailyRates(); err != nil {
+		t.Fatal(err)
+	}
+	if hits != 1 {
+		t.Errorf("the second URL was requested %d times, want 1", hits)
+	}
+}
+
+func TestCompressedCache(t *testing.T) {
+
+	_, query := newTestServer(t)
+	query.CacheDir = t.TempDir()
+
+	if _, err := query.History("USD"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := query.Daily("USD"); err != nil {
+		t.Fatal(err)
+	}
+
+	hist, err := os.ReadFile(query.CachePath(query.Hist90Url))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(hist) < 2 || hist[0] != 0x1f || hist[1] != 0x8b {
+		t.Error("the cached 90-day file is not compressed")
+	}
+	if daily, _ := os.ReadFile(query.CachePath(query.Url)); !bytes.HasPrefix(daily, []byte("<?xml")) {
+		t.Error("the cached daily file is not plain XML")
+	}
+
+	query.Offline = true
+	series, err := query.History("USD")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(series.Points) != 10 {
+		t.Errorf("got %d points from the compressed cache, want 10", len(series.Points))
+	}
+}
+
+func TestCacheStatsAndPurge(t *testing.T) {
+
+	_, query := newTestServer(t)
+	query.CacheDir = t.TempDir()
+
+	for i := 0; i < 2; i++ {
+		if _, err := query.Daily("USD"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := query.History("USD"); err != nil {
+		t.Fatal(err)
+	}
+
+	stats := query.CacheStats()
+	if stats.Hits != 1 || stats.Misses != 2 {
+		t.Errorf("got %d hits and %d misses, want 1 and 2", stats.Hits, stats.Misses)
+	}
+	names := []string{}
+	size := int64(0)
+	for _, entry := range stats.Entries {
+		names = append(names, entry.Name)
+		size += entry.Size
+		if entry.Expired || entry.Age < 0 || entry.Age > time.Minute {
+			t.Errorf("unexpected age of %+v", entry)
+		}
+	}
+	hist90 := filepath.Base(query.CachePath(query.Hist90Url))
+	want := []string{filepath.Base(query.CachePath(query.Url)), hist90, hist90 + ".gob"}
+	if strings.Join(names, " ") != strings.Join(want, " ") || stats.Size != size {
+		t.Errorf("got the entries %v of %d bytes, want %v", names, stats.Size, want)
+	}
+
+	// no cached file holds the publications of that day
+	if err := query.Invalidate(PublicationDate(2024, 2, 10)); err != nil {
+		t.Fatal(err)
+	}
+	if got := len(query.CacheStats().Entries); got != 3 {
+		t.Errorf("got %d entries, want 3", got)
+	}
+
+	// only the historical file holds those of 28 February
+	if err := query.Invalidate(PublicationDate(2024, 2, 28)); err != nil {
+		t.Fatal(err)
+	}
+	if entries := query.CacheStats().Entries; len(entries) != 1 || entries[0].Url != query.Url {
+		t.Errorf("got %+v, want the daily file only", entries)
+	}
+
+	// the rates kept in memory are purged too
+	if err := query.Start(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	defer query.Stop()
+	if err := query.Purge(); err != nil {
+		t.Fatal(err)
+	}
+	if got := len(query.CacheStats().Entries); got != 0 {
+		t.Errorf("got %d entries after the purge", got)
+	}
+	if table, ok := query.state.warmTable(); ok {
+		t.Errorf("the rates of %v were kept after the purge", table.Date)
+	}
+	if _, err := query.Daily("USD"); err != nil {
+		t.Fatal(err)
+	}
+	if stats := query.CacheStats(); stats.Misses != 4 {
+		t.Errorf("got %d misses, want the daily file downloaded again", stats.Misses)
+	}
+}
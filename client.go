@@ -0,0 +1,211 @@
+//
+// Copyright 2023 The GoEurofxref Authors. All rights reserved.
+// Use of this source code is governed by a MIT License
+// license that can be found in the LICENSE file.
+// Last Modification: 2026-10-15 10:20:00
+//
+
+package eurofxref
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sync"
+)
+
+// transportKey identifies the configuration of a transport.
+type transportKey struct {
+	proxyUrl  string
+	tlsConfig *tls.Config
+}
+
+// transports are the transports of the requests by configuration, built
+// once so that their connections are reused.
+type transports struct {
+	mu    sync.Mutex
+	byKey map[transportKey]*http.Transport
+}
+
+// sharedTransports serve the clients not created with New.
+var sharedTransports transports
+
+// get returns the transport of key, built on the first request.
+func (t *transports) get(key transportKey) (*http.Transport, error) {
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if transport, ok := t.byKey[key]; ok {
+		return transport, nil
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = http.ProxyFromEnvironment
+
+	if key.proxyUrl != "" {
+		proxyUrl, err := url.Parse(key.proxyUrl)
+		if err != nil || proxyUrl.Host == "" {
+			return nil, fmt.Errorf("invalid proxy url \"%s\"", key.proxyUrl)
+		}
+		transport.Proxy = http.ProxyURL(proxyUrl)
+	}
+
+	if key.tlsConfig != nil {
+		transport.TLSClientConfig = key.tlsConfig.Clone()
+	}
+
+	if t.byKey == nil {
+		t.byKey = map[transportKey]*http.Transport{}
+	}
+	t.byKey[key] = transport
+
+	return transport, nil
+}
+
+// httpClient returns the client of the requests to the ECB, configured
+// by the fields of efr. Its transport is shared by the copies of the
+// client with the same ProxyUrl and TLSConfig.
+func (efr EuroFxRef) httpClient() (*http.Client, error) {
+
+	shared := &sharedTransports
+	if efr.state != nil {
+		shared = &efr.state.transports
+	}
+	transport, err := shared.get(transportKey{proxyUrl: efr.ProxyUrl, tlsConfig: efr.TLSConfig})
+	if err != nil {
+		return nil, err
+	}
+
+	return &http.Client{
+		Transport: transport,
+		Timeout:   efr.Timeout,
+	}, nil
+}
+
+// statusError is returned by get for the responses other than 200.
+type statusError struct {
+	Url        string
+	StatusCode int
+}
+
+func (e *statusError) Error() string {
+	return fmt.Sprintf("the request get \"%s\" returned an error with status code %d",
+		e.Url, e.StatusCode)
+}
+
+// get downloads the content at fileUrl, which must answer 200.
+func (efr EuroFxRef) get(ctx context.Context, fileUrl string) ([]byte, error) {
+
+	contentBytes, _, err := efr.getConditional(ctx, fileUrl, nil)
+
+	return contentBytes, err
+}
+
+// validators are the ETag and Last-Modified headers of a response, sent
+// back to download the content again only if it changed.
+type validators struct {
+	etag         string
+	lastModified string
+}
+
+// getConditional is like get, with the validators of a previous response
+// of fileUrl unless nil. The validators of the response are returned, and
+// a nil content when it is unchanged (304).
+func (efr EuroFxRef) getConditional(ctx context.Context, fileUrl string,
+	previous *validators) (contentBytes []byte, current *validators, err error) {
+
+	ctx, span := efr.startSpan(ctx, "eurofxref.http.get", attrUrl.String(fileUrl))
+	defer func() { endSpan(span, err) }()
+
+	req, err := http.NewRequestWithContext(ctx, "GET", fileUrl, nil)
+	if err != nil {
+		return nil, nil, fmt.Errorf("client could not create request: %v", err)
+	}
+	if previous != nil {
+		if previous.etag != "" {
+			req.Header.Set("If-None-Match", previous.etag)
+		}
+		if previous.lastModified != "" {
+			req.Header.Set("If-Modified-Since", previous.lastModified)
+		}
+	}
+
+	userAgent := efr.UserAgent
+	if userAgent == "" {
+		userAgent = DefaultUserAgent
+	}
+	req.Header.Set("User-Agent", userAgent)
+	// set explicitly, the response is then decompressed by gunzip
+	req.Header.Set("Accept-Encoding", "gzip")
+
+	client, err := efr.httpClient()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error making http request: %v", err)
+	}
+
+	defer resp.Body.Close()
+
+	span.SetAttributes(attrStatusCode.Int(resp.StatusCode))
+
+	current = &validators{etag: resp.Header.Get("ETag"), lastModified: resp.Header.Get("Last-Modified")}
+	if resp.StatusCode == http.StatusNotModified && previous != nil {
+		return nil, previous, nil
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, nil, &statusError{Url: fileUrl, StatusCode: resp.StatusCode}
+	}
+
+	contentBytes, err = readLimited(resp.Body, efr.maxResponseSize())
+	if err == nil {
+		contentBytes, err = gunzip(contentBytes)
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("client could not read response body: %v", err)
+	}
+
+	return contentBytes, current, nil
+}
+
+// gunzip returns data decompressed when it starts with the gzip magic
+// number, and unchanged otherwise. It handles both the responses sent
+// with "Content-Encoding: gzip" and the gzipped files (.xml.gz) of
+// mirrors or of the cache directory; XML never starts with these bytes.
+func gunzip(data []byte) ([]byte, error) {
+
+	if len(data) < 2 || data[0] != 0x1f || data[1] != 0x8b {
+		return data, nil
+	}
+
+	zr, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	defer zr.Close()
+
+	return readLimited(zr, maxDecompressedSize)
+}
+
+// gzipBytes returns data compressed with gzip.
+func gzipBytes(data []byte) ([]byte, error) {
+
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	if _, err := zw.Write(data); err != nil {
+		return nil, err
+	}
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,203 @@
+//
+// Copyright 2023 The GoEurofxref Authors. All rights reserved.
+// Use of this source code is governed by a MIT License
+// license that can be found in the LICENSE file.
+// Last Modification: 2026-10-15 10:20:00
+//
+
+package eurofxref
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestProxyUrl(t *testing.T) {
+
+	proxied := ""
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		proxied = r.URL.String()
+		http.ServeFile(w, r, "testdata/eurofxref-daily.xml")
+	}))
+	defer proxy.Close()
+
+	query := New("", false)
+	query.CacheDir = ""
+	query.Url = "http://ecb.invalid/stats/eurofxref/eurofxref-daily.xml"
+	query.ProxyUrl = proxy.URL
+
+	result, err := query.Daily("USD")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if proxied != query.Url {
+		t.Errorf("the proxy received %q, want %q", proxied, query.Url)
+	}
+	if result.RateValue != 1.0876 {
+		t.Errorf("got %v, want 1.0876", result.RateValue)
+	}
+
+	query.ProxyUrl = "proxy.example.com:3128"
+	if _, err := query.Daily("USD"); err == nil {
+		t.Error("expected an error for a proxy url without scheme")
+	}
+}
+
+func TestTLSConfig(t *testing.T) {
+
+	ts := httptest.NewTLSServer(http.FileServer(http.Dir("testdata")))
+	defer ts.Close()
+
+	query := New("", false)
+	query.CacheDir = ""
+	query.Url = ts.URL + "/eurofxref-daily.xml"
+
+	if _, err := query.Daily("USD"); err == nil {
+		t.Fatal("expected an error for a certificate of an unknown authority")
+	}
+
+	roots := x509.NewCertPool()
+	roots.AddCert(ts.Certificate())
+	query.TLSConfig = &tls.Config{RootCAs: roots, MinVersion: tls.VersionTLS12}
+
+	if _, err := query.Daily("USD"); err != nil {
+		t.Fatal(err)
+	}
+
+	query.TLSConfig.MinVersion = tls.VersionTLS13
+	query.TLSConfig.MaxVersion = tls.VersionTLS13
+	if _, err := query.Daily("USD"); err != nil {
+		t.Errorf("TLS 1.3 only: %v", err)
+	}
+}
+
+func TestConnectionReuse(t *testing.T) {
+
+	var connections atomic.Int32
+	ts := httptest.NewUnstartedServer(http.FileServer(http.Dir("testdata")))
+	ts.Config.ConnState = func(conn net.Conn, state http.ConnState) {
+		if state == http.StateNew {
+			connections.Add(1)
+		}
+	}
+	ts.Start()
+	defer ts.Close()
+
+	query := New("", false)
+	query.CacheDir = ""
+	query.Url = ts.URL + "/eurofxref-daily.xml"
+
+	for i := 0; i < 3; i++ {
+		if _, err := query.DailyRates(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if got := connections.Load(); got != 1 {
+		t.Errorf("got %d connections, want 1 reused", got)
+	}
+}
+
+func gzipped(t *testing.T, file string) []byte {
+
+	data, err := os.ReadFile(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	zw.Write(data)
+	zw.Close()
+
+	return buf.Bytes()
+}
+
+func TestGzip(t *testing.T) {
+
+	compressed := gzipped(t, "testdata/eurofxref-daily.xml")
+	acceptEncoding := ""
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		acceptEncoding = r.Header.Get("Accept-Encoding")
+		w.Header().Set("Content-Encoding", "gzip")
+		w.Write(compressed)
+	}))
+	defer ts.Close()
+
+	query := New(t.TempDir(), false)
+	query.Url = ts.URL + "/eurofxref-daily.xml"
+
+	result, err := query.Daily("USD")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if acceptEncoding != "gzip" || result.RateValue != 1.0876 {
+		t.Errorf("got %v with Accept-Encoding %q", result.RateValue, acceptEncoding)
+	}
+
+	// a gzipped file dropped in the cache directory
+	query.Hist90Url = ts.URL + "/eurofxref-hist-90d.xml"
+	cached := query.CachePath(query.Hist90Url)
+	if err := os.WriteFile(cached, gzipped(t, "testdata/eurofxref-hist-90d.xml"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	query.Offline = true
+
+	series, err := query.History("USD")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(series.Points) != 10 {
+		t.Errorf("got %d points from the gzipped cache, want 10", len(series.Points))
+	}
+}
+
+func TestTimeouts(t *testing.T) {
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-time.After(2 * time.Second):
+		case <-r.Context().Done():
+		}
+	}))
+	defer ts.Close()
+
+	query := New("", false)
+	query.CacheDir = ""
+	query.Url = ts.URL + "/eurofxref-daily.xml"
+	query.HistUrl = ts.URL + "/eurofxref-hist.xml"
+
+	if New("", false).Timeout != DefaultTimeout || DefaultTimeout != time.Minute {
+		t.Errorf("unexpected default timeout %v", New("", false).Timeout)
+	}
+
+	query.Timeout = 100 * time.Millisecond
+	start := time.Now()
+	if _, err := query.Daily("USD"); err == nil {
+		t.Error("expected the client timeout to expire")
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("the client timeout expired after %v", elapsed)
+	}
+
+	query.Timeout = 0
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	start = time.Now()
+	from := PublicationDate(2024, 2, 1)
+	if _, err := query.HistoryRangeContext(ctx, "USD", from, from.AddDate(0, 1, 0)); err == nil {
+		t.Error("expected the deadline of the context to expire")
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("the deadline expired after %v", elapsed)
+	}
+}
@@ -0,0 +1,51 @@
+//
+// Copyright 2023 The GoEurofxref Authors. All rights reserved.
+// Use of this source code is governed by a MIT License
+// license that can be found in the LICENSE file.
+// Last Modification: 2026-10-14 19:10:05
+//
+
+package eurofxref
+
+import (
+	"time"
+)
+
+// Clock tells the current time to a client. The cache expiry, the
+// publication schedule and the staleness of the rates follow it, so they
+// can be tested at a fixed time.
+type Clock interface {
+	Now() time.Time
+}
+
+// ClockFunc adapts a function to a Clock.
+type ClockFunc func() time.Time
+
+func (fn ClockFunc) Now() time.Time {
+	return fn()
+}
+
+// FixedClock returns a Clock stopped at t.
+func FixedClock(t time.Time) Clock {
+	return ClockFunc(func() time.Time { return t })
+}
+
+// Now returns the current time of the Clock of the client, the time of the
+// system when it is nil.
+func (efr EuroFxRef) Now() time.Time {
+
+	if efr.Clock != nil {
+		return efr.Clock.Now()
+	}
+
+	return time.Now()
+}
+
+// sameLocalDay reports whether a and b are on the same day of the local
+// time zone.
+func sameLocalDay(a, b time.Time) bool {
+
+	a, b = a.Local(), b.Local()
+
+	return a.Year() == b.Year() && a.YearDay() == b.YearDay()
+}
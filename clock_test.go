@@ -0,0 +1,125 @@
+//
+// Copyright 2023 The GoEurofxref Authors. All rights reserved.
+// Use of this source code is governed by a MIT License
+// license that can be found in the LICENSE file.
+// Last Modification: 2026-10-15 18:05:00
+//
+
+package eurofxref
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestCacheExpiryAtMidnight(t *testing.T) {
+
+	ts, query := newTestServer(t)
+	query.CacheDir = t.TempDir()
+
+	if _, err := query.Daily("USD"); err != nil {
+		t.Fatal(err)
+	}
+	downloaded := time.Date(2024, 3, 1, 23, 59, 0, 0, time.Local)
+	if err := os.Chtimes(query.CachePath(query.Url), downloaded, downloaded); err != nil {
+		t.Fatal(err)
+	}
+	ts.Close()
+
+	tests := []struct {
+		name    string
+		now     time.Time
+		expired bool
+	}{
+		{"the same day", downloaded.Add(30 * time.Second), false},
+		{"after midnight", downloaded.Add(90 * time.Second), true},
+		{"the same day of the next month", downloaded.AddDate(0, 1, 0), true},
+	}
+
+	for _, test := range tests {
+		query.state = &state{}
+		query.Clock = FixedClock(test.now)
+		_, err := query.Daily("USD")
+		if expired := err != nil; expired != test.expired {
+			t.Errorf("%s: got the error %v, want expired %v", test.name, err, test.expired)
+		}
+	}
+
+	// the age of the stale rates follows the clock too
+	query.state = &state{}
+	query.ServeStale = true
+	query.Clock = FixedClock(downloaded.Add(26 * time.Hour))
+	result, err := query.Daily("USD")
+	if err != nil || !result.Stale || result.Age != 26*time.Hour {
+		t.Errorf("got %+v (%v), want the rate cached 26 hours ago", result, err)
+	}
+}
+
+func TestCacheDatedByClock(t *testing.T) {
+
+	var requests atomic.Int32
+	files := http.FileServer(http.Dir("testdata"))
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		files.ServeHTTP(w, r)
+	}))
+	defer ts.Close()
+
+	query := New(t.TempDir(), false)
+	query.Url = ts.URL + "/eurofxref-daily.xml"
+	query.Clock = FixedClock(time.Date(2024, 3, 1, 17, 0, 0, 0, CET))
+
+	for i := 0; i < 2; i++ {
+		// read from the file, not from the memo
+		query.state = &state{}
+		if _, err := query.Daily("USD"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if requests.Load() != 1 {
+		t.Errorf("got %d requests, want the file cached at the time of the clock", requests.Load())
+	}
+
+	stats := query.CacheStats()
+	if len(stats.Entries) != 1 || stats.Entries[0].Age != 0 || stats.Entries[0].Expired {
+		t.Errorf("unexpected cache entries %+v", stats.Entries)
+	}
+}
+
+func TestDailyEuroDate(t *testing.T) {
+
+	_, query := newTestServer(t)
+	// a saturday evening in Lisbon, two days after the publication
+	query.Clock = FixedClock(time.Date(2024, 3, 3, 23, 30, 0, 0, time.FixedZone("WET", 0)))
+
+	result, err := query.Daily("eur")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := PublicationDate(2024, 3, 1); !result.LastUpdate.Equal(want) || result.LastUpdate.Location() != CET {
+		t.Errorf("got the euro dated %s, want %s", result.LastUpdate, want)
+	}
+	if result.RateValue != 1.00 || result.Currency != "EUR" {
+		t.Errorf("unexpected result %+v", result)
+	}
+}
+
+func TestNextRefreshAcrossDST(t *testing.T) {
+
+	query := New("", false)
+	query.state.table = &RateTable{Date: PublicationDate(2024, 3, 28)}
+
+	// Thursday after the publication, before Good Friday, the switch to
+	// summer time on Sunday and Easter Monday
+	now := time.Date(2024, 3, 28, 17, 0, 0, 0, CET)
+	query.Clock = FixedClock(now)
+
+	next := query.Now().Add(query.nextRefresh(query.Now(), true))
+	if want := time.Date(2024, 4, 2, 14, 0, 0, 0, time.UTC); !next.Equal(want) {
+		t.Errorf("got the next refresh at %v, want %v", next.UTC(), want)
+	}
+}
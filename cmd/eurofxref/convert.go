@@ -0,0 +1,126 @@
+//
+// Copyright 2023 The GoEurofxref Authors. All rights reserved.
+// Use of this source code is governed by a MIT License
+// license that can be found in the LICENSE file.
+// Last Modification: 2026-10-15 12:30:00
+//
+
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	eurofxref "github.com/mrhdias/go-eurofxref"
+)
+
+func runConvert(args []string) error {
+
+	fs := flag.NewFlagSet("convert", flag.ContinueOnError)
+	opts := options{}
+	opts.register(fs)
+	opts.registerOutput(fs)
+	date := fs.String("date", "", "date of the rates (YYYY-MM-DD); the previous publication is used on non-trading days")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: eurofxref convert [flags] <amount> <from> <to>")
+		fs.PrintDefaults()
+	}
+
+	positional, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if err := opts.validate(); err != nil {
+		return err
+	}
+	if len(positional) != 3 {
+		fs.Usage()
+		return errors.New("expected an amount and two currency codes")
+	}
+
+	amount, err := strconv.ParseFloat(positional[0], 64)
+	if err != nil {
+		return fmt.Errorf("invalid amount \"%s\"", positional[0])
+	}
+	from, to := strings.ToUpper(positional[1]), strings.ToUpper(positional[2])
+
+	var onDate time.Time
+	if *date != "" {
+		if onDate, err = time.Parse("2006-01-02", *date); err != nil {
+			return fmt.Errorf("invalid date \"%s\": expected YYYY-MM-DD", *date)
+		}
+	}
+
+	query, err := opts.query()
+	if err != nil {
+		return err
+	}
+	// the first run can answer without network, and the next ones during
+	// an outage
+	query.EmbeddedFallback = true
+	query.ServeStale = true
+
+	// rounded to the minor units of the target currency
+	convertOptions := eurofxref.ConvertOptions{Rounding: true}
+	var result *eurofxref.ConversionResult
+	if onDate.IsZero() {
+		result, err = query.Convert(amount, from, to, convertOptions)
+	} else {
+		result, err = query.ConvertOn(amount, from, to, onDate, convertOptions)
+	}
+	if err != nil {
+		return err
+	}
+	if result.Embedded {
+		warnEmbedded(result.LastUpdate)
+	}
+	if result.Stale {
+		warnStale(result.LastUpdate, result.Age)
+	}
+
+	format := func(value float64) string { return strconv.FormatFloat(value, 'f', -1, 64) }
+
+	return opts.print(output{
+		json:   result,
+		header: []string{"amount", "from", "to", "value", "rate", "from_rate", "to_rate", "date"},
+		rows: [][]string{{
+			format(result.Amount), result.From, result.To, format(result.Value), format(result.Rate),
+			format(result.FromRate), format(result.ToRate), result.LastUpdate.Format("2006-01-02"),
+		}},
+		text: func() error {
+			printConversion(result)
+			return nil
+		},
+	})
+}
+
+// printConversion prints the converted amount, the rates used and the
+// publication date of the rates.
+func printConversion(result *eurofxref.ConversionResult) {
+
+	minorUnits := eurofxref.LookupRoundingRule(result.To).MinorUnits
+	fmt.Fprintf(stdout, "%s %s = %s %s\n",
+		strconv.FormatFloat(result.Amount, 'f', -1, 64), result.From,
+		strconv.FormatFloat(result.Value, 'f', minorUnits, 64), result.To)
+
+	legs := []string{}
+	for _, leg := range []struct {
+		currency string
+		rate     float64
+	}{{result.From, result.FromRate}, {result.To, result.ToRate}} {
+		if leg.currency != "EUR" {
+			legs = append(legs, fmt.Sprintf("%s %s", leg.currency, strconv.FormatFloat(leg.rate, 'f', -1, 64)))
+		}
+	}
+	fmt.Fprintf(stdout, "rate: %s %s per %s", strconv.FormatFloat(result.Rate, 'f', -1, 64), result.To, result.From)
+	if len(legs) == 2 && result.From != result.To {
+		fmt.Fprintf(stdout, " (%s per EUR)", strings.Join(legs, ", "))
+	}
+	fmt.Fprintln(stdout)
+
+	fmt.Fprintf(stdout, "date: %s\n", result.LastUpdate.Format("2006-01-02"))
+}
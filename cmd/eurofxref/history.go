@@ -0,0 +1,179 @@
+//
+// Copyright 2023 The GoEurofxref Authors. All rights reserved.
+// Use of this source code is governed by a MIT License
+// license that can be found in the LICENSE file.
+// Last Modification: 2026-10-15 13:30:00
+//
+
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	eurofxref "github.com/mrhdias/go-eurofxref"
+)
+
+// sparkWidth is the maximum width of the sparkline, in characters.
+const sparkWidth = 60
+
+func runHistory(args []string) error {
+
+	fs := flag.NewFlagSet("history", flag.ContinueOnError)
+	opts := options{}
+	opts.register(fs)
+	opts.registerOutput(fs)
+	from := fs.String("from", "", "first date of the series (YYYY-MM-DD), 90 days ago by default")
+	to := fs.String("to", "", "last date of the series (YYYY-MM-DD), today by default")
+	chart := fs.Bool("chart", false, "print a sparkline of the series instead of the rates, with the text output")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: eurofxref history [flags] <currency>")
+		fs.PrintDefaults()
+	}
+
+	positional, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if err := opts.validate(); err != nil {
+		return err
+	}
+	if len(positional) != 1 {
+		fs.Usage()
+		return errors.New("expected exactly one currency code")
+	}
+
+	var fromDate, toDate time.Time
+	for _, d := range []struct {
+		flag  string
+		value string
+		date  *time.Time
+	}{{"from", *from, &fromDate}, {"to", *to, &toDate}} {
+		if d.value == "" {
+			continue
+		}
+		if *d.date, err = time.Parse("2006-01-02", d.value); err != nil {
+			return fmt.Errorf("invalid -%s date \"%s\": expected YYYY-MM-DD", d.flag, d.value)
+		}
+	}
+
+	currencyCode := strings.ToUpper(positional[0])
+	query, err := opts.query()
+	if err != nil {
+		return err
+	}
+
+	var series *eurofxref.Series
+	if fromDate.IsZero() && toDate.IsZero() {
+		series, err = query.History(currencyCode)
+	} else {
+		if fromDate.IsZero() {
+			fromDate = toDate.AddDate(0, 0, -90)
+		}
+		if toDate.IsZero() {
+			toDate = query.Now()
+		}
+		series, err = query.HistoryRange(currencyCode, fromDate, toDate)
+	}
+	if err != nil {
+		return err
+	}
+
+	points := make([]jsonPoint, 0, len(series.Points))
+	rows := make([][]string, 0, len(series.Points))
+	for _, point := range series.Points {
+		date := point.Date.Format("2006-01-02")
+		points = append(points, jsonPoint{date, point.Rate})
+		rows = append(rows, []string{series.Currency, date, strconv.FormatFloat(point.Rate, 'f', -1, 64)})
+	}
+
+	return opts.print(output{
+		json: struct {
+			Currency string      `json:"currency"`
+			Points   []jsonPoint `json:"points"`
+		}{series.Currency, points},
+		header: []string{"currency", "date", "rate"},
+		rows:   rows,
+		text: func() error {
+			if *chart {
+				return printChart(series)
+			}
+			for _, row := range rows {
+				fmt.Fprintf(stdout, "%s %s\n", row[1], row[2])
+			}
+			return nil
+		},
+	})
+}
+
+type jsonPoint struct {
+	Date string  `json:"date"`
+	Rate float64 `json:"rate"`
+}
+
+// printChart prints the sparkline of the series with its range and its
+// lowest, highest and last rates.
+func printChart(series *eurofxref.Series) error {
+
+	stats, err := series.Stats()
+	if err != nil {
+		return err
+	}
+
+	first, last := series.Points[0], series.Points[len(series.Points)-1]
+	fmt.Fprintf(stdout, "%s %s .. %s\n", series.Currency,
+		first.Date.Format("2006-01-02"), last.Date.Format("2006-01-02"))
+	fmt.Fprintln(stdout, sparkline(series.Points, sparkWidth))
+	fmt.Fprintf(stdout, "min %s (%s)  max %s (%s)  last %s\n",
+		strconv.FormatFloat(stats.Min.Rate, 'f', -1, 64), stats.Min.Date.Format("2006-01-02"),
+		strconv.FormatFloat(stats.Max.Rate, 'f', -1, 64), stats.Max.Date.Format("2006-01-02"),
+		strconv.FormatFloat(last.Rate, 'f', -1, 64))
+
+	return nil
+}
+
+var sparks = []rune("▁▂▃▄▅▆▇█")
+
+// sparkline draws the rates of the points with block characters, at most
+// width of them: longer series are averaged over buckets of consecutive
+// points.
+func sparkline(points []eurofxref.Point, width int) string {
+
+	if len(points) == 0 {
+		return ""
+	}
+
+	values := make([]float64, 0, width)
+	buckets := len(points)
+	if buckets > width {
+		buckets = width
+	}
+	for i := 0; i < buckets; i++ {
+		start, end := i*len(points)/buckets, (i+1)*len(points)/buckets
+		sum := 0.0
+		for _, point := range points[start:end] {
+			sum += point.Rate
+		}
+		values = append(values, sum/float64(end-start))
+	}
+
+	low, high := values[0], values[0]
+	for _, value := range values {
+		low, high = min(low, value), max(high, value)
+	}
+
+	var b strings.Builder
+	for _, value := range values {
+		level := len(sparks) / 2
+		if high > low {
+			level = int((value - low) / (high - low) * float64(len(sparks)-1))
+		}
+		b.WriteRune(sparks[level])
+	}
+
+	return b.String()
+}
@@ -0,0 +1,230 @@
+//
+// Copyright 2023 The GoEurofxref Authors. All rights reserved.
+// Use of this source code is governed by a MIT License
+// license that can be found in the LICENSE file.
+// Last Modification: 2026-10-14 18:59:18
+//
+
+// Command eurofxref prints the euro foreign exchange reference rates
+// published by the European Central Bank.
+//
+// Usage:
+//
+//	eurofxref <command> [flags] [arguments]
+//
+// The commands are:
+//
+//	rate     print the reference rate of a currency
+//	convert  convert an amount between two currencies
+//	history  print the reference rates of a currency over a period
+//	watch    report the changes of the rates of new publications
+//	serve    serve the reference rates over HTTP
+package main
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"flag"
+	"fmt"
+	"log/slog"
+	"os"
+
+	eurofxref "github.com/mrhdias/go-eurofxref"
+)
+
+const usage = `Usage: eurofxref <command> [flags] [arguments]
+
+Commands:
+  rate <currency>                print the reference rate of a currency
+  convert <amount> <from> <to>   convert an amount between two currencies
+  history <currency>             print the reference rates of a currency over a period
+  watch [currency...]            report the changes of the rates of new publications
+  serve                          serve the reference rates over HTTP
+
+Run "eurofxref <command> -h" for the flags of a command.
+`
+
+type command struct {
+	name string
+	run  func(args []string) error
+}
+
+var commands = []command{
+	{"rate", runRate},
+	{"convert", runConvert},
+	{"history", runHistory},
+	{"watch", runWatch},
+	{"serve", runServe},
+}
+
+// options holds the flags shared by all commands.
+type options struct {
+	cacheDir string
+	output   string
+	debug    bool
+	offline  bool
+	proxy    string
+	caFile   string
+	// configFile is the configuration file, whose settings the flags
+	// given on the command line override.
+	configFile string
+	config     *eurofxref.Config
+	fs         *flag.FlagSet
+}
+
+func (opts *options) register(fs *flag.FlagSet) {
+
+	fs.StringVar(&opts.cacheDir, "cache-dir", eurofxref.DefaultCacheDir(),
+		"directory used to cache the ECB files (empty disables the cache)")
+	fs.BoolVar(&opts.debug, "debug", false, "log the fetch, cache and parse events to stderr")
+	fs.StringVar(&opts.proxy, "proxy", "", "proxy url of the requests (defaults to HTTPS_PROXY)")
+	fs.StringVar(&opts.caFile, "ca-file", "", "PEM file of additional certificate authorities")
+	fs.BoolVar(&opts.offline, "offline", false, "answer from the cache only, without network access")
+	fs.StringVar(&opts.configFile, "config", "", "YAML or TOML configuration file, overridden by the flags given")
+	opts.fs = fs
+}
+
+// loadConfig returns the configuration file, empty without -config.
+func (opts *options) loadConfig() (*eurofxref.Config, error) {
+
+	if opts.config != nil {
+		return opts.config, nil
+	}
+	if opts.configFile == "" {
+		opts.config = &eurofxref.Config{}
+		return opts.config, nil
+	}
+
+	config, err := eurofxref.LoadConfig(opts.configFile)
+	if err != nil {
+		return nil, err
+	}
+	opts.config = config
+
+	return config, nil
+}
+
+// set reports whether the flag overrides the configuration file: always
+// without one, and otherwise when it is given on the command line.
+func (opts *options) set(name string) bool {
+
+	if opts.configFile == "" {
+		return true
+	}
+
+	given := false
+	opts.fs.Visit(func(f *flag.Flag) {
+		if f.Name == name {
+			given = true
+		}
+	})
+
+	return given
+}
+
+// registerOutput registers the flag of the commands that print results.
+func (opts *options) registerOutput(fs *flag.FlagSet) {
+	fs.StringVar(&opts.output, "output", "text", "output format: text, json, csv or table")
+}
+
+func (opts *options) validate() error {
+
+	switch opts.output {
+	case "", "text", "json", "csv", "table":
+		return nil
+	}
+	return fmt.Errorf("unknown output format \"%s\"", opts.output)
+}
+
+// query returns the client configured by the flags.
+func (opts *options) query() (eurofxref.EuroFxRef, error) {
+
+	config, err := opts.loadConfig()
+	if err != nil {
+		return eurofxref.EuroFxRef{}, err
+	}
+
+	query := config.Client()
+	if opts.set("cache-dir") {
+		// an empty -cache-dir disables the cache
+		query.CacheDir = opts.cacheDir
+	}
+	if opts.set("offline") {
+		query.Offline = opts.offline
+	}
+	if opts.set("proxy") {
+		query.ProxyUrl = opts.proxy
+	}
+	if opts.debug {
+		query.Logger = slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{
+			Level: slog.LevelDebug,
+		}))
+	}
+
+	if opts.caFile != "" {
+		pemCerts, err := os.ReadFile(opts.caFile)
+		if err != nil {
+			return query, fmt.Errorf("error reading the CA file: %v", err)
+		}
+		roots, err := x509.SystemCertPool()
+		if err != nil {
+			roots = x509.NewCertPool()
+		}
+		if !roots.AppendCertsFromPEM(pemCerts) {
+			return query, fmt.Errorf("no certificates found in \"%s\"", opts.caFile)
+		}
+		query.TLSConfig = &tls.Config{RootCAs: roots}
+	}
+
+	return query, nil
+}
+
+// parseArgs parses the flags of fs allowing them to be interleaved with
+// the positional arguments, which are returned in order.
+func parseArgs(fs *flag.FlagSet, args []string) ([]string, error) {
+
+	positional := []string{}
+	for {
+		if err := fs.Parse(args); err != nil {
+			return nil, err
+		}
+		args = fs.Args()
+		if len(args) == 0 {
+			return positional, nil
+		}
+		positional = append(positional, args[0])
+		args = args[1:]
+	}
+}
+
+func main() {
+
+	if len(os.Args) < 2 {
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+
+	name := os.Args[1]
+	if name == "-h" || name == "-help" || name == "--help" || name == "help" {
+		fmt.Fprint(os.Stdout, usage)
+		return
+	}
+
+	for _, cmd := range commands {
+		if cmd.name != name {
+			continue
+		}
+		if err := cmd.run(os.Args[2:]); err != nil {
+			if errors.Is(err, flag.ErrHelp) {
+				return
+			}
+			fmt.Fprintf(os.Stderr, "eurofxref %s: %v\n", name, err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	fmt.Fprintf(os.Stderr, "eurofxref: unknown command \"%s\"\n\n%s", name, usage)
+	os.Exit(2)
+}
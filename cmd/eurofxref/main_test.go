@@ -0,0 +1,241 @@
+//
+// Copyright 2023 The GoEurofxref Authors. All rights reserved.
+// Use of this source code is governed by a MIT License
+// license that can be found in the LICENSE file.
+// Last Modification: 2026-10-15 17:40:00
+//
+
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	eurofxref "github.com/mrhdias/go-eurofxref"
+	"github.com/mrhdias/go-eurofxref/eurofxreftest"
+)
+
+// runCommand runs the command of args[0] against the fake ECB with the
+// configuration file, returning what it printed.
+func runCommand(t *testing.T, ecb *eurofxreftest.Server, config string, args ...string) (string, error) {
+
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "eurofxref.yaml")
+	config = fmt.Sprintf("url: %s%s\nhist_url: %s%s\nhist90_url: %s%s\ncache:\n  disabled: true\n%s",
+		ecb.URL, eurofxreftest.DailyPath, ecb.URL, eurofxreftest.HistPath,
+		ecb.URL, eurofxreftest.Hist90Path, config)
+	if err := os.WriteFile(path, []byte(config), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	defer func(w io.Writer) { stdout = w }(stdout)
+	stdout = &buf
+
+	for _, cmd := range commands {
+		if cmd.name == args[0] {
+			err := cmd.run(append([]string{"-config", path}, args[1:]...))
+			return buf.String(), err
+		}
+	}
+	t.Fatalf("unknown command %s", args[0])
+
+	return "", nil
+}
+
+func TestCommands(t *testing.T) {
+
+	ecb := eurofxreftest.NewServer(t)
+
+	for _, test := range []struct {
+		name    string
+		config  string
+		args    []string
+		want    string
+		wantErr string
+	}{
+		{name: "rate", args: []string{"rate", "usd"}, want: "1.0876\n"},
+		{
+			name: "rate json",
+			args: []string{"rate", "-output", "json", "usd"},
+			want: `{"currency":"USD","date":"2024-03-01","rate":1.0876}` + "\n",
+		},
+		{
+			name: "rate csv",
+			args: []string{"rate", "-output", "csv", "usd"},
+			want: "currency,date,rate\nUSD,2024-03-01,1.0876\n",
+		},
+		{
+			name: "rate table",
+			args: []string{"rate", "usd", "-output", "table"},
+			want: "CURRENCY  DATE        RATE\nUSD       2024-03-01  1.0876\n",
+		},
+		{name: "rate on a sunday", args: []string{"rate", "-date", "2024-02-25", "usd"}, want: "1.0824\n"},
+		{name: "rate without currency", args: []string{"rate"}, wantErr: "expected exactly one currency code"},
+		{name: "rate with two currencies", args: []string{"rate", "usd", "gbp"}, wantErr: "expected exactly one currency code"},
+		{name: "rate invalid date", args: []string{"rate", "-date", "01/03/2024", "usd"}, wantErr: "invalid date"},
+		{name: "rate unknown output", args: []string{"rate", "-output", "xml", "usd"}, wantErr: "unknown output format"},
+		{name: "rate unknown currency", args: []string{"rate", "xxx"}, wantErr: "XXX"},
+		{
+			name: "convert",
+			args: []string{"convert", "100", "eur", "usd"},
+			want: "100 EUR = 108.76 USD\nrate: 1.0876 USD per EUR\ndate: 2024-03-01\n",
+		},
+		{
+			name: "convert cross",
+			args: []string{"convert", "100", "usd", "gbp"},
+			want: "100 USD = 78.69 GBP\nrate: 0.7868517837440236 GBP per USD (USD 1.0876, GBP 0.85578 per EUR)\n" +
+				"date: 2024-03-01\n",
+		},
+		{
+			name: "convert csv",
+			args: []string{"convert", "100", "usd", "gbp", "-output", "csv"},
+			want: "amount,from,to,value,rate,from_rate,to_rate,date\n" +
+				"100,USD,GBP,78.69,0.7868517837440236,1.0876,0.85578,2024-03-01\n",
+		},
+		{name: "convert invalid amount", args: []string{"convert", "ten", "eur", "usd"}, wantErr: "invalid amount"},
+		{name: "convert missing currency", args: []string{"convert", "100", "eur"}, wantErr: "expected an amount and two currency codes"},
+		{
+			name: "history",
+			args: []string{"history", "-from", "2024-02-27", "-to", "2024-03-01", "usd"},
+			want: "2024-02-27 1.0884\n2024-02-28 1.0925\n2024-02-29 1.0922\n2024-03-01 1.0876\n",
+		},
+		{
+			name: "history json",
+			args: []string{"history", "usd", "-from", "2024-02-29", "-to", "2024-03-01", "-output", "json"},
+			want: `{"currency":"USD","points":[{"date":"2024-02-29","rate":1.0922},{"date":"2024-03-01","rate":1.0876}]}` + "\n",
+		},
+		{
+			name: "history chart",
+			args: []string{"history", "-from", "2024-02-27", "-to", "2024-03-01", "-chart", "usd"},
+			want: "USD 2024-02-27 .. 2024-03-01\n▂█▇▁\nmin 1.0876 (2024-03-01)  max 1.0925 (2024-02-28)  last 1.0876\n",
+		},
+		{name: "history invalid date", args: []string{"history", "-from", "2024-02-30", "usd"}, wantErr: "invalid -from date"},
+		{name: "history offline", config: "offline: true\n", args: []string{"history", "usd"}, wantErr: "offline"},
+		{
+			name:   "history flag over config",
+			config: "offline: true\n",
+			args:   []string{"history", "-offline=false", "-from", "2024-03-01", "-to", "2024-03-01", "usd"},
+			want:   "2024-03-01 1.0876\n",
+		},
+		{name: "watch unknown currency", args: []string{"watch", "usd", "xxx"}, wantErr: "XXX"},
+		{name: "watch unknown output", args: []string{"watch", "-output", "yaml"}, wantErr: "unknown output format"},
+		{name: "serve arguments", args: []string{"serve", "extra"}, wantErr: "unexpected arguments"},
+		{name: "serve unknown flag", args: []string{"serve", "-port", "80"}, wantErr: "flag provided but not defined"},
+		{name: "serve config addr", config: "server:\n  addr: localhost:-1\n", args: []string{"serve"}, wantErr: "invalid port"},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := runCommand(t, ecb, test.config, test.args...)
+			if test.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), test.wantErr) {
+					t.Fatalf("got the error %v, want %q", err, test.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != test.want {
+				t.Errorf("got %q, want %q", got, test.want)
+			}
+		})
+	}
+}
+
+func TestPrintEvent(t *testing.T) {
+
+	event := watchEvent{
+		Date:     "2024-03-01",
+		Previous: "2024-02-29",
+		Changes:  []rateChange{{"USD", 1.0922, 1.0876}, {"GBP", 0.85605, 0.85578}},
+		Alerts:   []string{"USD below 1.09"},
+	}
+
+	for _, test := range []struct {
+		output string
+		want   string
+	}{
+		{"text", "2024-03-01 USD 1.0922 -> 1.0876\n2024-03-01 GBP 0.85605 -> 0.85578\n2024-03-01 alert: USD below 1.09\n"},
+		{"csv", "date,currency,previous,rate\n2024-03-01,USD,1.0922,1.0876\n2024-03-01,GBP,0.85605,0.85578\n"},
+		{"table", "DATE        CURRENCY  PREVIOUS  RATE\n2024-03-01  USD       1.0922    1.0876\n2024-03-01  GBP       0.85605   0.85578\n"},
+		{"json", `{"date":"2024-03-01","previous":"2024-02-29","changes":[{"currency":"USD","previous":1.0922,"rate":1.0876},` +
+			`{"currency":"GBP","previous":0.85605,"rate":0.85578}],"alerts":["USD below 1.09"]}` + "\n"},
+	} {
+		var buf bytes.Buffer
+		stdout = &buf
+		err := printEvent(&options{output: test.output}, event)
+		stdout = os.Stdout
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := buf.String(); got != test.want {
+			t.Errorf("%s: got %q, want %q", test.output, got, test.want)
+		}
+	}
+}
+
+func TestNewWatchEvent(t *testing.T) {
+
+	date := time.Date(2024, 3, 4, 0, 0, 0, 0, eurofxref.CET)
+	previous := time.Date(2024, 2, 29, 0, 0, 0, 0, eurofxref.CET)
+	// a publication replacing an older one than the previous business day
+	publication := eurofxref.Publication{
+		Date:          date,
+		Previous:      previous,
+		Rates:         map[string]float64{"USD": 1.0851, "GBP": 0.85578, "JPY": 162.55},
+		Changed:       []string{"USD", "JPY"},
+		PreviousRates: map[string]float64{"USD": 1.0922, "GBP": 0.85578, "JPY": 163.01},
+	}
+	rules := []eurofxref.AlertRule{{Currency: "usd", Below: 1.09}}
+
+	event := newWatchEvent(publication, map[string]bool{"USD": true}, rules)
+	want := watchEvent{
+		Date:     "2024-03-04",
+		Previous: "2024-02-29",
+		Changes:  []rateChange{{"USD", 1.0922, 1.0851}},
+		Alerts:   []string{"USD below 1.09"},
+	}
+	if !reflect.DeepEqual(event, want) {
+		t.Errorf("got %+v, want %+v", event, want)
+	}
+
+	if event := newWatchEvent(publication, map[string]bool{"GBP": true}, rules); len(event.Changes) != 0 || len(event.Alerts) != 0 {
+		t.Errorf("got %+v for an unchanged currency", event)
+	}
+}
+
+func TestParseArgs(t *testing.T) {
+
+	for _, test := range []struct {
+		args       []string
+		positional []string
+		output     string
+	}{
+		{[]string{"usd"}, []string{"usd"}, "text"},
+		{[]string{"-output", "csv", "usd", "gbp"}, []string{"usd", "gbp"}, "csv"},
+		{[]string{"usd", "-output", "json", "gbp"}, []string{"usd", "gbp"}, "json"},
+		{[]string{"usd", "--", "-output"}, []string{"usd", "-output"}, "text"},
+	} {
+		fs := flag.NewFlagSet("test", flag.ContinueOnError)
+		opts := options{}
+		opts.registerOutput(fs)
+		positional, err := parseArgs(fs, test.args)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !slices.Equal(positional, test.positional) || opts.output != test.output {
+			t.Errorf("%v: got %v and -output %s, want %v and %s",
+				test.args, positional, opts.output, test.positional, test.output)
+		}
+	}
+}
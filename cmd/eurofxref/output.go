@@ -0,0 +1,68 @@
+//
+// Copyright 2023 The GoEurofxref Authors. All rights reserved.
+// Use of this source code is governed by a MIT License
+// license that can be found in the LICENSE file.
+// Last Modification: 2026-10-15 12:30:00
+//
+
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+)
+
+// stdout receives the output of the commands, replaced by the tests.
+var stdout io.Writer = os.Stdout
+
+// output is what a command prints: the value encoded by the json format,
+// the rows of the csv and table formats, and the text format.
+type output struct {
+	json   any
+	header []string
+	rows   [][]string
+	text   func() error
+}
+
+// print writes out in the format selected by -output.
+func (opts *options) print(out output) error {
+
+	switch opts.output {
+	case "json":
+		return json.NewEncoder(stdout).Encode(out.json)
+	case "csv":
+		w := csv.NewWriter(stdout)
+		w.Write(out.header)
+		w.WriteAll(out.rows)
+		return w.Error()
+	case "table":
+		w := tabwriter.NewWriter(stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, strings.ToUpper(strings.Join(out.header, "\t")))
+		for _, row := range out.rows {
+			fmt.Fprintln(w, strings.Join(row, "\t"))
+		}
+		return w.Flush()
+	default:
+		return out.text()
+	}
+}
+
+// warnEmbedded warns on stderr of an answer with the rates embedded in
+// the tool, as neither the ECB nor the cache could be read.
+func warnEmbedded(date time.Time) {
+	fmt.Fprintf(os.Stderr, "warning: the ECB could not be reached, using the rates of %s embedded in the tool\n",
+		date.Format("2006-01-02"))
+}
+
+// warnStale warns on stderr of an answer with the cached rates, as the
+// ECB could not be reached to refresh them.
+func warnStale(date time.Time, age time.Duration) {
+	fmt.Fprintf(os.Stderr, "warning: the ECB could not be reached, using the rates of %s cached %s ago\n",
+		date.Format("2006-01-02"), age.Round(time.Minute))
+}
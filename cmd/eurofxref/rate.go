@@ -0,0 +1,103 @@
+//
+// Copyright 2023 The GoEurofxref Authors. All rights reserved.
+// Use of this source code is governed by a MIT License
+// license that can be found in the LICENSE file.
+// Last Modification: 2026-10-15 12:30:00
+//
+
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	eurofxref "github.com/mrhdias/go-eurofxref"
+)
+
+func runRate(args []string) error {
+
+	fs := flag.NewFlagSet("rate", flag.ContinueOnError)
+	opts := options{}
+	opts.register(fs)
+	opts.registerOutput(fs)
+	date := fs.String("date", "", "date of the rate (YYYY-MM-DD); the previous publication is used on non-trading days")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: eurofxref rate [flags] <currency>")
+		fs.PrintDefaults()
+	}
+
+	positional, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if err := opts.validate(); err != nil {
+		return err
+	}
+	if len(positional) != 1 {
+		fs.Usage()
+		return errors.New("expected exactly one currency code")
+	}
+
+	var onDate time.Time
+	if *date != "" {
+		if onDate, err = time.Parse("2006-01-02", *date); err != nil {
+			return fmt.Errorf("invalid date \"%s\": expected YYYY-MM-DD", *date)
+		}
+	}
+
+	currencyCode := strings.ToUpper(positional[0])
+	query, err := opts.query()
+	if err != nil {
+		return err
+	}
+	// the first run can answer without network, and the next ones during
+	// an outage
+	query.EmbeddedFallback = true
+	query.ServeStale = true
+
+	var result *eurofxref.QueryResult
+	if onDate.IsZero() {
+		result, err = query.Daily(currencyCode)
+	} else {
+		var historical *eurofxref.HistoricalResult
+		// falls back to the previous publication on non-trading days
+		historical, err = query.RateOn(currencyCode, onDate)
+		if historical != nil {
+			result = &historical.QueryResult
+		}
+	}
+	if err != nil {
+		return err
+	}
+	if result.Embedded {
+		warnEmbedded(result.LastUpdate)
+	}
+	if result.Stale {
+		warnStale(result.LastUpdate, result.Age)
+	}
+
+	published := result.LastUpdate.Format("2006-01-02")
+	rate := strconv.FormatFloat(result.RateValue, 'f', -1, 64)
+
+	return opts.print(output{
+		json: struct {
+			Currency string  `json:"currency"`
+			Date     string  `json:"date"`
+			Rate     float64 `json:"rate"`
+		}{
+			Currency: currencyCode,
+			Date:     published,
+			Rate:     result.RateValue,
+		},
+		header: []string{"currency", "date", "rate"},
+		rows:   [][]string{{currencyCode, published, rate}},
+		text: func() error {
+			fmt.Fprintln(stdout, rate)
+			return nil
+		},
+	})
+}
@@ -0,0 +1,178 @@
+//
+// Copyright 2023 The GoEurofxref Authors. All rights reserved.
+// Use of this source code is governed by a MIT License
+// license that can be found in the LICENSE file.
+// Last Modification: 2026-10-14 19:38:01
+//
+
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"log/slog"
+	"net"
+	"net/http"
+	"os"
+	"time"
+
+	eurofxref "github.com/mrhdias/go-eurofxref"
+	"github.com/mrhdias/go-eurofxref/rpc"
+	"github.com/mrhdias/go-eurofxref/server"
+	"google.golang.org/grpc"
+)
+
+// historySyncInterval is the wait between two syncs of the history
+// store, which read the 90-day file once it is up to date.
+const historySyncInterval = time.Hour
+
+func runServe(args []string) error {
+
+	fs := flag.NewFlagSet("serve", flag.ContinueOnError)
+	opts := options{}
+	opts.register(fs)
+	addr := fs.String("addr", ":8080", "address to listen on")
+	dashboard := fs.Bool("dashboard", false, "serve the rates dashboard at /")
+	accessLog := fs.Bool("access-log", false, "write a JSON access log entry per request to stderr")
+	metrics := fs.Bool("metrics", false, "serve the request metrics at /metrics")
+	apiKeys := fs.String("api-keys", "", "file of name:key lines; when set every request requires a key")
+	quota := fs.Uint64("quota", 0, "soft daily quota of requests per api key (0 is unlimited)")
+	grpcAddr := fs.String("grpc-addr", "", "address to serve the gRPC RatesService on (disabled if empty)")
+	historyStore := fs.String("history-store", "", "file of the history store answering the historical endpoints, synced hourly")
+	maxStaleness := fs.Duration("max-staleness", server.DefaultMaxStaleness,
+		"age past the expected publication after which /readyz fails")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: eurofxref serve [flags]")
+		fs.PrintDefaults()
+	}
+
+	positional, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 0 {
+		fs.Usage()
+		return errors.New("unexpected arguments")
+	}
+
+	source, err := opts.query()
+	if err != nil {
+		return err
+	}
+
+	// the server settings of the configuration file, unless overridden
+	config, err := opts.loadConfig()
+	if err != nil {
+		return err
+	}
+	if !opts.set("addr") && config.Server.Addr != "" {
+		*addr = config.Server.Addr
+	}
+	if !opts.set("grpc-addr") && config.Server.GRPCAddr != "" {
+		*grpcAddr = config.Server.GRPCAddr
+	}
+	if !opts.set("dashboard") {
+		*dashboard = config.Server.Dashboard
+	}
+	if !opts.set("access-log") {
+		*accessLog = config.Server.AccessLog
+	}
+	if !opts.set("metrics") {
+		*metrics = config.Server.Metrics
+	}
+	if !opts.set("api-keys") && config.Server.APIKeys != "" {
+		*apiKeys = config.Server.APIKeys
+	}
+	if !opts.set("quota") && config.Server.Quota != 0 {
+		*quota = config.Server.Quota
+	}
+	if !opts.set("history-store") && config.Server.HistoryStore != "" {
+		*historyStore = config.Server.HistoryStore
+	}
+	if !opts.set("max-staleness") && config.Server.MaxStaleness != 0 {
+		*maxStaleness = config.Server.MaxStaleness
+	}
+
+	if err := notifyEvents(context.Background(), source, config); err != nil {
+		return err
+	}
+
+	// the rates are kept in memory, and /readyz succeeds once loaded
+	if err := source.Start(context.Background()); err != nil {
+		log.Printf("[Error] loading the rates: %v\r\n", err)
+	}
+
+	handler := server.New(source, *dashboard)
+	handler.MaxStaleness = *maxStaleness
+	if *accessLog {
+		handler.Logger = slog.New(slog.NewJSONHandler(os.Stderr, nil))
+	}
+	if *metrics {
+		handler.Metrics = server.NewMetrics()
+	}
+	if *apiKeys != "" {
+		file, err := os.Open(*apiKeys)
+		if err != nil {
+			return err
+		}
+		keys, err := server.ReadAPIKeys(file)
+		file.Close()
+		if err != nil {
+			return err
+		}
+		handler.APIKeys = server.NewAPIKeys(keys, *quota)
+	}
+
+	if *historyStore != "" {
+		store, err := eurofxref.OpenHistoryStore(*historyStore)
+		if err != nil {
+			return err
+		}
+		if _, err := store.Sync(source); err != nil {
+			log.Printf("[Error] history store: %v\r\n", err)
+		}
+		go func() {
+			for range time.Tick(historySyncInterval) {
+				if _, err := store.Sync(source); err != nil {
+					log.Printf("[Error] history store: %v\r\n", err)
+				}
+			}
+		}()
+		handler.Store = store
+	}
+
+	if *grpcAddr != "" {
+		listener, err := net.Listen("tcp", *grpcAddr)
+		if err != nil {
+			return err
+		}
+		interceptors := []grpc.UnaryServerInterceptor{
+			rpc.UnaryInterceptor(handler.Logger, handler.Metrics),
+		}
+		if handler.APIKeys != nil {
+			interceptors = append(interceptors, rpc.APIKeyInterceptor(handler.APIKeys))
+		}
+		grpcServer := grpc.NewServer(grpc.ChainUnaryInterceptor(interceptors...))
+		rpc.New(source).Register(grpcServer)
+
+		log.Printf("[Info] gRPC listening on %s\r\n", *grpcAddr)
+		go func() {
+			if err := grpcServer.Serve(listener); err != nil {
+				log.Fatalf("[Fatal] gRPC server: %v\r\n", err)
+			}
+		}()
+	}
+
+	httpServer := &http.Server{
+		Addr:              *addr,
+		Handler:           handler,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	log.Printf("[Info] listening on %s\r\n", *addr)
+
+	return httpServer.ListenAndServe()
+}
@@ -0,0 +1,227 @@
+//
+// Copyright 2023 The GoEurofxref Authors. All rights reserved.
+// Use of this source code is governed by a MIT License
+// license that can be found in the LICENSE file.
+// Last Modification: 2026-10-15 17:40:00
+//
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"os/exec"
+	"os/signal"
+	"strconv"
+	"strings"
+	"syscall"
+
+	eurofxref "github.com/mrhdias/go-eurofxref"
+)
+
+// rateChange is the change of the rate of a watched currency between two
+// publications.
+type rateChange struct {
+	Currency string  `json:"currency"`
+	Previous float64 `json:"previous"`
+	Rate     float64 `json:"rate"`
+}
+
+// watchEvent is printed, and passed to the hook, for each publication
+// that changes the rates of the watched currencies.
+type watchEvent struct {
+	Date     string       `json:"date"`
+	Previous string       `json:"previous"`
+	Changes  []rateChange `json:"changes"`
+	// Alerts are the alert rules of the configuration file triggered by
+	// the new rates, as "USD above 1.1".
+	Alerts []string `json:"alerts,omitempty"`
+}
+
+func runWatch(args []string) error {
+
+	fs := flag.NewFlagSet("watch", flag.ContinueOnError)
+	opts := options{}
+	opts.register(fs)
+	opts.registerOutput(fs)
+	notifyCmd := fs.String("notify-cmd", "", "command run on each change, with the event as JSON on stdin")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: eurofxref watch [flags] [currency...]")
+		fs.PrintDefaults()
+	}
+
+	positional, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if err := opts.validate(); err != nil {
+		return err
+	}
+
+	query, err := opts.query()
+	if err != nil {
+		return err
+	}
+	config, err := opts.loadConfig()
+	if err != nil {
+		return err
+	}
+
+	// all the currencies without arguments
+	watched := map[string]bool{}
+	for _, arg := range positional {
+		currencyCode := strings.ToUpper(arg)
+		if err := query.ValidateCurrencyCode(currencyCode); err != nil {
+			return err
+		}
+		watched[currencyCode] = true
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	publications := query.Subscribe(ctx)
+	if err := notifyEvents(ctx, query, config); err != nil {
+		return err
+	}
+	if err := query.Start(ctx); err != nil {
+		// the background refresh keeps retrying
+		log.Printf("[Warning] watch: %v\r\n", err)
+	}
+	defer query.Stop()
+
+	for publication := range publications {
+		event := newWatchEvent(publication, watched, config.Alerts)
+		if len(event.Changes) == 0 {
+			continue
+		}
+
+		if err := printEvent(&opts, event); err != nil {
+			return err
+		}
+		if *notifyCmd != "" {
+			if err := notify(ctx, *notifyCmd, event); err != nil {
+				log.Printf("[Error] watch: the notify command failed: %v\r\n", err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// newWatchEvent returns the event of the changes of the publication in
+// the watched currencies, all of them when watched is empty, with the
+// alerts of the rules triggered by its rates.
+func newWatchEvent(publication eurofxref.Publication, watched map[string]bool, rules []eurofxref.AlertRule) watchEvent {
+
+	event := watchEvent{
+		Date:     publication.Date.Format("2006-01-02"),
+		Previous: publication.Previous.Format("2006-01-02"),
+	}
+	for _, currency := range publication.Changed {
+		if len(watched) > 0 && !watched[currency] {
+			continue
+		}
+		event.Changes = append(event.Changes, rateChange{
+			Currency: currency,
+			Previous: publication.PreviousRates[currency],
+			Rate:     publication.Rates[currency],
+		})
+	}
+	if len(event.Changes) == 0 {
+		return event
+	}
+
+	table := &eurofxref.RateTable{Date: publication.Date, Rates: publication.Rates}
+	for _, alert := range eurofxref.CheckAlerts(rules, table) {
+		event.Alerts = append(event.Alerts, alert.String())
+	}
+
+	return event
+}
+
+// printEvent prints a line per changed rate, or the event as JSON.
+func printEvent(opts *options, event watchEvent) error {
+
+	rows := make([][]string, 0, len(event.Changes))
+	for _, change := range event.Changes {
+		rows = append(rows, []string{event.Date, change.Currency,
+			strconv.FormatFloat(change.Previous, 'f', -1, 64),
+			strconv.FormatFloat(change.Rate, 'f', -1, 64)})
+	}
+
+	return opts.print(output{
+		json:   event,
+		header: []string{"date", "currency", "previous", "rate"},
+		rows:   rows,
+		text: func() error {
+			for _, row := range rows {
+				fmt.Fprintf(stdout, "%s %s %s -> %s\n", row[0], row[1], row[2], row[3])
+			}
+			for _, alert := range event.Alerts {
+				fmt.Fprintf(stdout, "%s alert: %s\n", event.Date, alert)
+			}
+			return nil
+		},
+	})
+}
+
+// notifyEvents notifies the webhooks and the email recipients of the
+// configuration file of the new publications and of the alerts of its
+// rules.
+func notifyEvents(ctx context.Context, query eurofxref.EuroFxRef, config *eurofxref.Config) error {
+
+	notifiers := []eurofxref.Notifier{}
+	for i := range config.Webhooks {
+		notifiers = append(notifiers, &config.Webhooks[i])
+	}
+	for i := range config.Emails {
+		// dated like the rates of the client
+		if config.Emails[i].Clock == nil {
+			config.Emails[i].Clock = query.Clock
+		}
+		notifiers = append(notifiers, &config.Emails[i])
+	}
+	if len(notifiers) == 0 {
+		return nil
+	}
+
+	return query.Notify(ctx, config.Alerts, notifiers...)
+}
+
+// notify runs the command with the event as JSON on its standard input
+// and the date and the changed currencies in the environment.
+func notify(ctx context.Context, command string, event watchEvent) error {
+
+	fields := strings.Fields(command)
+	if len(fields) == 0 {
+		return errors.New("empty command")
+	}
+
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	currencies := make([]string, 0, len(event.Changes))
+	for _, change := range event.Changes {
+		currencies = append(currencies, change.Currency)
+	}
+
+	cmd := exec.CommandContext(ctx, fields[0], fields[1:]...)
+	cmd.Stdin = bytes.NewReader(data)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	cmd.Env = append(os.Environ(),
+		"EUROFXREF_DATE="+event.Date,
+		"EUROFXREF_PREVIOUS="+event.Previous,
+		"EUROFXREF_CHANGED="+strings.Join(currencies, " "))
+
+	return cmd.Run()
+}
@@ -0,0 +1,211 @@
+//
+// Copyright 2023 The GoEurofxref Authors. All rights reserved.
+// Use of this source code is governed by a MIT License
+// license that can be found in the LICENSE file.
+// Last Modification: 2026-10-14 19:39:01
+//
+
+package eurofxref
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v3"
+)
+
+// Config is the configuration of a client, of its alerts and of the
+// server of the command line tool, read by LoadConfig. The zero values
+// keep the defaults of New.
+type Config struct {
+	Url       string         `yaml:"url" toml:"url"`
+	HistUrl   string         `yaml:"hist_url" toml:"hist_url"`
+	Hist90Url string         `yaml:"hist90_url" toml:"hist90_url"`
+	Mirrors   []string       `yaml:"mirrors" toml:"mirrors"`
+	ProxyUrl  string         `yaml:"proxy_url" toml:"proxy_url"`
+	UserAgent string         `yaml:"user_agent" toml:"user_agent"`
+	Strict    bool           `yaml:"strict" toml:"strict"`
+	Offline   bool           `yaml:"offline" toml:"offline"`
+	Cache     CacheConfig    `yaml:"cache" toml:"cache"`
+	Timeouts  TimeoutsConfig `yaml:"timeouts" toml:"timeouts"`
+	Breaker   BreakerConfig  `yaml:"breaker" toml:"breaker"`
+	Alerts    []AlertRule    `yaml:"alerts" toml:"alerts"`
+	Server    ServerConfig   `yaml:"server" toml:"server"`
+	// ServeStale serves the cached rates when they cannot be refreshed.
+	ServeStale bool `yaml:"serve_stale" toml:"serve_stale"`
+	// Webhooks are notified of the new publications by the "serve" and
+	// "watch" commands of the command line tool.
+	Webhooks []Webhook `yaml:"webhooks" toml:"webhooks"`
+	// Emails are sent for them, and for the alerts, likewise.
+	Emails []EmailNotifier `yaml:"emails" toml:"emails"`
+}
+
+// CacheConfig configures the cache directory, DefaultCacheDir when Dir
+// is empty.
+type CacheConfig struct {
+	Dir      string `yaml:"dir" toml:"dir"`
+	Disabled bool   `yaml:"disabled" toml:"disabled"`
+}
+
+// TimeoutsConfig configures the timeouts, written as "30s" or "1m".
+// Hedge is the HedgeDelay of the mirrors.
+type TimeoutsConfig struct {
+	Request time.Duration `yaml:"request" toml:"request"`
+	Hedge   time.Duration `yaml:"hedge" toml:"hedge"`
+}
+
+// BreakerConfig configures the circuit breaker of the downloads, disabled
+// when Threshold is zero.
+type BreakerConfig struct {
+	Threshold int           `yaml:"threshold" toml:"threshold"`
+	Cooldown  time.Duration `yaml:"cooldown" toml:"cooldown"`
+}
+
+// AlertRule is triggered when the reference rate of Currency rises above
+// Above or falls below Below. A zero bound is not checked.
+type AlertRule struct {
+	Currency string  `yaml:"currency" toml:"currency"`
+	Above    float64 `yaml:"above" toml:"above"`
+	Below    float64 `yaml:"below" toml:"below"`
+}
+
+// Triggered reports whether the rate of the result breaks the rule.
+func (rule AlertRule) Triggered(result *QueryResult) bool {
+
+	if result == nil || !strings.EqualFold(result.Currency, rule.Currency) {
+		return false
+	}
+
+	return (rule.Above != 0 && result.RateValue > rule.Above) ||
+		(rule.Below != 0 && result.RateValue < rule.Below)
+}
+
+// ServerConfig configures the "serve" command of the command line tool.
+type ServerConfig struct {
+	Addr      string `yaml:"addr" toml:"addr"`
+	GRPCAddr  string `yaml:"grpc_addr" toml:"grpc_addr"`
+	Dashboard bool   `yaml:"dashboard" toml:"dashboard"`
+	AccessLog bool   `yaml:"access_log" toml:"access_log"`
+	Metrics   bool   `yaml:"metrics" toml:"metrics"`
+	// APIKeys is the file of the API keys, and Quota the daily quota of
+	// requests per key.
+	APIKeys string `yaml:"api_keys" toml:"api_keys"`
+	Quota   uint64 `yaml:"quota" toml:"quota"`
+	// HistoryStore is the file of the history store answering the
+	// historical endpoints.
+	HistoryStore string `yaml:"history_store" toml:"history_store"`
+	// MaxStaleness is the age past the expected publication after which
+	// the readiness probe fails.
+	MaxStaleness time.Duration `yaml:"max_staleness" toml:"max_staleness"`
+}
+
+// LoadConfig reads the configuration file at path, in YAML (".yaml" or
+// ".yml") or TOML (".toml"). Unknown keys are rejected, so a misspelt
+// setting is not silently ignored.
+func LoadConfig(path string) (*Config, error) {
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading the config file: %v", err)
+	}
+
+	config := &Config{}
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		decoder := yaml.NewDecoder(bytes.NewReader(data))
+		decoder.KnownFields(true)
+		if err := decoder.Decode(config); err != nil && !errors.Is(err, io.EOF) {
+			return nil, fmt.Errorf("error parsing the config file: %v", err)
+		}
+	case ".toml":
+		metadata, err := toml.Decode(string(data), config)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing the config file: %v", err)
+		}
+		if undecoded := metadata.Undecoded(); len(undecoded) > 0 {
+			return nil, fmt.Errorf("error parsing the config file: unknown key \"%s\"", undecoded[0])
+		}
+	default:
+		return nil, fmt.Errorf("unknown config file format \"%s\": want .yaml, .yml or .toml",
+			filepath.Ext(path))
+	}
+
+	if err := config.validate(); err != nil {
+		return nil, err
+	}
+
+	return config, nil
+}
+
+func (config *Config) validate() error {
+
+	if config.Timeouts.Request < 0 || config.Breaker.Cooldown < 0 {
+		return errors.New("invalid config: the timeouts cannot be negative")
+	}
+
+	for _, rule := range config.Alerts {
+		if len(rule.Currency) != 3 {
+			return fmt.Errorf("invalid config: the alert currency \"%s\" is not a currency code",
+				rule.Currency)
+		}
+		if rule.Above == 0 && rule.Below == 0 {
+			return fmt.Errorf("invalid config: the alert of \"%s\" has no bound", rule.Currency)
+		}
+	}
+
+	for _, hook := range config.Webhooks {
+		if err := hook.validate(); err != nil {
+			return fmt.Errorf("invalid config: %v", err)
+		}
+	}
+	for _, notifier := range config.Emails {
+		if err := notifier.validate(); err != nil {
+			return fmt.Errorf("invalid config: %v", err)
+		}
+	}
+
+	return nil
+}
+
+// Client returns the client of New configured by config.
+func (config *Config) Client() EuroFxRef {
+
+	efr := New(config.Cache.Dir, true)
+	if config.Cache.Disabled {
+		efr.CacheDir = ""
+	}
+
+	if config.Url != "" {
+		efr.Url = config.Url
+	}
+	if config.HistUrl != "" {
+		efr.HistUrl = config.HistUrl
+	}
+	if config.Hist90Url != "" {
+		efr.Hist90Url = config.Hist90Url
+	}
+	if config.UserAgent != "" {
+		efr.UserAgent = config.UserAgent
+	}
+	if config.Timeouts.Request > 0 {
+		efr.Timeout = config.Timeouts.Request
+	}
+
+	efr.Mirrors = config.Mirrors
+	efr.HedgeDelay = config.Timeouts.Hedge
+	efr.ProxyUrl = config.ProxyUrl
+	efr.Strict = config.Strict
+	efr.Offline = config.Offline
+	efr.ServeStale = config.ServeStale
+	efr.BreakerThreshold = config.Breaker.Threshold
+	efr.BreakerCooldown = config.Breaker.Cooldown
+
+	return efr
+}
@@ -0,0 +1,125 @@
+//
+// Copyright 2023 The GoEurofxref Authors. All rights reserved.
+// Use of this source code is governed by a MIT License
+// license that can be found in the LICENSE file.
+// Last Modification: 2026-10-14 19:36:44
+//
+
+package eurofxref
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func writeConfig(t *testing.T, name, content string) string {
+
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	return path
+}
+
+func TestLoadConfig(t *testing.T) {
+
+	files := map[string]string{
+		"config.yaml": `
+url: http://localhost/daily.xml
+cache:
+  disabled: true
+timeouts:
+  request: 3s
+  hedge: 500ms
+breaker:
+  threshold: 2
+  cooldown: 1m
+alerts:
+  - currency: USD
+    above: 1.1
+server:
+  addr: ":9090"
+  dashboard: true
+webhooks:
+  - url: https://hooks.example.com/rates
+    secret: s3cret
+`,
+		"config.toml": `
+url = "http://localhost/daily.xml"
+
+[cache]
+disabled = true
+
+[timeouts]
+request = "3s"
+hedge = "500ms"
+
+[breaker]
+threshold = 2
+cooldown = "1m"
+
+[[alerts]]
+currency = "USD"
+above = 1.1
+
+[server]
+addr = ":9090"
+dashboard = true
+
+[[webhooks]]
+url = "https://hooks.example.com/rates"
+secret = "s3cret"
+`,
+	}
+
+	for name, content := range files {
+		t.Run(name, func(t *testing.T) {
+			config, err := LoadConfig(writeConfig(t, name, content))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if config.Server.Addr != ":9090" || !config.Server.Dashboard ||
+				len(config.Alerts) != 1 || config.Alerts[0].Above != 1.1 ||
+				len(config.Webhooks) != 1 || config.Webhooks[0].Secret != "s3cret" {
+				t.Errorf("unexpected configuration %+v", config)
+			}
+
+			efr := config.Client()
+			if efr.Url != "http://localhost/daily.xml" || efr.CacheDir != "" ||
+				efr.Timeout != 3*time.Second || efr.HedgeDelay != 500*time.Millisecond || efr.BreakerThreshold != 2 || efr.BreakerCooldown != time.Minute {
+				t.Errorf("unexpected client %+v", efr)
+			}
+			if efr.HistUrl != New("", false).HistUrl {
+				t.Errorf("got HistUrl %s, want the default", efr.HistUrl)
+			}
+		})
+	}
+
+	for name, content := range map[string]string{
+		"unknown.yaml": "timeout: 3s\n",
+		"unknown.toml": "timeout = \"3s\"\n",
+		"alert.yaml":   "alerts:\n  - currency: USD\n",
+		"config.json":  "{}",
+	} {
+		if _, err := LoadConfig(writeConfig(t, name, content)); err == nil {
+			t.Errorf("expected an error for %s", name)
+		}
+	}
+}
+
+func TestAlertRule(t *testing.T) {
+
+	rule := AlertRule{Currency: "USD", Above: 1.1, Below: 1.0}
+	for rate, want := range map[float64]bool{1.05: false, 1.2: true, 0.9: true} {
+		result := &QueryResult{Currency: "USD", RateValue: rate}
+		if got := rule.Triggered(result); got != want {
+			t.Errorf("got %v for %v, want %v", got, rate, want)
+		}
+	}
+	if rule.Triggered(&QueryResult{Currency: "JPY", RateValue: 160}) {
+		t.Error("the rule was triggered by another currency")
+	}
+}
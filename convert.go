@@ -0,0 +1,208 @@
+//
+// Copyright 2023 The GoEurofxref Authors. All rights reserved.
+// Use of this source code is governed by a MIT License
+// license that can be found in the LICENSE file.
+// Last Modification: 2026-10-14 19:27:43
+//
+
+package eurofxref
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ConvertOptions are the optional settings of a conversion.
+//
+// CashRounding rounds the converted value to the smallest amount that can
+// be paid in cash in the target currency, following its RoundingRule, and
+// Rounding to its minor units. RoundingMode is the mode of both, the
+// RoundingMode of the client when zero.
+//
+// MarkupPercent and MarkupPips lower the reference rate by a spread, in
+// percent of the rate and in pips of the target currency (0.0001, or 0.01
+// for the currencies without minor units such as the yen), so that the
+// converted value includes the margin of a checkout or an exchange
+// office. A negative markup raises the rate.
+type ConvertOptions struct {
+	CashRounding  bool
+	MarkupPercent float64
+	MarkupPips    float64
+	Rounding      bool
+	RoundingMode  RoundingMode
+}
+
+// ConversionResult is the outcome of converting an amount between two
+// currencies through their euro reference rates, with what is needed to
+// audit it later.
+type ConversionResult struct {
+	From       string
+	To         string
+	Amount     float64
+	Rate       float64 // units of To per unit of From
+	Value      float64
+	LastUpdate time.Time
+	// EffectiveRate is Rate less the markup, the rate Value is
+	// converted with.
+	EffectiveRate float64
+
+	// The audit trail of the conversion: FromRate and ToRate are the
+	// euro legs, the reference rates of From and To published on
+	// LastUpdate (1 for the euro), UnroundedValue is Value before the
+	// rounding, and Options are the options applied, with the rounding
+	// mode resolved. It is kept by the JSON encoding of the result.
+	FromRate       float64
+	ToRate         float64
+	UnroundedValue float64
+	Options        ConvertOptions
+	// Embedded reports a conversion with the rates embedded in the
+	// package, served with EmbeddedFallback, and Stale one with the cached
+	// rates served with ServeStale, downloaded Age ago.
+	Embedded bool
+	Stale    bool
+	Age      time.Duration
+}
+
+// Convert converts an amount of the from currency into the to currency
+// using the rates of the latest publication. Either currency can be the
+// euro.
+func (efr EuroFxRef) Convert(amount float64, from, to string,
+	options ...ConvertOptions) (*ConversionResult, error) {
+	return efr.ConvertContext(context.Background(), amount, from, to, options...)
+}
+
+// ConvertContext is like Convert, with the requests bound to ctx.
+func (efr EuroFxRef) ConvertContext(ctx context.Context, amount float64, from, to string,
+	options ...ConvertOptions) (*ConversionResult, error) {
+
+	return efr.convert(amount, from, to, time.Time{}, options, func() (*RateTable, error) {
+		return efr.DailyRatesContext(ctx)
+	})
+}
+
+// ConvertOn is like Convert, with the rates published on date or, on the
+// dates without a publication, the most recent ones before, as RatesOn
+// returns them.
+func (efr EuroFxRef) ConvertOn(amount float64, from, to string, date time.Time,
+	options ...ConvertOptions) (*ConversionResult, error) {
+	return efr.ConvertOnContext(context.Background(), amount, from, to, date, options...)
+}
+
+// ConvertOnContext is like ConvertOn, with the requests bound to ctx.
+func (efr EuroFxRef) ConvertOnContext(ctx context.Context, amount float64, from, to string,
+	date time.Time, options ...ConvertOptions) (*ConversionResult, error) {
+
+	return efr.convert(amount, from, to, date, options, func() (*RateTable, error) {
+		return efr.RatesOnContext(ctx, date)
+	})
+}
+
+// convert validates the currencies, for the rates of date unless it is
+// zero, and converts the amount with the table returned by rates.
+func (efr EuroFxRef) convert(amount float64, from, to string, date time.Time, options []ConvertOptions,
+	rates func() (*RateTable, error)) (*ConversionResult, error) {
+
+	opts := ConvertOptions{}
+	if len(options) == 1 {
+		opts = options[0]
+	}
+
+	from, to = strings.ToUpper(from), strings.ToUpper(to)
+	for _, currencyCode := range []string{from, to} {
+		if currencyCode == "EUR" {
+			continue
+		}
+		err := efr.ValidateCurrencyCode(currencyCode)
+		if !date.IsZero() {
+			err = efr.ValidateHistoricalCurrency(currencyCode, date)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	table, err := rates()
+	if err != nil {
+		return nil, err
+	}
+
+	if opts.RoundingMode == 0 {
+		opts.RoundingMode = efr.RoundingMode
+	}
+
+	return table.Conversion(amount, from, to, opts)
+}
+
+// Conversion converts an amount of the from currency into the to
+// currency with the rates of the table, as EuroFxRef.Convert does.
+func (table *RateTable) Conversion(amount float64, from, to string,
+	options ...ConvertOptions) (*ConversionResult, error) {
+
+	opts := ConvertOptions{}
+	if len(options) == 1 {
+		opts = options[0]
+	}
+	if opts.RoundingMode == 0 {
+		opts.RoundingMode = RoundHalfUp
+	}
+
+	from, to = strings.ToUpper(from), strings.ToUpper(to)
+	fromRate, ok := table.Get(from)
+	if !ok {
+		return nil, fmt.Errorf("no conversion rate value was returned for \"%s\" currency code", from)
+	}
+	toRate, ok := table.Get(to)
+	if !ok {
+		return nil, fmt.Errorf("no conversion rate value was returned for \"%s\" currency code", to)
+	}
+
+	rate := toRate / fromRate
+	effectiveRate := opts.EffectiveRate(rate, to)
+	if effectiveRate <= 0 {
+		return nil, fmt.Errorf("the markup exceeds the rate %v of %s/%s", rate, from, to)
+	}
+	unrounded := amount * effectiveRate
+
+	value := unrounded
+	switch {
+	case opts.CashRounding:
+		value = LookupRoundingRule(to).CashRoundMode(value, opts.RoundingMode)
+	case opts.Rounding:
+		value = LookupRoundingRule(to).RoundMode(value, opts.RoundingMode)
+	}
+
+	return &ConversionResult{
+		From:           from,
+		To:             to,
+		Amount:         amount,
+		Rate:           rate,
+		EffectiveRate:  effectiveRate,
+		Value:          value,
+		LastUpdate:     table.Date,
+		FromRate:       fromRate,
+		ToRate:         toRate,
+		UnroundedValue: unrounded,
+		Options:        opts,
+		Embedded:       table.Embedded,
+		Stale:          table.Stale,
+		Age:            table.Age,
+	}, nil
+}
+
+// EffectiveRate returns the rate, quoted in the to currency, less the
+// markup of the options.
+func (opts ConvertOptions) EffectiveRate(rate float64, to string) float64 {
+	return rate*(1-opts.MarkupPercent/100) - opts.MarkupPips*pipSize(to)
+}
+
+// pipSize returns the value of a pip of the rates quoted in the currency.
+func pipSize(currencyCode string) float64 {
+
+	if LookupRoundingRule(currencyCode).MinorUnits == 0 {
+		return 0.01
+	}
+
+	return 0.0001
+}
@@ -0,0 +1,200 @@
+//
+// Copyright 2023 The GoEurofxref Authors. All rights reserved.
+// Use of this source code is governed by a MIT License
+// license that can be found in the LICENSE file.
+// Last Modification: 2026-10-14 19:32:21
+//
+
+package eurofxref
+
+import (
+	"encoding/json"
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestConvert(t *testing.T) {
+
+	_, query := newTestServer(t)
+
+	tests := []struct {
+		amount   float64
+		from, to string
+		want     float64
+	}{
+		{100, "EUR", "USD", 108.76},
+		{108.76, "usd", "eur", 100},
+		{100, "USD", "GBP", 100 * 0.85578 / 1.0876},
+		{5, "EUR", "EUR", 5},
+	}
+
+	for _, tt := range tests {
+		result, err := query.Convert(tt.amount, tt.from, tt.to)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if math.Abs(result.Value-tt.want) > 1e-9 {
+			t.Errorf("Convert(%v, %s, %s) = %v, want %v", tt.amount, tt.from, tt.to, result.Value, tt.want)
+		}
+	}
+
+	if _, err := query.Convert(1, "USD", "XXX"); err == nil {
+		t.Error("expected an error for an unknown currency")
+	}
+}
+
+func TestConvertOn(t *testing.T) {
+
+	_, query := newTestServer(t)
+
+	date, _ := parseDate("2024-02-24") // Saturday
+	result, err := query.ConvertOn(100, "USD", "GBP", date)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.LastUpdate.Format("2006-01-02") != "2024-02-23" {
+		t.Errorf("converted with the rates of %s, want 2024-02-23", result.LastUpdate.Format("2006-01-02"))
+	}
+	if want := 100 * 0.8475 / 1.0823; math.Abs(result.Value-want) > 1e-9 {
+		t.Errorf("got %v, want %v", result.Value, want)
+	}
+
+	if _, err := query.ConvertOn(1, "USD", "XXX", date); err == nil {
+		t.Error("expected an error for an unknown currency")
+	}
+}
+
+func TestConvertCashRounding(t *testing.T) {
+
+	_, query := newTestServer(t)
+
+	// 10 EUR are 9.554 CHF, paid as 9.55 in cash
+	result, err := query.Convert(10, "EUR", "CHF", ConvertOptions{CashRounding: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Value != 9.55 {
+		t.Errorf("got %v, want 9.55", result.Value)
+	}
+
+	// 10 EUR are 3903.3 HUF, paid as 3905 in cash
+	result, err = query.Convert(10, "EUR", "HUF", ConvertOptions{CashRounding: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Value != 3905 {
+		t.Errorf("got %v, want 3905", result.Value)
+	}
+}
+
+func TestConvertMarkup(t *testing.T) {
+
+	_, query := newTestServer(t)
+
+	result, err := query.Convert(100, "EUR", "USD", ConvertOptions{MarkupPercent: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Rate != 1.0876 || math.Abs(result.EffectiveRate-1.065848) > 1e-9 ||
+		math.Abs(result.Value-106.5848) > 1e-9 {
+		t.Errorf("got rate %v, effective %v, value %v", result.Rate, result.EffectiveRate, result.Value)
+	}
+
+	// 50 pips of 0.0001, and of 0.01 for the yen
+	result, err = query.Convert(1, "EUR", "USD", ConvertOptions{MarkupPips: 50})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if math.Abs(result.EffectiveRate-1.0826) > 1e-9 {
+		t.Errorf("got effective rate %v, want 1.0826", result.EffectiveRate)
+	}
+	result, err = query.Convert(1, "EUR", "JPY", ConvertOptions{MarkupPips: 50})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if math.Abs(result.EffectiveRate-162.03) > 1e-9 {
+		t.Errorf("got effective rate %v, want 162.03", result.EffectiveRate)
+	}
+
+	result, err = query.Convert(1, "EUR", "USD")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.EffectiveRate != result.Rate {
+		t.Errorf("the effective rate %v differs from %v without markup", result.EffectiveRate, result.Rate)
+	}
+
+	if _, err := query.Convert(1, "EUR", "USD", ConvertOptions{MarkupPercent: 100}); err == nil {
+		t.Error("expected an error for a markup of the whole rate")
+	}
+}
+
+func TestConvertRoundingMode(t *testing.T) {
+
+	_, query := newTestServer(t)
+
+	// 0.25 EUR are 0.2719 USD
+	result, err := query.Convert(0.25, "EUR", "USD", ConvertOptions{Rounding: true, RoundingMode: RoundTruncate})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Value != 0.27 {
+		t.Errorf("got %v, want 0.27", result.Value)
+	}
+
+	query.RoundingMode = RoundHalfEven
+	result, err = query.Convert(0.125, "EUR", "EUR", ConvertOptions{Rounding: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Value != 0.12 {
+		t.Errorf("got %v with the mode of the client, want 0.12", result.Value)
+	}
+	result, err = query.Convert(0.125, "EUR", "EUR", ConvertOptions{Rounding: true, RoundingMode: RoundHalfUp})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Value != 0.13 {
+		t.Errorf("got %v with the mode of the call, want 0.13", result.Value)
+	}
+}
+
+func TestConversionAudit(t *testing.T) {
+
+	_, query := newTestServer(t)
+
+	result, err := query.Convert(100, "usd", "GBP", ConvertOptions{
+		MarkupPercent: 1,
+		CashRounding:  true,
+		RoundingMode:  RoundHalfEven,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.FromRate != 1.0876 || result.ToRate != 0.85578 || result.Value != 77.9 ||
+		math.Abs(result.UnroundedValue-100*0.85578/1.0876*0.99) > 1e-9 {
+		t.Errorf("unexpected audit trail %+v", result)
+	}
+
+	data, err := json.Marshal(result)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{`"from":"USD"`, `"to_rate":"0.85578"`, `"from_rate":"1.0876"`,
+		`"date":"2024-03-01"`, `"rounding":"cash"`, `"rounding_mode":"half-even"`, `"markup_percent":"1"`,
+		`"value":"77.9"`} {
+		if !strings.Contains(string(data), want) {
+			t.Errorf("%s does not contain %s", data, want)
+		}
+	}
+
+	var decoded ConversionResult
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(decoded, *result) {
+		t.Errorf("got %+v after a round trip, want %+v", decoded, *result)
+	}
+}
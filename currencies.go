@@ -0,0 +1,161 @@
+//
+// Copyright 2023 The GoEurofxref Authors. All rights reserved.
+// Use of this source code is governed by a MIT License
+// license that can be found in the LICENSE file.
+// Last Modification: 2026-10-14 19:27:43
+//
+
+package eurofxref
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// embeddedCurrencies are the currencies of the daily file embedded in the
+// package, accepted until a daily file is read.
+var embeddedCurrencies = sync.OnceValue(func() map[string]void {
+	return currencySet(EmbeddedRates().Rates)
+})
+
+func currencySet(rates map[string]float64) map[string]void {
+
+	currencies := make(map[string]void, len(rates))
+	for currencyCode := range rates {
+		currencies[currencyCode] = void{}
+	}
+
+	return currencies
+}
+
+// currencies returns the accepted currencies: Currencies when it is set,
+// and otherwise those of the feed, with the changes of AddCurrency and
+// RemoveCurrency.
+func (efr EuroFxRef) currencies() map[string]void {
+
+	if efr.Currencies != nil {
+		return efr.state.restrict(efr.Currencies)
+	}
+
+	return efr.state.acceptedCurrencies()
+}
+
+// AddCurrency accepts the currency code, even if the feed does not list
+// it. It is safe to call while the client is in use.
+func (efr EuroFxRef) AddCurrency(currencyCode string) error {
+	return efr.changeCurrency(currencyCode, true)
+}
+
+// RemoveCurrency stops accepting the currency code, e.g. to disallow the
+// currencies a shop does not support, even if the feed lists it. It is
+// safe to call while the client is in use.
+func (efr EuroFxRef) RemoveCurrency(currencyCode string) error {
+	return efr.changeCurrency(currencyCode, false)
+}
+
+func (efr EuroFxRef) changeCurrency(currencyCode string, accept bool) error {
+
+	if efr.state == nil {
+		return errors.New("the client must be created with New to change its currencies")
+	}
+
+	cc := strings.ToUpper(currencyCode)
+	if len(cc) != 3 || cc == "EUR" {
+		return fmt.Errorf("invalid currency code \"%s\"", currencyCode)
+	}
+
+	efr.state.currencyMu.Lock()
+	defer efr.state.currencyMu.Unlock()
+
+	if efr.state.overrides == nil {
+		efr.state.overrides = map[string]bool{}
+	}
+	efr.state.overrides[cc] = accept
+	efr.state.accepted = nil
+
+	return nil
+}
+
+// acceptedCurrencies returns the currencies of the daily files read, or
+// of the embedded one before the first, with the overrides. The set is
+// replaced, never modified.
+func (s *state) acceptedCurrencies() map[string]void {
+
+	if s == nil {
+		return embeddedCurrencies()
+	}
+
+	s.currencyMu.RLock()
+	accepted := s.accepted
+	s.currencyMu.RUnlock()
+	if accepted != nil {
+		return accepted
+	}
+
+	s.currencyMu.Lock()
+	defer s.currencyMu.Unlock()
+
+	if s.accepted == nil {
+		base := s.currencies
+		if base == nil {
+			base = embeddedCurrencies()
+		}
+		s.accepted = s.override(base)
+	}
+
+	return s.accepted
+}
+
+// restrict returns base with the overrides.
+func (s *state) restrict(base map[string]void) map[string]void {
+
+	if s == nil {
+		return base
+	}
+
+	s.currencyMu.RLock()
+	defer s.currencyMu.RUnlock()
+
+	return s.override(base)
+}
+
+// override returns base with the overrides, with currencyMu held.
+func (s *state) override(base map[string]void) map[string]void {
+
+	if len(s.overrides) == 0 {
+		return base
+	}
+
+	currencies := make(map[string]void, len(base)+len(s.overrides))
+	for currencyCode := range base {
+		currencies[currencyCode] = void{}
+	}
+	for currencyCode, accept := range s.overrides {
+		if accept {
+			currencies[currencyCode] = void{}
+		} else {
+			delete(currencies, currencyCode)
+		}
+	}
+
+	return currencies
+}
+
+// learnCurrencies sets the currencies of the feed to those of rates, on
+// the first daily file read or, with refresh, on every one.
+func (s *state) learnCurrencies(rates map[string]float64, refresh bool) {
+
+	if s == nil || len(rates) == 0 {
+		return
+	}
+
+	s.currencyMu.Lock()
+	defer s.currencyMu.Unlock()
+
+	if s.currencies == nil || refresh {
+		s.currencies = currencySet(rates)
+		s.accepted = nil
+	}
+}
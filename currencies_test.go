@@ -0,0 +1,136 @@
+//
+// Copyright 2023 The GoEurofxref Authors. All rights reserved.
+// Use of this source code is governed by a MIT License
+// license that can be found in the LICENSE file.
+// Last Modification: 2026-10-14 19:25:14
+//
+
+package eurofxref
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sync"
+	"testing"
+)
+
+func TestCurrenciesFromFeed(t *testing.T) {
+
+	currencies := []string{"GBP", "XYZ"}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `<gesmes:Envelope xmlns:gesmes="http://www.gesmes.org/xml/2002-08-01"><Cube><Cube time='2024-03-01'>`)
+		for _, currencyCode := range currencies {
+			fmt.Fprintf(w, "<Cube currency='%s' rate='1.5'/>", currencyCode)
+		}
+		fmt.Fprint(w, `</Cube></Cube></gesmes:Envelope>`)
+	}))
+	defer ts.Close()
+
+	query := New("", false)
+	query.CacheDir = ""
+	query.Url = ts.URL
+
+	// the currencies of the embedded file before the first read
+	if !reflect.DeepEqual(query.SupportedCurrencies(), EmbeddedRates().Currencies()) {
+		t.Errorf("got %v, want the currencies of the embedded file", query.SupportedCurrencies())
+	}
+	if err := query.ValidateCurrencyCode("XYZ"); err == nil {
+		t.Error("XYZ was accepted before it was published")
+	}
+
+	if _, err := query.DailyRates(); err != nil {
+		t.Fatal(err)
+	}
+	if got := query.SupportedCurrencies(); !reflect.DeepEqual(got, []string{"GBP", "XYZ"}) {
+		t.Errorf("got %v, want the currencies of the feed", got)
+	}
+	if err := query.ValidateCurrencyCode("USD"); err == nil {
+		t.Error("USD was accepted after it was dropped")
+	}
+
+	// kept until RefreshCurrencies is set
+	currencies = []string{"GBP", "USD"}
+	if _, err := query.DailyRates(); err != nil {
+		t.Fatal(err)
+	}
+	if got := query.SupportedCurrencies(); !reflect.DeepEqual(got, []string{"GBP", "XYZ"}) {
+		t.Errorf("got %v, want the currencies of the first file", got)
+	}
+
+	query.RefreshCurrencies = true
+	if _, err := query.DailyRates(); err != nil {
+		t.Fatal(err)
+	}
+	if got := query.SupportedCurrencies(); !reflect.DeepEqual(got, []string{"GBP", "USD"}) {
+		t.Errorf("got %v, want the currencies of the last file", got)
+	}
+}
+
+func TestAddRemoveCurrency(t *testing.T) {
+
+	_, query := newTestServer(t)
+
+	if err := query.RemoveCurrency("usd"); err != nil {
+		t.Fatal(err)
+	}
+	if err := query.AddCurrency("XAU"); err != nil {
+		t.Fatal(err)
+	}
+
+	// the copies of the client share the currencies
+	copied := query
+	if err := copied.ValidateCurrencyCode("USD"); err == nil {
+		t.Error("USD was accepted after it was removed")
+	}
+	if _, err := copied.Daily("USD"); err == nil {
+		t.Error("got the rate of a removed currency")
+	}
+	if err := copied.ValidateCurrencyCode("XAU"); err != nil {
+		t.Error(err)
+	}
+
+	// the overrides outlive the currencies read from the feed
+	if _, err := query.Daily("GBP"); err != nil {
+		t.Fatal(err)
+	}
+	if err := query.ValidateCurrencyCode("USD"); err == nil {
+		t.Error("USD was accepted after the daily file was read")
+	}
+
+	if err := query.AddCurrency("USD"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := query.Daily("USD"); err != nil {
+		t.Error(err)
+	}
+
+	for _, currencyCode := range []string{"", "EURO", "EUR"} {
+		if err := query.AddCurrency(currencyCode); err == nil {
+			t.Errorf("%q was added", currencyCode)
+		}
+	}
+	if err := (EuroFxRef{}).RemoveCurrency("USD"); err == nil {
+		t.Error("expected an error without New")
+	}
+
+	// concurrent changes and lookups
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				if i%2 == 0 {
+					query.RemoveCurrency("JPY")
+				} else {
+					query.AddCurrency("JPY")
+				}
+				query.SupportedCurrencies()
+				query.ValidateCurrencyCode("JPY")
+			}
+		}(i)
+	}
+	wg.Wait()
+}
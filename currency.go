@@ -0,0 +1,93 @@
+//
+// Copyright 2023 The GoEurofxref Authors. All rights reserved.
+// Use of this source code is governed by a MIT License
+// license that can be found in the LICENSE file.
+// Last Modification: 2026-10-15 13:45:00
+//
+
+package eurofxref
+
+import (
+	"context"
+	"time"
+)
+
+// Currency is an ISO 4217 currency code. Its constants, the codes of the
+// euro and of the currencies of the reference list, are accepted by the
+// typed methods, DailyCurrency, ConvertCurrency and RateOnCurrency, so that
+// a misspelt code does not compile; the methods taking a string accept
+// the raw codes, or a Currency converted with its String method.
+type Currency string
+
+// String returns the code of the currency.
+func (c Currency) String() string {
+	return string(c)
+}
+
+// The codes of the euro and of the currencies of the reference list.
+const (
+	EUR Currency = "EUR"
+	AUD Currency = "AUD"
+	BGN Currency = "BGN"
+	BRL Currency = "BRL"
+	CAD Currency = "CAD"
+	CHF Currency = "CHF"
+	CNY Currency = "CNY"
+	CZK Currency = "CZK"
+	DKK Currency = "DKK"
+	GBP Currency = "GBP"
+	HKD Currency = "HKD"
+	HUF Currency = "HUF"
+	IDR Currency = "IDR"
+	ILS Currency = "ILS"
+	INR Currency = "INR"
+	ISK Currency = "ISK"
+	JPY Currency = "JPY"
+	KRW Currency = "KRW"
+	MXN Currency = "MXN"
+	MYR Currency = "MYR"
+	NOK Currency = "NOK"
+	NZD Currency = "NZD"
+	PHP Currency = "PHP"
+	PLN Currency = "PLN"
+	RON Currency = "RON"
+	SEK Currency = "SEK"
+	SGD Currency = "SGD"
+	THB Currency = "THB"
+	TRY Currency = "TRY"
+	USD Currency = "USD"
+	ZAR Currency = "ZAR"
+)
+
+// DailyCurrency is Daily with a Currency.
+func (efr EuroFxRef) DailyCurrency(currency Currency) (*QueryResult, error) {
+	return efr.DailyContext(context.Background(), currency.String())
+}
+
+// DailyCurrencyContext is DailyContext with a Currency.
+func (efr EuroFxRef) DailyCurrencyContext(ctx context.Context, currency Currency) (*QueryResult, error) {
+	return efr.DailyContext(ctx, currency.String())
+}
+
+// ConvertCurrency is Convert with Currency codes.
+func (efr EuroFxRef) ConvertCurrency(amount float64, from, to Currency,
+	options ...ConvertOptions) (*ConversionResult, error) {
+	return efr.ConvertContext(context.Background(), amount, from.String(), to.String(), options...)
+}
+
+// ConvertCurrencyContext is ConvertContext with Currency codes.
+func (efr EuroFxRef) ConvertCurrencyContext(ctx context.Context, amount float64, from, to Currency,
+	options ...ConvertOptions) (*ConversionResult, error) {
+	return efr.ConvertContext(ctx, amount, from.String(), to.String(), options...)
+}
+
+// RateOnCurrency is RateOn with a Currency.
+func (efr EuroFxRef) RateOnCurrency(currency Currency, date time.Time) (*HistoricalResult, error) {
+	return efr.RateOnContext(context.Background(), currency.String(), date)
+}
+
+// RateOnCurrencyContext is RateOnContext with a Currency.
+func (efr EuroFxRef) RateOnCurrencyContext(ctx context.Context, currency Currency,
+	date time.Time) (*HistoricalResult, error) {
+	return efr.RateOnContext(ctx, currency.String(), date)
+}
@@ -0,0 +1,51 @@
+//
+// Copyright 2023 The GoEurofxref Authors. All rights reserved.
+// Use of this source code is governed by a MIT License
+// license that can be found in the LICENSE file.
+// Last Modification: 2026-10-15 13:45:00
+//
+
+package eurofxref
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCurrencyConstants(t *testing.T) {
+
+	constants := []Currency{
+		AUD, BGN, BRL, CAD, CHF, CNY, CZK, DKK, GBP, HKD, HUF, IDR, ILS, INR, ISK,
+		JPY, KRW, MXN, MYR, NOK, NZD, PHP, PLN, RON, SEK, SGD, THB, TRY, USD, ZAR,
+	}
+	codes := []string{}
+	for _, currency := range constants {
+		codes = append(codes, currency.String())
+	}
+	if got := New("", false).SupportedCurrencies(); !reflect.DeepEqual(got, codes) {
+		t.Errorf("got the currencies %v, want the constants %v", got, codes)
+	}
+
+	_, query := newTestServer(t)
+	result, err := query.DailyCurrency(USD)
+	if err != nil || result.Currency != USD.String() {
+		t.Fatalf("got %+v (%v), want the rate of USD", result, err)
+	}
+	conversion, err := query.ConvertCurrency(100, EUR, GBP)
+	if err != nil || conversion.To != GBP.String() || conversion.FromRate != 1 {
+		t.Errorf("got %+v (%v), want a conversion from EUR to GBP", conversion, err)
+	}
+	historical, err := query.RateOnCurrency(JPY, PublicationDate(2024, 3, 2))
+	if err != nil || !historical.LastUpdate.Equal(PublicationDate(2024, 3, 1)) {
+		t.Errorf("got %+v (%v), want the rate of JPY of 2024-03-01", historical, err)
+	}
+
+	// the raw strings are accepted by the string methods
+	code := "gbp"
+	if conversion, err = query.Convert(100, EUR.String(), code); err != nil || conversion.To != "GBP" {
+		t.Errorf("got %+v (%v), want a conversion to GBP", conversion, err)
+	}
+	if result, err = query.DailyCurrency(Currency(code)); err != nil || result.Currency != "GBP" {
+		t.Errorf("got %+v (%v), want the rate of GBP", result, err)
+	}
+}
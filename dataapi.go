@@ -0,0 +1,213 @@
+//
+// Copyright 2023 The GoEurofxref Authors. All rights reserved.
+// Use of this source code is governed by a MIT License
+// license that can be found in the LICENSE file.
+// Last Modification: 2026-10-15 13:15:00
+//
+
+package eurofxref
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DefaultDataApiUrl is the EXR (exchange rates) dataflow of the ECB Data
+// API.
+const DefaultDataApiUrl = "https://data-api.ecb.europa.eu/service/data/EXR"
+
+// dataApiKey returns the series key of the daily reference rates of the
+// currencies against the euro, all of them when none is given.
+func dataApiKey(currencyCodes []string) string {
+	return "D." + strings.Join(currencyCodes, "+") + ".EUR.SP00.A"
+}
+
+// DataAPIHistory queries the ECB Data API for the reference rates of the
+// currencies published between from and to, both inclusive, indexed by
+// currency code. Only the requested observations are downloaded, unlike
+// the historical files. Without currencies every currency is returned.
+func (efr EuroFxRef) DataAPIHistory(ctx context.Context, from, to time.Time,
+	currencyCodes ...string) (map[string]*Series, error) {
+
+	if efr.Offline {
+		return nil, errors.New("the Data API cannot be queried in offline mode")
+	}
+
+	codes := make([]string, len(currencyCodes))
+	for i, currencyCode := range currencyCodes {
+		if err := efr.ValidateHistoricalCurrency(currencyCode, from); err != nil {
+			return nil, err
+		}
+		codes[i] = strings.ToUpper(currencyCode)
+	}
+
+	return efr.dataAPISeries(ctx, dataApiKey(codes), codes, from, to)
+}
+
+// dataAPISeries queries the ECB Data API for the series of key published
+// between from and to, indexed by the code of their CURRENCY dimension.
+// The series of codes are returned empty when they have no observation.
+// The request is subject to the budget and the circuit breaker of the
+// downloads of the files.
+func (efr EuroFxRef) dataAPISeries(ctx context.Context, key string, codes []string,
+	from, to time.Time) (map[string]*Series, error) {
+
+	from, to = truncateDay(from), truncateDay(to)
+	if !from.IsZero() && !to.IsZero() && to.Before(from) {
+		return nil, errors.New("the end of the range is before its start")
+	}
+
+	baseUrl := efr.DataApiUrl
+	if baseUrl == "" {
+		baseUrl = DefaultDataApiUrl
+	}
+
+	query := url.Values{}
+	query.Set("format", "csvdata")
+	if !from.IsZero() {
+		query.Set("startPeriod", from.Format("2006-01-02"))
+	}
+	if !to.IsZero() {
+		query.Set("endPeriod", to.Format("2006-01-02"))
+	}
+	queryUrl := strings.TrimSuffix(baseUrl, "/") + "/" + key + "?" + query.Encode()
+
+	efr.logger().Info("fetching", slog.String("url", queryUrl))
+
+	series := make(map[string]*Series, len(codes))
+	for _, cc := range codes {
+		series[cc] = &Series{Currency: cc, Points: []Point{}}
+	}
+
+	if err := efr.allowFetch(queryUrl, efr.Now()); err != nil {
+		return nil, err
+	}
+
+	contentBytes, err := efr.get(ctx, queryUrl)
+	var statusErr *statusError
+	if errors.As(err, &statusErr) && statusErr.StatusCode == http.StatusNotFound {
+		// the answer of the Data API to a query without observations
+		efr.state.downloadSucceeded()
+		return series, nil
+	}
+	if err != nil {
+		if ctx.Err() == nil &&
+			efr.state.downloadFailed(efr.BreakerThreshold, efr.breakerCooldown(), efr.Now()) {
+			efr.logger().Warn("circuit breaker opened", slog.Duration("cooldown", efr.breakerCooldown()))
+		}
+		efr.logger().Warn("fetch failed", slog.String("url", queryUrl), slog.Any("error", err))
+		return nil, err
+	}
+	efr.state.downloadSucceeded()
+
+	decoded, err := decodeDataAPI(contentBytes)
+	if err != nil {
+		efr.logger().Error("parse failed", slog.String("url", queryUrl), slog.Any("error", err))
+		return nil, err
+	}
+	for currencyCode, s := range decoded {
+		series[currencyCode] = s
+	}
+
+	return series, nil
+}
+
+// decodeDataAPI decodes an answer of the Data API, in SDMX-ML when it is
+// XML (a mirror may serve format=genericdata) and in SDMX-CSV otherwise.
+func decodeDataAPI(contentBytes []byte) (map[string]*Series, error) {
+
+	if bytes.HasPrefix(bytes.TrimSpace(contentBytes), []byte("<")) {
+		if err := scanXML(contentBytes); err != nil {
+			return nil, err
+		}
+		return DecodeSDMX(bytes.NewReader(contentBytes))
+	}
+
+	series := map[string]*Series{}
+	if err := decodeDataCSV(bytes.NewReader(contentBytes), func(currencyCode string, point Point) error {
+		if _, ok := series[currencyCode]; !ok {
+			series[currencyCode] = &Series{Currency: currencyCode, Points: []Point{}}
+		}
+		series[currencyCode].Points = append(series[currencyCode].Points, point)
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	for _, s := range series {
+		sort.Slice(s.Points, func(i, j int) bool {
+			return s.Points[i].Date.Before(s.Points[j].Date)
+		})
+	}
+
+	return series, nil
+}
+
+// decodeDataCSV reads an SDMX-CSV answer of the Data API, calling fn for
+// every observation with a value.
+func decodeDataCSV(r io.Reader, fn func(currencyCode string, point Point) error) error {
+
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+
+	header, err := cr.Read()
+	if err != nil {
+		return fmt.Errorf("error when reading the SDMX-CSV header: %v", err)
+	}
+
+	columns := map[string]int{}
+	for i, name := range header {
+		columns[strings.TrimSpace(name)] = i
+	}
+	// the records must reach the last column read
+	width := 0
+	for _, name := range []string{"CURRENCY", "TIME_PERIOD", "OBS_VALUE"} {
+		i, ok := columns[name]
+		if !ok {
+			return fmt.Errorf("the SDMX-CSV answer has no %s column", name)
+		}
+		width = max(width, i+1)
+	}
+
+	for {
+		record, err := cr.Read()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("error when reading the SDMX-CSV answer: %v", err)
+		}
+		if len(record) < width {
+			line, _ := cr.FieldPos(0)
+			return fmt.Errorf("the SDMX-CSV record of line %d has %d fields, want at least %d", line, len(record), width)
+		}
+
+		value := record[columns["OBS_VALUE"]]
+		if value == "" || value == "NaN" {
+			continue
+		}
+		rateValue, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return fmt.Errorf("error when convert rate string from sdmx to float: %v", err)
+		}
+		date, err := parseDate(record[columns["TIME_PERIOD"]])
+		if err != nil {
+			return fmt.Errorf("invalid time period \"%s\": %v", record[columns["TIME_PERIOD"]], err)
+		}
+
+		if err := fn(strings.ToUpper(record[columns["CURRENCY"]]), Point{Date: date, Rate: rateValue}); err != nil {
+			return err
+		}
+	}
+}
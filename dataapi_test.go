@@ -0,0 +1,147 @@
+//
+// Copyright 2023 The GoEurofxref Authors. All rights reserved.
+// Use of this source code is governed by a MIT License
+// license that can be found in the LICENSE file.
+// Last Modification: 2026-10-15 13:15:00
+//
+
+package eurofxref
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+// newDataAPIServer answers the queries of the GBP and USD series with a
+// sample SDMX-CSV download, and any other key with 404.
+func newDataAPIServer(t *testing.T) (*httptest.Server, chan *url.URL) {
+
+	queries := make(chan *url.URL, 8)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		queries <- r.URL
+		if r.URL.Path != "/service/data/EXR/D.GBP+USD.EUR.SP00.A" && r.URL.Path != "/service/data/EXR/D.USD.EUR.SP00.A" {
+			http.Error(w, "No results found.", http.StatusNotFound)
+			return
+		}
+		http.ServeFile(w, r, "testdata/exr-gbp-usd.csv")
+	}))
+	t.Cleanup(ts.Close)
+
+	return ts, queries
+}
+
+func TestDataAPIHistory(t *testing.T) {
+
+	ts, queries := newDataAPIServer(t)
+
+	query := New("", false)
+	query.CacheDir = ""
+	query.DataApiUrl = ts.URL + "/service/data/EXR"
+
+	from, _ := parseDate("2024-02-26")
+	to, _ := parseDate("2024-03-01")
+	series, err := query.DataAPIHistory(context.Background(), from, to, "gbp", "USD")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	q := <-queries
+	if got := q.Query().Get("startPeriod") + "/" + q.Query().Get("endPeriod"); got != "2024-02-26/2024-03-01" {
+		t.Errorf("queried the period %s", got)
+	}
+	if q.Query().Get("format") != "csvdata" {
+		t.Errorf("queried the format %q", q.Query().Get("format"))
+	}
+
+	if len(series) != 2 || len(series["GBP"].Points) != 5 || len(series["USD"].Points) != 5 {
+		t.Fatalf("unexpected series %v", series)
+	}
+	if last := series["USD"].Points[4]; last.Rate != 1.0876 || !last.Date.Equal(to) {
+		t.Errorf("got last USD point %+v", last)
+	}
+
+	series, err = query.DataAPIHistory(context.Background(), from, to, "JPY")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(series["JPY"].Points) != 0 {
+		t.Errorf("expected an empty series without observations, got %v", series["JPY"])
+	}
+}
+
+func TestDecodeDataCSVShortRecord(t *testing.T) {
+
+	data := "KEY,CURRENCY,TIME_PERIOD,OBS_VALUE\n" +
+		"EXR.D.USD.EUR.SP00.A,USD,2024-03-01,1.0876\n" +
+		"EXR.D.USD.EUR.SP00.A,USD\n"
+	if _, err := decodeDataAPI([]byte(data)); err == nil || !strings.Contains(err.Error(), "line 3") {
+		t.Errorf("got %v, want the short record of line 3 rejected", err)
+	}
+}
+
+func TestDataAPIOfflineAndBudget(t *testing.T) {
+
+	ts, queries := newDataAPIServer(t)
+
+	query := New("", false)
+	query.CacheDir = ""
+	query.DataApiUrl = ts.URL + "/service/data/EXR"
+	query.Offline = true
+
+	from, _ := parseDate("2024-02-26")
+	to, _ := parseDate("2024-03-01")
+	if _, err := query.DataAPIHistory(context.Background(), from, to, "USD"); err == nil {
+		t.Error("expected an error in offline mode")
+	}
+	if len(queries) != 0 {
+		t.Errorf("got %d queries in offline mode", len(queries))
+	}
+
+	query.Offline = false
+	query.MaxFetchesPerHour = 1
+	if _, err := query.DataAPIHistory(context.Background(), from, to, "USD"); err != nil {
+		t.Fatal(err)
+	}
+	var budgetErr *BudgetExceededError
+	if _, err := query.DataAPIHistory(context.Background(), from, to, "USD"); !errors.As(err, &budgetErr) {
+		t.Errorf("got %v, want a *BudgetExceededError", err)
+	}
+
+	// the failures open the circuit breaker
+	query = New("", false)
+	query.CacheDir = ""
+	query.DataApiUrl = "http://127.0.0.1:1/service/data/EXR"
+	query.BreakerThreshold = 1
+	query.DataAPIHistory(context.Background(), from, to, "USD")
+	var openErr *CircuitOpenError
+	if _, err := query.DataAPIHistory(context.Background(), from, to, "USD"); !errors.As(err, &openErr) {
+		t.Errorf("got %v, want a *CircuitOpenError", err)
+	}
+}
+
+func TestHistoryRangeDataAPI(t *testing.T) {
+
+	ts, queries := newDataAPIServer(t)
+
+	query := New("", false)
+	query.CacheDir = ""
+	query.DataApiUrl = ts.URL + "/service/data/EXR"
+	query.UseDataAPI = true
+
+	date, _ := parseDate("2024-03-03")
+	result, err := query.RateOn("USD", date)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.RateValue != 1.0876 || result.LastUpdate.Format("2006-01-02") != "2024-03-01" {
+		t.Errorf("unexpected rate %+v", result)
+	}
+	if q := <-queries; q.Path != "/service/data/EXR/D.USD.EUR.SP00.A" {
+		t.Errorf("queried %s", q.Path)
+	}
+}
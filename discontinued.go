@@ -0,0 +1,72 @@
+//
+// Copyright 2023 The GoEurofxref Authors. All rights reserved.
+// Use of this source code is governed by a MIT License
+// license that can be found in the LICENSE file.
+// Last Modification: 2026-10-14 19:32:21
+//
+
+package eurofxref
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// discontinuedCurrencies are the currencies of the historical files no
+// longer published, replaced by the euro or suspended, by the date of
+// their last reference rate.
+var discontinuedCurrencies = map[string]time.Time{
+	"CYP": PublicationDate(2007, 12, 31),
+	"EEK": PublicationDate(2010, 12, 31),
+	"HRK": PublicationDate(2022, 12, 30),
+	"LTL": PublicationDate(2014, 12, 31),
+	"LVL": PublicationDate(2013, 12, 31),
+	"MTL": PublicationDate(2007, 12, 31),
+	"ROL": PublicationDate(2005, 6, 30),
+	"RUB": PublicationDate(2022, 3, 1),
+	"SIT": PublicationDate(2006, 12, 29),
+	"SKK": PublicationDate(2008, 12, 31),
+	"TRL": PublicationDate(2004, 12, 31),
+}
+
+// DiscontinuedError is returned for a currency no longer published,
+// queried for the rates of a date after Last, its last publication. It
+// is an ErrUnsupportedCurrency.
+type DiscontinuedError struct {
+	Currency string
+	Last     time.Time
+}
+
+func (e *DiscontinuedError) Error() string {
+	return fmt.Sprintf("the \"%s\" currency is discontinued, its last reference rate was published on %s",
+		e.Currency, e.Last.Format("2006-01-02"))
+}
+
+func (e *DiscontinuedError) Unwrap() error {
+	return ErrUnsupportedCurrency
+}
+
+// Discontinued returns the date of the last reference rate of a currency
+// no longer published, such as HRK or RUB.
+func Discontinued(currencyCode string) (time.Time, bool) {
+
+	last, ok := discontinuedCurrencies[strings.ToUpper(currencyCode)]
+
+	return last, ok
+}
+
+// ValidateHistoricalCurrency is like ValidateCurrencyCode for the rates
+// published from date on: the discontinued currencies are accepted until
+// their last publication.
+func (efr EuroFxRef) ValidateHistoricalCurrency(currencyCode string, date time.Time) error {
+
+	err := efr.ValidateCurrencyCode(currencyCode)
+	var discontinued *DiscontinuedError
+	if errors.As(err, &discontinued) && !truncateDay(date).After(discontinued.Last) {
+		return nil
+	}
+
+	return err
+}
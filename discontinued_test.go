@@ -0,0 +1,85 @@
+//
+// Copyright 2023 The GoEurofxref Authors. All rights reserved.
+// Use of this source code is governed by a MIT License
+// license that can be found in the LICENSE file.
+// Last Modification: 2026-10-14 19:32:21
+//
+
+package eurofxref
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// discontinuedHist is the end of the history of the kuna.
+const discontinuedHist = `<?xml version="1.0" encoding="UTF-8"?>
+<gesmes:Envelope xmlns:gesmes="http://www.gesmes.org/xml/2002-08-01" xmlns="http://www.ecb.int/vocabulary/2002-08-01/eurofxref">
+	<Cube>
+		<Cube time='2023-01-02'><Cube currency='USD' rate='1.0683'/></Cube>
+		<Cube time='2022-12-30'><Cube currency='USD' rate='1.0666'/><Cube currency='HRK' rate='7.5365'/></Cube>
+		<Cube time='2022-12-29'><Cube currency='USD' rate='1.0617'/><Cube currency='HRK' rate='7.5365'/></Cube>
+	</Cube>
+</gesmes:Envelope>`
+
+func TestDiscontinuedCurrencies(t *testing.T) {
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, discontinuedHist)
+	}))
+	defer ts.Close()
+
+	query := New("", false)
+	query.CacheDir = ""
+	query.HistUrl = ts.URL
+	query.Hist90Url = ts.URL
+
+	day := func(value string) time.Time {
+		date, _ := parseDate(value)
+		return date
+	}
+
+	var discontinued *DiscontinuedError
+	if _, err := query.Daily("hrk"); !errors.As(err, &discontinued) || !discontinued.Last.Equal(day("2022-12-30")) {
+		t.Errorf("got %v, want the kuna discontinued after 2022-12-30", err)
+	}
+
+	series, err := query.HistoryRange("HRK", day("2022-12-01"), day("2023-01-31"))
+	if err != nil || len(series.Points) != 2 {
+		t.Errorf("got %+v (%v), want the two last rates of the kuna", series, err)
+	}
+
+	result, err := query.RateOn("HRK", day("2022-12-30"))
+	if err != nil || result.RateValue != 7.5365 {
+		t.Errorf("got %+v (%v), want the last rate of the kuna", result, err)
+	}
+
+	conversion, err := query.ConvertOn(100, "HRK", "USD", day("2022-12-29"))
+	if err != nil || conversion.FromRate != 7.5365 {
+		t.Errorf("got %+v (%v), want a conversion with the kuna", conversion, err)
+	}
+
+	// the last rate is not carried forward
+	for name, lookup := range map[string]func() error{
+		"RateOn": func() error {
+			_, err := query.RateOn("HRK", day("2023-01-02"))
+			return err
+		},
+		"HistoryRange": func() error {
+			_, err := query.HistoryRange("HRK", day("2023-01-02"), day("2023-01-31"))
+			return err
+		},
+		"ConvertOn": func() error {
+			_, err := query.ConvertOn(100, "USD", "HRK", day("2023-01-02"))
+			return err
+		},
+	} {
+		if err := lookup(); !errors.As(err, &discontinued) {
+			t.Errorf("%s: got %v, want a *DiscontinuedError", name, err)
+		}
+	}
+}
@@ -0,0 +1,92 @@
+//
+// Copyright 2023 The GoEurofxref Authors. All rights reserved.
+// Use of this source code is governed by a MIT License
+// license that can be found in the LICENSE file.
+// Last Modification: 2026-10-14 19:20:11
+//
+
+package eurofxref
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// EERGroup is a group of trading partners of the euro effective exchange
+// rate (EER) indices of the ECB, the trade weighted averages of the
+// rates of the euro against the currencies of the group.
+type EERGroup string
+
+const (
+	EER19 EERGroup = "EER-19"
+	EER42 EERGroup = "EER-42"
+)
+
+// eerCodes are the codes of the groups in the CURRENCY dimension of the
+// EXR dataflow.
+var eerCodes = map[EERGroup]string{
+	EER19: "E5",
+	EER42: "E8",
+}
+
+// EffectiveRate returns the daily nominal effective exchange rate index
+// of the euro against the group published between from and to, both
+// inclusive, from the ECB Data API, as a series named after the group. A
+// rise of the index is an appreciation of the euro.
+func (efr EuroFxRef) EffectiveRate(group EERGroup, from, to time.Time) (*Series, error) {
+	return efr.EffectiveRateContext(context.Background(), group, from, to)
+}
+
+// EffectiveRateContext is like EffectiveRate, with the request bound to
+// ctx.
+func (efr EuroFxRef) EffectiveRateContext(ctx context.Context, group EERGroup,
+	from, to time.Time) (*Series, error) {
+
+	code, ok := eerCodes[group]
+	if !ok {
+		return nil, fmt.Errorf("unknown effective exchange rate group \"%s\"", group)
+	}
+	if efr.Offline {
+		return nil, errors.New("the effective exchange rates are only available from the Data API")
+	}
+
+	series, err := efr.dataAPISeries(ctx, "D."+code+".EUR.EN00.A", []string{code}, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	result := series[code]
+	result.Currency = string(group)
+
+	return result, nil
+}
+
+// Index derives an effective exchange rate index of the euro against the
+// basket, its value between from and to relative to its value on base,
+// which is 100. With the trade weights of a group of partners it
+// follows, as the indices of the ECB, the geometric weighted average of
+// the rates.
+func (basket *Basket) Index(base, from, to time.Time) (*Series, error) {
+	return basket.IndexContext(context.Background(), base, from, to)
+}
+
+// IndexContext is like Index, with the requests bound to ctx.
+func (basket *Basket) IndexContext(ctx context.Context, base, from, to time.Time) (*Series, error) {
+
+	baseValue, err := basket.ValueContext(ctx, base)
+	if err != nil {
+		return nil, err
+	}
+
+	series, err := basket.HistoryContext(ctx, from, to)
+	if err != nil {
+		return nil, err
+	}
+	for i := range series.Points {
+		series.Points[i].Rate = 100 * series.Points[i].Rate / baseValue.Value
+	}
+
+	return series, nil
+}
@@ -0,0 +1,85 @@
+//
+// Copyright 2023 The GoEurofxref Authors. All rights reserved.
+// Use of this source code is governed by a MIT License
+// license that can be found in the LICENSE file.
+// Last Modification: 2026-10-14 19:32:21
+//
+
+package eurofxref
+
+import (
+	"io"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestEffectiveRate(t *testing.T) {
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/service/data/EXR/D.E5.EUR.EN00.A" {
+			http.Error(w, "No results found.", http.StatusNotFound)
+			return
+		}
+		io.WriteString(w, `KEY,FREQ,CURRENCY,CURRENCY_DENOM,EXR_TYPE,EXR_SUFFIX,TIME_PERIOD,OBS_VALUE
+EXR.D.E5.EUR.EN00.A,D,E5,EUR,EN00,A,2024-03-01,97.9829
+EXR.D.E5.EUR.EN00.A,D,E5,EUR,EN00,A,2024-02-29,98.0379
+`)
+	}))
+	defer ts.Close()
+
+	query := New("", false)
+	query.CacheDir = ""
+	query.DataApiUrl = ts.URL + "/service/data/EXR"
+
+	from, _ := parseDate("2024-02-29")
+	to, _ := parseDate("2024-03-01")
+	series, err := query.EffectiveRate(EER19, from, to)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if series.Currency != "EER-19" || len(series.Points) != 2 ||
+		!series.Points[0].Date.Equal(from) || series.Points[1].Rate != 97.9829 {
+		t.Errorf("got %+v, want the EER-19 index in date order", series)
+	}
+
+	// no observation of the group
+	series, err = query.EffectiveRate(EER42, from, to)
+	if err != nil || series.Currency != "EER-42" || len(series.Points) != 0 {
+		t.Errorf("got %+v (%v), want an empty series", series, err)
+	}
+
+	if _, err := query.EffectiveRate("EER-1", from, to); err == nil {
+		t.Error("expected an error for an unknown group")
+	}
+}
+
+func TestBasketIndex(t *testing.T) {
+
+	_, query := newTestServer(t)
+	basket := &Basket{
+		Name:    "partners",
+		Weights: map[string]float64{"USD": 0.6, "GBP": 0.4},
+		Source:  query,
+	}
+
+	base, _ := parseDate("2024-02-23")
+	to, _ := parseDate("2024-02-29")
+	series, err := basket.Index(base, base, to)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(series.Points) < 2 || !series.Points[0].Date.Equal(base) || math.Abs(series.Points[0].Rate-100) > 1e-9 {
+		t.Fatalf("got %+v, want an index of 100 on %v", series, base)
+	}
+
+	usd, _ := query.RateOn("USD", to)
+	gbp, _ := query.RateOn("GBP", to)
+	usdBase, _ := query.RateOn("USD", base)
+	gbpBase, _ := query.RateOn("GBP", base)
+	want := 100 * math.Pow(usd.RateValue/usdBase.RateValue, 0.6) * math.Pow(gbp.RateValue/gbpBase.RateValue, 0.4)
+	if got := series.Points[len(series.Points)-1].Rate; math.Abs(got-want) > 1e-9 {
+		t.Errorf("got the index %v on %v, want %v", got, to, want)
+	}
+}
@@ -0,0 +1,176 @@
+//
+// Copyright 2023 The GoEurofxref Authors. All rights reserved.
+// Use of this source code is governed by a MIT License
+// license that can be found in the LICENSE file.
+// Last Modification: 2026-10-15 12:10:00
+//
+
+package eurofxref
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"mime"
+	"net"
+	"net/smtp"
+	"slices"
+	"strings"
+	"text/template"
+	"time"
+)
+
+const (
+	// DefaultEmailSubject and DefaultEmailBody are the templates of the
+	// emails of an EmailNotifier without Subject and Body.
+	DefaultEmailSubject = `ECB reference rates: {{if eq .Event "alert"}}{{join .Alerts ", "}}{{else}}publication of {{.Date}}{{end}}`
+	DefaultEmailBody    = `{{if .Alerts}}Alerts:
+{{range .Alerts}}  {{.}}
+{{end}}
+{{end}}Reference rates of {{.Date}}, changed since {{.Previous}}:
+{{range .Changes}}  {{.Currency}}  {{.Previous}} -> {{.Rate}}
+{{end}}`
+)
+
+// sendMail sends the emails, replaced by the tests.
+var sendMail = smtp.SendMail
+
+// EmailNotifier is a Notifier sending an email per event through an SMTP
+// server, upgraded to TLS when the server supports it.
+//
+// Subject and Body are text/template templates executed with the
+// WebhookPayload of the event, DefaultEmailSubject and DefaultEmailBody
+// when empty. Events restricts the events emailed, "publication" or
+// "alert", to all of them when empty.
+type EmailNotifier struct {
+	// Addr is the address of the SMTP server, as "smtp.example.com:587".
+	Addr string `yaml:"addr" toml:"addr"`
+	// Username and Password authenticate with PLAIN, unless empty.
+	Username string   `yaml:"username" toml:"username"`
+	Password string   `yaml:"password" toml:"password"`
+	From     string   `yaml:"from" toml:"from"`
+	To       []string `yaml:"to" toml:"to"`
+	Subject  string   `yaml:"subject" toml:"subject"`
+	Body     string   `yaml:"body" toml:"body"`
+	Events   []string `yaml:"events" toml:"events"`
+	// Clock dates the emails, the time of the system when nil.
+	Clock Clock `yaml:"-" toml:"-"`
+}
+
+var emailFuncs = template.FuncMap{"join": strings.Join}
+
+// templates returns the parsed templates of the subject and the body.
+func (notifier *EmailNotifier) templates() (*template.Template, *template.Template, error) {
+
+	subject, body := notifier.Subject, notifier.Body
+	if subject == "" {
+		subject = DefaultEmailSubject
+	}
+	if body == "" {
+		body = DefaultEmailBody
+	}
+
+	subjectTemplate, err := template.New("subject").Funcs(emailFuncs).Parse(subject)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid email subject: %v", err)
+	}
+	bodyTemplate, err := template.New("body").Funcs(emailFuncs).Parse(body)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid email body: %v", err)
+	}
+
+	return subjectTemplate, bodyTemplate, nil
+}
+
+// validate checks the settings and the templates of the notifier.
+func (notifier *EmailNotifier) validate() error {
+
+	if _, _, err := net.SplitHostPort(notifier.Addr); err != nil {
+		return fmt.Errorf("invalid smtp address \"%s\": want host:port", notifier.Addr)
+	}
+	if notifier.From == "" || len(notifier.To) == 0 {
+		return errors.New("the email sender and recipients are required")
+	}
+	for _, kind := range notifier.Events {
+		if kind != EventPublication.String() && kind != EventAlert.String() {
+			return fmt.Errorf("unknown event \"%s\": want publication or alert", kind)
+		}
+	}
+
+	_, _, err := notifier.templates()
+
+	return err
+}
+
+// Notify emails the event, unless it is not one of Events.
+func (notifier *EmailNotifier) Notify(ctx context.Context, event Event) error {
+
+	if len(notifier.Events) > 0 && !slices.Contains(notifier.Events, event.Kind.String()) {
+		return nil
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	message, err := notifier.message(event)
+	if err != nil {
+		return err
+	}
+
+	var auth smtp.Auth
+	if notifier.Username != "" {
+		host, _, _ := net.SplitHostPort(notifier.Addr)
+		auth = smtp.PlainAuth("", notifier.Username, notifier.Password, host)
+	}
+
+	if err := sendMail(notifier.Addr, auth, notifier.From, notifier.To, message); err != nil {
+		return fmt.Errorf("error sending the email: %v", err)
+	}
+
+	return nil
+}
+
+// now returns the time of Clock, or of the system.
+func (notifier *EmailNotifier) now() time.Time {
+
+	if notifier.Clock != nil {
+		return notifier.Clock.Now()
+	}
+
+	return time.Now()
+}
+
+// message returns the email of the event, headers included.
+func (notifier *EmailNotifier) message(event Event) ([]byte, error) {
+
+	subjectTemplate, bodyTemplate, err := notifier.templates()
+	if err != nil {
+		return nil, err
+	}
+
+	payload := NewWebhookPayload(event)
+	var subject, body bytes.Buffer
+	if err := subjectTemplate.Execute(&subject, payload); err != nil {
+		return nil, fmt.Errorf("error executing the email subject: %v", err)
+	}
+	if err := bodyTemplate.Execute(&body, payload); err != nil {
+		return nil, fmt.Errorf("error executing the email body: %v", err)
+	}
+
+	// a header cannot span lines
+	oneLine := strings.NewReplacer("\r", " ", "\n", " ")
+
+	var message bytes.Buffer
+	fmt.Fprintf(&message, "From: %s\r\n", oneLine.Replace(notifier.From))
+	fmt.Fprintf(&message, "To: %s\r\n", oneLine.Replace(strings.Join(notifier.To, ", ")))
+	// encoded as RFC 2047 words when not plain ASCII
+	fmt.Fprintf(&message, "Subject: %s\r\n",
+		mime.QEncoding.Encode("utf-8", oneLine.Replace(strings.TrimSpace(subject.String()))))
+	fmt.Fprintf(&message, "Date: %s\r\n", notifier.now().Format(time.RFC1123Z))
+	message.WriteString("MIME-Version: 1.0\r\n")
+	message.WriteString("Content-Type: text/plain; charset=utf-8\r\n\r\n")
+	message.WriteString(strings.ReplaceAll(strings.ReplaceAll(body.String(), "\r\n", "\n"), "\n", "\r\n"))
+
+	return message.Bytes(), nil
+}
@@ -0,0 +1,128 @@
+//
+// Copyright 2023 The GoEurofxref Authors. All rights reserved.
+// Use of this source code is governed by a MIT License
+// license that can be found in the LICENSE file.
+// Last Modification: 2026-10-15 12:10:00
+//
+
+package eurofxref
+
+import (
+	"context"
+	"net/smtp"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestEmailNotifier(t *testing.T) {
+
+	type sent struct {
+		addr    string
+		auth    smtp.Auth
+		from    string
+		to      []string
+		message string
+	}
+	var mails []sent
+	defer func(send func(string, smtp.Auth, string, []string, []byte) error) { sendMail = send }(sendMail)
+	sendMail = func(addr string, auth smtp.Auth, from string, to []string, msg []byte) error {
+		mails = append(mails, sent{addr, auth, from, to, string(msg)})
+		return nil
+	}
+
+	publication := Publication{
+		Date:          PublicationDate(2024, 3, 1),
+		Previous:      PublicationDate(2024, 2, 29),
+		Rates:         map[string]float64{"USD": 1.0876},
+		Changed:       []string{"USD"},
+		PreviousRates: map[string]float64{"USD": 1.0796},
+	}
+	alert := Event{Kind: EventAlert, Publication: publication,
+		Alerts: []Alert{{Rule: AlertRule{Currency: "USD", Above: 1.085}, Rate: 1.0876}}}
+
+	notifier := &EmailNotifier{
+		Addr:     "smtp.example.com:587",
+		Username: "rates",
+		Password: "s3cret",
+		From:     "rates@example.com",
+		To:       []string{"finance@example.com", "cfo@example.com"},
+		Events:   []string{"alert"},
+	}
+	if err := notifier.validate(); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+	if err := notifier.Notify(ctx, Event{Kind: EventPublication, Publication: publication}); err != nil {
+		t.Fatal(err)
+	}
+	if err := notifier.Notify(ctx, alert); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(mails) != 1 {
+		t.Fatalf("got %d emails, want the one of the alert", len(mails))
+	}
+	mail := mails[0]
+	if mail.addr != "smtp.example.com:587" || mail.auth == nil || mail.from != "rates@example.com" || len(mail.to) != 2 {
+		t.Errorf("unexpected email %+v", mail)
+	}
+	for _, want := range []string{
+		"To: finance@example.com, cfo@example.com\r\n",
+		"Subject: ECB reference rates: USD above 1.085\r\n",
+		"\r\n  USD above 1.085\r\n",
+		"Reference rates of 2024-03-01, changed since 2024-02-29:\r\n  USD  1.0796 -> 1.0876\r\n",
+	} {
+		if !strings.Contains(mail.message, want) {
+			t.Errorf("the email lacks %q:\n%s", want, mail.message)
+		}
+	}
+
+	// templated, with a subject kept on one line
+	notifier = &EmailNotifier{
+		Addr:    "localhost:25",
+		From:    "rates@example.com",
+		To:      []string{"finance@example.com"},
+		Subject: "{{.Event}}\n{{.Date}}",
+		Body:    "{{range .Changes}}{{.Currency}}={{.Rate}}{{end}}",
+	}
+	if err := notifier.Notify(ctx, Event{Kind: EventPublication, Publication: publication}); err != nil {
+		t.Fatal(err)
+	}
+	if mail := mails[1]; mail.auth != nil || !strings.Contains(mail.message, "Subject: publication 2024-03-01\r\n") ||
+		!strings.HasSuffix(mail.message, "\r\n\r\nUSD=1.0876") {
+		t.Errorf("unexpected email %q", mail.message)
+	}
+
+	// non-ASCII subject encoded, dated by the clock
+	notifier = &EmailNotifier{
+		Addr:    "localhost:25",
+		From:    "rates@example.com",
+		To:      []string{"finance@example.com"},
+		Subject: "Câmbios de {{.Date}}",
+		Clock:   FixedClock(time.Date(2024, 3, 1, 16, 5, 0, 0, CET)),
+	}
+	if err := notifier.Notify(ctx, Event{Kind: EventPublication, Publication: publication}); err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		"Subject: =?utf-8?q?C=C3=A2mbios_de_2024-03-01?=\r\n",
+		"Date: Fri, 01 Mar 2024 16:05:00 +0100\r\n",
+	} {
+		if !strings.Contains(mails[2].message, want) {
+			t.Errorf("the email lacks %q:\n%s", want, mails[2].message)
+		}
+	}
+
+	for _, invalid := range []EmailNotifier{
+		{Addr: "smtp.example.com", From: "a@example.com", To: []string{"b@example.com"}},
+		{Addr: "smtp.example.com:25", To: []string{"b@example.com"}},
+		{Addr: "smtp.example.com:25", From: "a@example.com", To: []string{"b@example.com"}, Subject: "{{.Date"},
+		{Addr: "smtp.example.com:25", From: "a@example.com", To: []string{"b@example.com"}, Events: []string{"daily"}},
+	} {
+		if err := invalid.validate(); err == nil {
+			t.Errorf("%+v: expected an error", invalid)
+		}
+	}
+}
@@ -0,0 +1,58 @@
+//
+// Copyright 2023 The GoEurofxref Authors. All rights reserved.
+// Use of this source code is governed by a MIT License
+// license that can be found in the LICENSE file.
+// Last Modification: 2026-10-14 19:05:43
+//
+
+package eurofxref
+
+import (
+	"bytes"
+	_ "embed"
+	"errors"
+	"log/slog"
+)
+
+// The daily file is refreshed before each release with go generate.
+//
+//go:generate curl -sSfo embedded/eurofxref-daily.xml https://www.ecb.europa.eu/stats/eurofxref/eurofxref-daily.xml
+
+//go:embed embedded/eurofxref-daily.xml
+var embeddedDaily []byte
+
+// EmbeddedRates returns the rates of the daily file embedded in the
+// package at its release, flagged as Embedded.
+func EmbeddedRates() *RateTable {
+
+	var table *RateTable
+	err := decodeCubes(bytes.NewReader(embeddedDaily), func(cube TimeCube) error {
+		date, rates, err := cube.rates()
+		if err != nil {
+			return err
+		}
+		table = &RateTable{Date: date, Rates: rates, Embedded: true}
+		return errStopIteration
+	})
+	if table == nil || (err != nil && !errors.Is(err, errStopIteration)) {
+		panic("eurofxref: the embedded daily file is invalid")
+	}
+
+	return table
+}
+
+// embeddedFallback returns the embedded rates in place of the daily
+// rates that could not be read, when EmbeddedFallback is set.
+func (efr EuroFxRef) embeddedFallback(err error) (*RateTable, bool) {
+
+	if !efr.EmbeddedFallback || efr.Provider != nil {
+		return nil, false
+	}
+
+	table := EmbeddedRates()
+	efr.logger().Warn("serving the embedded rates",
+		slog.String("publication", table.Date.Format("2006-01-02")),
+		slog.Any("reason", err))
+
+	return table, true
+}
@@ -0,0 +1,60 @@
+//
+// Copyright 2023 The GoEurofxref Authors. All rights reserved.
+// Use of this source code is governed by a MIT License
+// license that can be found in the LICENSE file.
+// Last Modification: 2026-10-14 19:05:43
+//
+
+package eurofxref
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestEmbeddedRates(t *testing.T) {
+
+	table := EmbeddedRates()
+	if !table.Embedded || table.Date.IsZero() || len(table.Rates) < 20 {
+		t.Fatalf("unexpected embedded rates %+v", table)
+	}
+
+	// the copies do not share the rates
+	table.Rates["USD"] = 0
+	if EmbeddedRates().Rates["USD"] == 0 {
+		t.Error("the embedded rates were modified")
+	}
+}
+
+func TestEmbeddedFallback(t *testing.T) {
+
+	ts, query := newTestServer(t)
+	ts.Close()
+
+	if _, err := query.Daily("USD"); err == nil {
+		t.Fatal("expected an error without the network and the cache")
+	}
+
+	query.EmbeddedFallback = true
+	result, err := query.Daily("USD")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !result.Embedded || result.RateValue != EmbeddedRates().Rates["USD"] {
+		t.Errorf("got %+v, want the embedded rate", result)
+	}
+
+	data, err := json.Marshal(result)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), `"embedded":true`) {
+		t.Errorf("the embedded rate is not flagged in %s", data)
+	}
+
+	conversion, err := query.Convert(100, "EUR", "USD")
+	if err != nil || !conversion.Embedded {
+		t.Errorf("got %+v (%v), want a conversion with the embedded rates", conversion, err)
+	}
+}
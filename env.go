@@ -0,0 +1,66 @@
+//
+// Copyright 2023 The GoEurofxref Authors. All rights reserved.
+// Use of this source code is governed by a MIT License
+// license that can be found in the LICENSE file.
+// Last Modification: 2026-10-14 18:54:34
+//
+
+package eurofxref
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+)
+
+// The environment variables read by NewFromEnv.
+const (
+	EnvUrl      = "EUROFXREF_URL"
+	EnvCacheDir = "EUROFXREF_CACHE_DIR"
+	EnvTimeout  = "EUROFXREF_TIMEOUT"
+	EnvOffline  = "EUROFXREF_OFFLINE"
+)
+
+// NewFromEnv returns the client of New configured by the environment:
+//
+//	EUROFXREF_URL        the daily file, instead of the ECB one
+//	EUROFXREF_CACHE_DIR  the cache directory, created if missing; the
+//	                     cache is disabled when it is set but empty
+//	EUROFXREF_TIMEOUT    the timeout of the requests, as "30s" or "1m"
+//	EUROFXREF_OFFLINE    "true" or "1" to read the files only from the
+//	                     cache
+//
+// The unset variables keep the defaults of New.
+func NewFromEnv() (EuroFxRef, error) {
+
+	cacheDir, setCacheDir := os.LookupEnv(EnvCacheDir)
+
+	efr := New(cacheDir, true)
+	if setCacheDir && cacheDir == "" {
+		efr.CacheDir = ""
+	}
+
+	if value := os.Getenv(EnvUrl); value != "" {
+		efr.Url = value
+	}
+
+	if value := os.Getenv(EnvTimeout); value != "" {
+		timeout, err := time.ParseDuration(value)
+		if err != nil || timeout <= 0 {
+			return EuroFxRef{}, fmt.Errorf("invalid %s \"%s\": want a positive duration, as \"30s\"",
+				EnvTimeout, value)
+		}
+		efr.Timeout = timeout
+	}
+
+	if value := os.Getenv(EnvOffline); value != "" {
+		offline, err := strconv.ParseBool(value)
+		if err != nil {
+			return EuroFxRef{}, fmt.Errorf("invalid %s \"%s\": want true or false", EnvOffline, value)
+		}
+		efr.Offline = offline
+	}
+
+	return efr, nil
+}
@@ -0,0 +1,51 @@
+//
+// Copyright 2023 The GoEurofxref Authors. All rights reserved.
+// Use of this source code is governed by a MIT License
+// license that can be found in the LICENSE file.
+// Last Modification: 2026-10-14 18:54:34
+//
+
+package eurofxref
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNewFromEnv(t *testing.T) {
+
+	cacheDir := t.TempDir()
+	t.Setenv(EnvUrl, "http://localhost/daily.xml")
+	t.Setenv(EnvCacheDir, cacheDir)
+	t.Setenv(EnvTimeout, "3s")
+	t.Setenv(EnvOffline, "1")
+
+	efr, err := NewFromEnv()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if efr.Url != "http://localhost/daily.xml" || efr.CacheDir != cacheDir ||
+		efr.Timeout != 3*time.Second || !efr.Offline {
+		t.Errorf("unexpected configuration %+v", efr)
+	}
+	if efr.HistUrl != New("", false).HistUrl {
+		t.Errorf("got HistUrl %s, want the default", efr.HistUrl)
+	}
+
+	t.Setenv(EnvCacheDir, "")
+	if efr, err := NewFromEnv(); err != nil || efr.CacheDir != "" {
+		t.Errorf("got cache directory %q (%v), want none", efr.CacheDir, err)
+	}
+
+	for name, value := range map[string]string{
+		EnvTimeout: "30",
+		EnvOffline: "maybe",
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Setenv(name, value)
+			if _, err := NewFromEnv(); err == nil {
+				t.Errorf("expected an error for %s=%s", name, value)
+			}
+		})
+	}
+}
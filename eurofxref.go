@@ -2,7 +2,7 @@
 // Copyright 2023 The GoEurofxref Authors. All rights reserved.
 // Use of this source code is governed by a MIT License
 // license that can be found in the LICENSE file.
-// Last Modification: 2023-05-18 18:55:56
+// Last Modification: 2026-10-15 18:05:00
 //
 // References:
 // https://www.ecb.europa.eu/stats/policy_and_exchange_rates/euro_reference_exchange_rates/html/index.en.html
@@ -15,227 +15,785 @@
 package eurofxref
 
 import (
+	"bytes"
+	"context"
+	"crypto/tls"
 	"encoding/xml"
 	"errors"
 	"fmt"
 	"io"
-	"net/http"
+	"io/fs"
+	"log/slog"
+	"net/url"
 	"os"
-	"path"
 	"path/filepath"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
+
+	"go.opentelemetry.io/otel/trace"
 )
 
+// Version is the version of the package, sent in DefaultUserAgent.
+const Version = "0.1.0"
+
+// DefaultTimeout bounds each request made to the ECB, including the
+// reading of the response. A zero Timeout means no limit, and the
+// deadline of the context of a call applies in any case.
+const DefaultTimeout = 60 * time.Second
+
+// DefaultUserAgent identifies the requests made to the ECB when no
+// UserAgent is configured.
+const DefaultUserAgent = "go-eurofxref/" + Version + " (+https://github.com/mrhdias/go-eurofxref)"
+
 type void struct{}
 
+// DefaultCacheDir returns the cache directory used by New when none is
+// given, the eurofxref subdirectory of os.UserCacheDir, or an empty
+// string, disabling the cache, if the user has no cache directory.
+func DefaultCacheDir() string {
+
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return ""
+	}
+
+	return filepath.Join(dir, "eurofxref")
+}
+
 type EuroFxRef struct {
 	Url            string
-	Timeout        int
+	HistUrl        string
+	Hist90Url      string
+	Timeout        time.Duration
 	CacheDir       string
 	CreateCacheDir bool
-	Currencies     map[string]void
-	Debug          bool
+	// CacheFS, when set, stores the cached files instead of CacheDir, e.g.
+	// in memory. Unlike those of a directory, its files are not locked
+	// against the other processes.
+	CacheFS CacheFS
+	// Currencies, when set, are the accepted currency codes, instead of
+	// those of the feed.
+	Currencies map[string]void
+	// ZipUrl and HistZipUrl are the zipped CSV equivalents of Url and
+	// HistUrl, several times smaller, downloaded instead when UseZip is
+	// set. The 90-day file has no zip download.
+	ZipUrl     string
+	HistZipUrl string
+	UseZip     bool
+	// Mirrors are the base URLs of copies of the ECB files, tried in order
+	// when the download of a file fails or does not answer 200: the file
+	// name of Url, HistUrl or Hist90Url is appended to each of them. A
+	// failing mirror is skipped for a while, longer after each failure.
+	Mirrors []string
+	// HedgeDelay, when positive, hedges the downloads across the URL and
+	// the Mirrors: the next one is requested each time HedgeDelay passes
+	// without an answer, or at once on a failure, and the first content
+	// downloaded is taken, cutting the latency of a slow endpoint.
+	HedgeDelay time.Duration
+	// Strict rejects the XML files that deviate from the envelopes of the
+	// ECB (namespace, sender, Cube structure, empty attributes), so that
+	// an error page served with status 200 never yields empty rates.
+	Strict bool
+	// Provider, when set, replaces the ECB files as the source of the
+	// rates; the fields configuring them are then unused.
+	Provider RateProvider
+	// DataApiUrl is the EXR dataflow of the ECB Data API, and UseDataAPI
+	// answers HistoryRange (and RateOn) from it, downloading a single
+	// series instead of a historical file. It is ignored when Offline.
+	DataApiUrl string
+	UseDataAPI bool
+	// ProxyUrl is the proxy of the requests, as "http://host:port". When
+	// empty, the HTTP_PROXY, HTTPS_PROXY and NO_PROXY environment
+	// variables apply.
+	ProxyUrl string
+	// TLSConfig, when set, configures the TLS connections, e.g. with the
+	// private CA of an internal mirror or a minimum version.
+	TLSConfig *tls.Config
+	// UserAgent is sent with every request, DefaultUserAgent when empty.
+	// Some egress proxies reject the default one of Go.
+	UserAgent string
+	// Logger receives the fetch, cache and parse events. They are
+	// discarded when it is nil.
+	Logger *slog.Logger
+	// TracerProvider, when set, traces the upstream requests, the cache
+	// reads and writes and the parsing of the XML.
+	TracerProvider trace.TracerProvider
+	// Offline forbids network access: the files are read from CacheDir
+	// whatever their age, and a file that was never cached fails with a
+	// *NotCachedError.
+	Offline bool
+	// Debug writes the debug events as text to DebugOutput, or to the
+	// standard output when nil. It is ignored when Logger is set.
+	Debug bool
+	// DebugOutput receives the output of Debug.
+	DebugOutput io.Writer
+	// DebugCategories selects the debug events logged, by Debug or by
+	// Logger, DefaultDebugCategories when zero.
+	DebugCategories DebugCategory
+	// RoundingMode is the rounding mode of the conversions that do not
+	// set one, RoundHalfUp when zero.
+	RoundingMode RoundingMode
+	// BreakerThreshold, when positive, is the number of consecutive
+	// failed downloads that open the circuit breaker: for BreakerCooldown
+	// (DefaultBreakerCooldown when zero) the files are read from the
+	// cache whatever their age, or fail at once with a *CircuitOpenError,
+	// instead of waiting on an unavailable ECB.
+	BreakerThreshold int
+	BreakerCooldown  time.Duration
+	// MaxFetchesPerHour and MaxFetchesPerDay, when positive, cap the
+	// downloads of a client over the last hour and day. Once a budget is
+	// spent the files are read from the cache whatever their age, or fail
+	// with a *BudgetExceededError.
+	MaxFetchesPerHour int
+	MaxFetchesPerDay  int
+	// MaxResponseSize is the largest download accepted, in bytes,
+	// DefaultMaxResponseSize when zero.
+	MaxResponseSize int64
+	// EmbeddedFallback serves the rates embedded in the package, flagged
+	// as Embedded, when the daily file can neither be downloaded nor read
+	// from the cache, e.g. on the first run without network.
+	EmbeddedFallback bool
+	// ServeStale serves the last cached rates, flagged as Stale with
+	// their Age, when the daily file cannot be refreshed, instead of an
+	// error. It is tried before EmbeddedFallback.
+	ServeStale bool
+	// FillPolicy answers the historical lookups of the dates without a
+	// publication, RateOn, RatesOn and ConvertOn, FillForward when zero.
+	FillPolicy FillPolicy
+	// RefreshCurrencies replaces the accepted currencies by those of
+	// every daily file read, so a currency added or dropped by the ECB is
+	// followed without a restart. Otherwise they are those of the first
+	// daily file read, or of the file embedded in the package before.
+	RefreshCurrencies bool
+	// Clock, when set, replaces the time of the system, e.g. to test the
+	// cache expiry or the schedule at a fixed time.
+	Clock Clock
+	// state is shared by the copies of the value returned by New.
+	state *state
 }
 
+// QueryResult is a reference rate: one unit of Base, the euro, is worth
+// RateValue units of Currency on the LastUpdate publication, whose date
+// is at midnight CET, the time zone of the ECB.
 type QueryResult struct {
 	LastUpdate time.Time
 	RateValue  float64
+	// Currency is the upper case code of the quoted currency.
+	Currency string
+	// Base is "EUR", but for the inverse rates.
+	Base string
+	// Embedded reports a rate of the daily file embedded in the package,
+	// served with EmbeddedFallback.
+	Embedded bool
+	// Stale reports a cached rate served with ServeStale, and Age the
+	// time since its download.
+	Stale bool
+	Age   time.Duration
 }
 
-func (efr EuroFxRef) ValidateCurrencyCode(currencyCode string) error {
+// SupportedCurrencies returns the codes of the currencies quoted against
+// the euro, sorted alphabetically: those of the daily file read, or of
+// the file embedded in the package before the first.
+func (efr EuroFxRef) SupportedCurrencies() []string {
 
-	if currencyCode == "" {
-		return errors.New("no currency code specified")
+	accepted := efr.currencies()
+	currencies := make([]string, 0, len(accepted))
+	for currencyCode := range accepted {
+		currencies = append(currencies, currencyCode)
 	}
+	sort.Strings(currencies)
+
+	return currencies
+}
+
+// ValidateCurrencyCode checks that the currency is quoted against the
+// euro. The errors tell the codes that are not currency codes at all, an
+// ErrInvalidCurrency, from the ISO 4217 codes the ECB does not publish,
+// an ErrUnsupportedCurrency.
+func (efr EuroFxRef) ValidateCurrencyCode(currencyCode string) error {
 
 	if len(currencyCode) != 3 {
-		return fmt.Errorf("the \"%s\" currency code has a wrong number of characters",
-			currencyCode)
+		return &InvalidCurrencyError{Code: currencyCode}
 	}
 
 	cc := strings.ToUpper(currencyCode)
-	if _, ok := efr.Currencies[cc]; !ok {
-		if strings.EqualFold(cc, "EUR") {
-			return errors.New("all currencies quoted against the euro (base currency)")
+	if _, ok := efr.currencies()[cc]; !ok {
+		if last, ok := Discontinued(cc); ok {
+			return &DiscontinuedError{Currency: cc, Last: last}
 		}
-		return fmt.Errorf("the currency code \"%s\" is not part of the reference list",
-			currencyCode)
+		if IsISOCurrency(cc) {
+			return &UnsupportedCurrencyError{Code: currencyCode}
+		}
+		return &InvalidCurrencyError{Code: currencyCode}
 	}
 
 	return nil
 }
 
-func (efr EuroFxRef) Daily(currencyCode string) (*QueryResult, error) {
+// RateCube is the rate of a currency in a TimeCube, as written in the
+// XML files of the ECB.
+type RateCube struct {
+	Text     string `xml:",chardata"`
+	Currency string `xml:"currency,attr"`
+	Rate     string `xml:"rate,attr"`
+}
 
-	if err := efr.ValidateCurrencyCode(currencyCode); err != nil {
-		if strings.EqualFold(strings.ToUpper(currencyCode), "EUR") {
-			return &QueryResult{
-				LastUpdate: time.Now().UTC(),
-				RateValue:  1.00,
-			}, nil
-		}
+// TimeCube is a publication of an Envelope: its date, as "2006-01-02",
+// and the rates of the currencies.
+type TimeCube struct {
+	Text string     `xml:",chardata"`
+	Time string     `xml:"time,attr"`
+	Cube []RateCube `xml:"Cube"`
+}
 
-		return nil, err
+// Envelope is the XML of the daily and historical files of the ECB, the
+// publications from the most recent, for the users of the ECB files kept
+// elsewhere. ParseDaily and ParseHist convert them into rate tables.
+type Envelope struct {
+	XMLName xml.Name `xml:"Envelope"`
+	Text    string   `xml:",chardata"`
+	Gesmes  string   `xml:"gesmes,attr"`
+	Xmlns   string   `xml:"xmlns,attr"`
+	Subject string   `xml:"subject"`
+	Sender  struct {
+		Text string `xml:",chardata"`
+		Name string `xml:"name"`
+	} `xml:"Sender"`
+	Cube struct {
+		Text string     `xml:",chardata"`
+		Cube []TimeCube `xml:"Cube"`
+	} `xml:"Cube"`
+}
+
+// NotCachedError is returned in Offline mode when the file at Url has no
+// copy in the cache directory. Path is empty without a cache directory.
+type NotCachedError struct {
+	Url  string
+	Path string
+}
+
+func (e *NotCachedError) Error() string {
+
+	if e.Path == "" {
+		return fmt.Sprintf("offline mode: \"%s\" cannot be read without a cache directory", e.Url)
 	}
 
-	req, err := http.NewRequest("GET", efr.Url, nil)
-	// req.Header.Add("User-Agent", fmt.Sprintf("%s/%s", userAgent, version))
+	return fmt.Sprintf("offline mode: \"%s\" is not cached in \"%s\"", e.Url, e.Path)
+}
+
+// fetch returns the content of the ECB file at fileUrl, reading it from
+// the cache directory when a copy downloaded today is available, unless
+// refresh forces it to be downloaded again.
+func (efr EuroFxRef) fetch(ctx context.Context, fileUrl string, refresh bool) ([]byte, error) {
+
+	contentBytes, _, err := efr.fetchConditional(ctx, fileUrl, refresh, nil)
+
+	return contentBytes, err
+}
+
+// fetchConditional is fetch with the validators of a previous download
+// of fileUrl unless nil: the content is then nil when it is unchanged
+// (304), and the cached copy is kept. The validators of the download are
+// returned, nil when the file is read from the cache.
+func (efr EuroFxRef) fetchConditional(ctx context.Context, fileUrl string, refresh bool,
+	previous *validators) (contentBytes []byte, current *validators, err error) {
+
+	ctx, span := efr.startSpan(ctx, "eurofxref.fetch", attrUrl.String(fileUrl))
+	defer func() { endSpan(span, err) }()
 
+	reqUrl, err := url.Parse(fileUrl)
 	if err != nil {
 		// log.Fatalf("[Fatal] %v\r\n", err)
-		return nil, fmt.Errorf("client could not create request: %v", err)
+		return nil, nil, fmt.Errorf("client could not create request: %v", err)
 	}
 
-	xmlFilename := path.Base(req.URL.Path)
-	xmlFilePath := filepath.Join(efr.CacheDir, xmlFilename)
-	// fmt.Println(xmlFilePath)
+	logger := efr.logger()
+
+	cache := efr.cacheFS()
+	xmlFilename := cacheFilename(reqUrl)
+	xmlFilePath := efr.CachePath(fileUrl)
 
 	expired := false
 	getFromCache := false
+	unlockCache := func() {}
+	defer func() { unlockCache() }()
 
 	if err := func() error {
-		if efr.CacheDir == "" {
+		if efr.Offline {
+			if cache == nil {
+				return &NotCachedError{Url: fileUrl}
+			}
+			if fileStat, err := fs.Stat(cache, xmlFilename); err != nil || fileStat.Size() == 0 {
+				return &NotCachedError{Url: fileUrl, Path: xmlFilePath}
+			}
+			getFromCache = true
+			return nil
+		}
+
+		if cache == nil {
 			return nil
 		}
 
-		// create the cache directory if it does not exist
-		if _, err := os.Stat(efr.CacheDir); errors.Is(err, os.ErrNotExist) {
-			if efr.CreateCacheDir {
-				if err := os.Mkdir(efr.CacheDir, os.ModePerm); err != nil {
+		if efr.CacheFS == nil {
+			// create the cache directory if it does not exist
+			if _, err := os.Stat(efr.CacheDir); errors.Is(err, os.ErrNotExist) {
+				if !efr.CreateCacheDir {
+					return nil
+				}
+				if err := os.MkdirAll(efr.CacheDir, os.ModePerm); err != nil {
 					return fmt.Errorf("error creating cache directory: %v", err)
 				}
+				logger.Info("created cache directory", slog.String("dir", efr.CacheDir))
 			}
-			return nil
+
+			// held until the file is read or refreshed, so that the
+			// processes sharing the cache directory download it only once
+			unlock, err := lockFile(ctx, xmlFilePath+".lock")
+			if err != nil {
+				return fmt.Errorf("error locking the cached xml file: %v", err)
+			}
+			unlockCache = unlock
 		}
 
-		if fileStat, err := os.Stat(xmlFilePath); err == nil {
-			// fmt.Println(fileStat.ModTime())
-			if (fileStat.ModTime().Local().Day() != time.Now().Local().Day()) || (fileStat.Size() == 0) {
+		if fileStat, err := fs.Stat(cache, xmlFilename); err == nil {
+			if !sameLocalDay(fileStat.ModTime(), efr.Now()) || fileStat.Size() == 0 {
 				expired = true
 				return nil
 			}
-			getFromCache = true
+			getFromCache = !refresh
+			expired = refresh
 			return nil
 		}
 
 		return nil
 	}(); err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
-	// fmt.Println("GetFromCache:", xmlFilePath, getFromCache)
+	span.SetAttributes(attrCacheHit.Bool(getFromCache))
+	if cache != nil {
+		efr.state.countCache(getFromCache)
+	}
 
-	contentBytes, err := func() ([]byte, error) {
+	contentBytes, err = func() ([]byte, error) {
 		if getFromCache {
-			data, err := os.ReadFile(xmlFilePath)
+			_, readSpan := efr.startSpan(ctx, "eurofxref.cache.read", attrCacheFile.String(xmlFilePath))
+			data, err := readCached(cache, xmlFilename)
 			if err != nil {
-				return nil, fmt.Errorf("error reading the cached xml file: %v", err)
+				err = fmt.Errorf("error reading the cached xml file: %v", err)
+				endSpan(readSpan, err)
+				return nil, err
 			}
+			endSpan(readSpan, nil)
+			efr.debug(DebugCache, "cache hit", slog.String("file", xmlFilePath))
 			return data, nil
 		}
 
-		client := &http.Client{
-			Timeout: time.Duration(time.Duration(efr.Timeout).Seconds()),
+		if expired {
+			efr.debug(DebugCache, "cache expired", slog.String("file", xmlFilePath))
 		}
 
-		resp, err := client.Do(req)
-		if err != nil {
-			return nil, fmt.Errorf("error making http request: %v", err)
+		if err := efr.allowFetch(fileUrl, efr.Now()); err != nil {
+			if expired {
+				if data, readErr := readCached(cache, xmlFilename); readErr == nil {
+					logger.Warn("serving the expired cache", slog.String("file", xmlFilePath),
+						slog.Any("reason", err))
+					return data, nil
+				}
+			}
+			return nil, err
 		}
 
-		defer resp.Body.Close()
+		logger.Info("fetching", slog.String("url", fileUrl))
+		start := time.Now()
 
-		if resp.StatusCode != http.StatusOK {
-			return nil, fmt.Errorf("the request get \"%s\" returned an error with status code %d",
-				efr.Url, resp.StatusCode)
+		respContentBytes, respValidators, err := efr.getMirrored(ctx, fileUrl, previous)
+		if err == nil && respContentBytes == nil {
+			efr.state.downloadSucceeded()
+			efr.debug(DebugRequests, "not modified", slog.String("url", fileUrl))
+			current = respValidators
+			return nil, nil
+		}
+		if err == nil {
+			// an error page must not be cached
+			err = efr.validatePayload(fileUrl, respContentBytes)
 		}
-
-		respContentBytes, err := io.ReadAll(resp.Body)
 		if err != nil {
-			return nil, fmt.Errorf("client could not read response body: %v", err)
+			if ctx.Err() == nil &&
+				efr.state.downloadFailed(efr.BreakerThreshold, efr.breakerCooldown(), efr.Now()) {
+				logger.Warn("circuit breaker opened", slog.Duration("cooldown", efr.breakerCooldown()))
+			}
+			return nil, err
 		}
+		efr.state.downloadSucceeded()
+		current = respValidators
+
+		efr.debug(DebugRequests, "fetched",
+			slog.String("url", fileUrl),
+			slog.Int("size", len(respContentBytes)),
+			slog.Duration("duration", time.Since(start)))
+
+		if cache != nil {
+			if err := func() (err error) {
+				_, writeSpan := efr.startSpan(ctx, "eurofxref.cache.write", attrCacheFile.String(xmlFilePath))
+				defer func() { endSpan(writeSpan, err) }()
+
+				// the historical files are kept compressed, a tenth of
+				// their size, and decompressed when read
+				cached := respContentBytes
+				if fileUrl == efr.HistUrl || fileUrl == efr.Hist90Url {
+					if cached, err = gzipBytes(respContentBytes); err != nil {
+						return fmt.Errorf("error compressing the cached xml file: %v", err)
+					}
+				}
 
-		if efr.CacheDir != "" {
-			if expired {
-				if err := os.Remove(xmlFilePath); err != nil {
-					return nil, fmt.Errorf("error removing cached xml file: %v", err)
+				// replaces an expired copy
+				if err := efr.writeCache(cache, xmlFilename, cached); err != nil {
+					return fmt.Errorf("error writing the cached xml file: %v", err)
 				}
-			}
 
-			if err := os.WriteFile(xmlFilePath, respContentBytes, 0644); err != nil {
-				return nil, fmt.Errorf("error writing the cached xml file: %v", err)
+				return nil
+			}(); err != nil {
+				return nil, err
 			}
+			efr.debug(DebugCache, "cached", slog.String("file", xmlFilePath))
 		}
 
 		return respContentBytes, nil
 	}()
 	if err != nil {
-		return nil, err
+		logger.Warn("fetch failed", slog.String("url", fileUrl), slog.Any("error", err))
+		return nil, nil, err
 	}
 
-	if efr.Debug {
-		fmt.Println(string(contentBytes))
+	efr.debug(DebugContent, "content", slog.String("url", fileUrl), slog.String("content", string(contentBytes)))
+
+	return contentBytes, current, nil
+}
+
+// fetchEnvelope downloads (or reads from the cache) and parses the ECB
+// file at fileUrl.
+func (efr EuroFxRef) fetchEnvelope(ctx context.Context, fileUrl string, refresh bool) (*Envelope, error) {
+
+	contentBytes, err := efr.fetch(ctx, fileUrl, refresh)
+	if err != nil {
+		return nil, err
 	}
 
-	type CubeElement struct {
-		Text     string `xml:",chardata"`
-		Currency string `xml:"currency,attr"`
-		Rate     string `xml:"rate,attr"`
+	return efr.decodeEnvelope(ctx, fileUrl, contentBytes)
+}
+
+// decodeEnvelope validates, in the strict mode, and parses the content
+// of the ECB file at fileUrl.
+func (efr EuroFxRef) decodeEnvelope(ctx context.Context, fileUrl string, contentBytes []byte) (*Envelope, error) {
+
+	var err error
+	_, span := efr.startSpan(ctx, "eurofxref.parse", attrUrl.String(fileUrl))
+	if efr.Strict {
+		err = validateStrict(bytes.NewReader(contentBytes), fileUrl == efr.Url)
+	}
+	var envelope *Envelope
+	if err == nil {
+		envelope, err = ParseEnvelope(contentBytes)
+	}
+	if err != nil {
+		endSpan(span, err)
+		efr.logger().Error("parse failed", slog.String("url", fileUrl), slog.Any("error", err))
+		return nil, err
 	}
 
-	type Envelope struct {
-		XMLName xml.Name `xml:"Envelope"`
-		Text    string   `xml:",chardata"`
-		Gesmes  string   `xml:"gesmes,attr"`
-		Xmlns   string   `xml:"xmlns,attr"`
-		Subject string   `xml:"subject"`
-		Sender  struct {
-			Text string `xml:",chardata"`
-			Name string `xml:"name"`
-		} `xml:"Sender"`
-		Cube struct {
-			Text string `xml:",chardata"`
-			Cube struct {
-				Text string        `xml:",chardata"`
-				Time string        `xml:"time,attr"`
-				Cube []CubeElement `xml:"Cube"`
-			} `xml:"Cube"`
-		} `xml:"Cube"`
+	span.SetAttributes(attrPublications.Int(len(envelope.Cube.Cube)))
+	if len(envelope.Cube.Cube) > 0 {
+		span.SetAttributes(attrPublicationDate.String(envelope.Cube.Cube[0].Time))
 	}
+	endSpan(span, nil)
+
+	efr.debug(DebugParse, "parsed",
+		slog.String("url", fileUrl),
+		slog.Int("publications", len(envelope.Cube.Cube)))
+
+	return envelope, nil
+}
+
+// ParseEnvelope parses the XML of an ECB file, with the limits of the
+// untrusted content of the downloads.
+func ParseEnvelope(contentBytes []byte) (*Envelope, error) {
 
 	var envelope Envelope
 
+	if err := scanXML(contentBytes); err != nil {
+		return nil, err
+	}
 	if err := xml.Unmarshal(contentBytes, &envelope); err != nil {
 		return nil, fmt.Errorf("error when unmarshal parses the XML-encoded data: %v", err)
 	}
 
-	// fmt.Println(envelope.Cube.Cube.Time)
+	return &envelope, nil
+}
 
-	for _, rate := range envelope.Cube.Cube.Cube {
-		if strings.EqualFold(rate.Currency, strings.ToUpper(currencyCode)) {
-			rateValue, err := strconv.ParseFloat(rate.Rate, 64)
-			if err != nil {
-				return nil, fmt.Errorf("error when convert rate string from envelope to float: %v", err)
-			}
+// Table returns the rate table of the publication.
+func (cube TimeCube) Table() (*RateTable, error) {
 
-			cubeTime, err := time.Parse("2006-01-02", envelope.Cube.Cube.Time)
-			if err != nil {
-				return nil, fmt.Errorf("error when convert time string from envelope to float: %v", err)
-			}
+	date, rates, err := cube.rates()
+	if err != nil {
+		return nil, err
+	}
+
+	return &RateTable{Date: date, Rates: rates}, nil
+}
+
+// rates converts the rates of a time cube into a map indexed by the
+// currency code.
+func (cube TimeCube) rates() (time.Time, map[string]float64, error) {
+
+	cubeTime, err := parseDate(cube.Time)
+	if err != nil {
+		return time.Time{}, nil, fmt.Errorf("error when convert time string from envelope to time: %v", err)
+	}
+
+	rates := make(map[string]float64, len(cube.Cube))
+	for _, rate := range cube.Cube {
+		rateValue, err := strconv.ParseFloat(rate.Rate, 64)
+		if err != nil {
+			return time.Time{}, nil, fmt.Errorf("error when convert rate string from envelope to float: %v", err)
+		}
+		rates[strings.ToUpper(rate.Currency)] = rateValue
+	}
+
+	return cubeTime, rates, nil
+}
+
+// DailyRates returns the table of the reference rates of the latest
+// publication.
+func (efr EuroFxRef) DailyRates() (*RateTable, error) {
+	return efr.DailyRatesContext(context.Background())
+}
+
+// DailyRatesContext is like DailyRates, with the request and the spans
+// bound to ctx.
+func (efr EuroFxRef) DailyRatesContext(ctx context.Context) (*RateTable, error) {
+
+	if table, ok := efr.state.warmTable(); ok {
+		return table.clone(), nil
+	}
+
+	table, err := efr.fetchDailyRates(ctx, false)
+	if err != nil {
+		if stale, ok := efr.staleRates(ctx, err); ok {
+			return stale, nil
+		}
+		if embedded, ok := efr.embeddedFallback(err); ok {
+			return embedded, nil
+		}
+		return nil, err
+	}
+
+	return table, nil
+}
+
+// CachedRates returns the latest rates the client has without any
+// request: those kept by the background refresh or in memory, or else
+// those of the cached daily file, whatever its age. With a Provider, they
+// are the latest rates of the provider.
+func (efr EuroFxRef) CachedRates(ctx context.Context) (*RateTable, bool) {
+
+	if table, ok := efr.state.warmTable(); ok {
+		return table.clone(), true
+	}
+
+	if efr.Provider == nil {
+		fileUrl := efr.Url
+		if efr.UseZip {
+			fileUrl = efr.ZipUrl
+		}
+		// not worth a failed read, and its warning
+		cache := efr.cacheFS()
+		if cache == nil {
+			return nil, false
+		}
+		if _, err := fs.Stat(cache, cacheName(fileUrl)); err != nil {
+			return nil, false
+		}
+	}
+
+	if efr.cacheFS() != nil && efr.Provider == nil {
+		if table, ok := efr.state.memoized(efr.memoKey(), efr.Now()); ok {
+			return table, true
+		}
+	}
+
+	// not recorded: an expired file must not be memoized as the rates of
+	// the day, nor notified to the subscribers
+	offline := efr
+	offline.Offline = true
+	table, err := offline.readDailyRates(ctx, false)
+
+	return table, err == nil
+}
+
+// fetchDailyRates returns the rates of the daily file, downloaded again
+// when refresh is set. With a cache directory, the file is parsed once and
+// the rates are kept in memory until the next publication.
+func (efr EuroFxRef) fetchDailyRates(ctx context.Context, refresh bool) (*RateTable, error) {
+
+	memo := efr.cacheFS() != nil && efr.Provider == nil
+	if memo && !refresh {
+		if table, ok := efr.state.memoized(efr.memoKey(), efr.Now()); ok {
+			efr.debug(DebugCache, "memo hit", slog.String("publication", table.Date.Format("2006-01-02")))
+			efr.state.countCache(true)
+			return table, nil
+		}
+	}
+
+	table, err := efr.readDailyRates(ctx, refresh)
+	if err != nil {
+		return nil, err
+	}
+	efr.record(table)
+
+	return table, nil
+}
+
+// readDailyRates is fetchDailyRates without the memo and the record of
+// the table.
+func (efr EuroFxRef) readDailyRates(ctx context.Context, refresh bool) (*RateTable, error) {
+
+	rates, lastUpdate, err := func() (map[string]float64, time.Time, error) {
+		if efr.Provider != nil {
+			return efr.Provider.Latest(ctx)
+		}
+		if efr.UseZip {
+			return efr.fetchZipDailyRates(ctx, refresh)
+		}
+
+		envelope, err := efr.fetchEnvelope(ctx, efr.Url, refresh)
+		if err != nil {
+			return nil, time.Time{}, err
+		}
+
+		if len(envelope.Cube.Cube) == 0 {
+			return nil, time.Time{}, errors.New("the envelope has no reference rates")
+		}
+
+		lastUpdate, rates, err := envelope.Cube.Cube[0].rates()
+		return rates, lastUpdate, err
+	}()
+	if err != nil {
+		return nil, err
+	}
+
+	return &RateTable{Date: lastUpdate, Rates: rates}, nil
+}
+
+// record keeps the table of a daily file read: its currencies, its memo
+// and the notification of the subscribers.
+func (efr EuroFxRef) record(table *RateTable) {
+
+	efr.state.learnCurrencies(table.Rates, efr.RefreshCurrencies)
+	// an expired file, served while the downloads are refused, is read
+	// again by the next call
+	if efr.cacheFS() != nil && efr.Provider == nil && !efr.dailyCacheExpired() {
+		efr.state.memoize(efr.memoKey(), table, efr.Now())
+	}
+	efr.observe(table)
+}
+
+func (efr EuroFxRef) Daily(currencyCode string) (*QueryResult, error) {
+	return efr.DailyContext(context.Background(), currencyCode)
+}
+
+// DailyContext is like Daily, with the request and the spans bound to
+// ctx.
+func (efr EuroFxRef) DailyContext(ctx context.Context, currencyCode string) (result *QueryResult, err error) {
 
-			return &QueryResult{
-				LastUpdate: cubeTime.UTC(),
-				RateValue:  rateValue,
-			}, nil
+	ctx, span := efr.startSpan(ctx, "eurofxref.Daily",
+		attrCurrency.String(strings.ToUpper(currencyCode)))
+	defer func() {
+		if result != nil {
+			span.SetAttributes(attrPublicationDate.String(result.LastUpdate.Format("2006-01-02")))
 		}
+		endSpan(span, err)
+	}()
+
+	// the euro is quoted as 1.00 on the date of the latest publication
+	if err := efr.ValidateCurrencyCode(currencyCode); err != nil && !strings.EqualFold(currencyCode, "EUR") {
+		return nil, err
+	}
+
+	table, err := efr.DailyRatesContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if rateValue, ok := table.Get(currencyCode); ok {
+		return &QueryResult{
+			LastUpdate: table.Date,
+			RateValue:  rateValue,
+			Currency:   strings.ToUpper(currencyCode),
+			Base:       "EUR",
+			Embedded:   table.Embedded,
+			Stale:      table.Stale,
+			Age:        table.Age,
+		}, nil
 	}
 
 	return nil, fmt.Errorf("no conversion rate value was returned for \"%s\" currency code",
 		currencyCode)
 }
 
+// DailyMulti returns the latest reference rates of several currencies,
+// indexed by their upper case codes, reading the daily file once. The euro
+// can be requested and is quoted as 1.00 on the publication date.
+func (efr EuroFxRef) DailyMulti(currencyCodes ...string) (map[string]*QueryResult, error) {
+	return efr.DailyMultiContext(context.Background(), currencyCodes...)
+}
+
+// DailyMultiContext is like DailyMulti, with the request and the spans
+// bound to ctx.
+func (efr EuroFxRef) DailyMultiContext(ctx context.Context, currencyCodes ...string) (map[string]*QueryResult, error) {
+
+	for _, currencyCode := range currencyCodes {
+		if strings.EqualFold(currencyCode, "EUR") {
+			continue
+		}
+		if err := efr.ValidateCurrencyCode(currencyCode); err != nil {
+			return nil, err
+		}
+	}
+
+	table, err := efr.DailyRatesContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make(map[string]*QueryResult, len(currencyCodes))
+	for _, currencyCode := range currencyCodes {
+		currencyCode = strings.ToUpper(currencyCode)
+		rateValue, ok := table.Get(currencyCode)
+		if !ok {
+			return nil, fmt.Errorf("no conversion rate value was returned for \"%s\" currency code",
+				currencyCode)
+		}
+		results[currencyCode] = &QueryResult{
+			LastUpdate: table.Date,
+			RateValue:  rateValue,
+			Currency:   currencyCode,
+			Base:       "EUR",
+			Embedded:   table.Embedded,
+			Stale:      table.Stale,
+			Age:        table.Age,
+		}
+	}
+
+	return results, nil
+}
+
+// New returns a client of the ECB files caching them in cacheDir, which
+// is created if missing when createCacheDir is set. Without cacheDir the
+// DefaultCacheDir is used, and created; set CacheDir to an empty string
+// afterwards to disable the cache.
 func New(
 	cacheDir string,
 	createCacheDir bool,
@@ -247,21 +805,23 @@ func New(
 	}
 
 	eurofxref := new(EuroFxRef)
-
-	eurofxref.Currencies = map[string]void{
-		"USD": {}, "JPY": {}, "BGN": {}, "CZK": {}, "DKK": {},
-		"GBP": {}, "HUF": {}, "PLN": {}, "RON": {}, "SEK": {},
-		"CHF": {}, "ISK": {}, "NOK": {}, "TRY": {}, "AUD": {},
-		"BRL": {}, "CAD": {}, "CNY": {}, "HKD": {}, "IDR": {},
-		"ILS": {}, "INR": {}, "KRW": {}, "MXN": {}, "MYR": {},
-		"NZD": {}, "PHP": {}, "SGD": {}, "THB": {}, "ZAR": {},
-	}
+	eurofxref.state = &state{}
 
 	eurofxref.Url = "https://www.ecb.europa.eu/stats/eurofxref/eurofxref-daily.xml"
-	eurofxref.Timeout = 60
+	eurofxref.HistUrl = "https://www.ecb.europa.eu/stats/eurofxref/eurofxref-hist.xml"
+	eurofxref.Hist90Url = "https://www.ecb.europa.eu/stats/eurofxref/eurofxref-hist-90d.xml"
+	eurofxref.ZipUrl = "https://www.ecb.europa.eu/stats/eurofxref/eurofxref.zip"
+	eurofxref.HistZipUrl = "https://www.ecb.europa.eu/stats/eurofxref/eurofxref-hist.zip"
+	eurofxref.DataApiUrl = DefaultDataApiUrl
+	eurofxref.Timeout = DefaultTimeout
+	eurofxref.UserAgent = DefaultUserAgent
 	// cache xml file only 24 hours
 	eurofxref.CacheDir = cacheDir
 	eurofxref.CreateCacheDir = createCacheDir
+	if cacheDir == "" {
+		eurofxref.CacheDir = DefaultCacheDir()
+		eurofxref.CreateCacheDir = true
+	}
 	eurofxref.Debug = debug
 
 	return *eurofxref
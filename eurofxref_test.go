@@ -2,19 +2,28 @@
 // Copyright 2023 The GoEurofxref Authors. All rights reserved.
 // Use of this source code is governed by a MIT License
 // license that can be found in the LICENSE file.
-// Last Modification: 2023-05-17 22:15:25
+// Last Modification: 2026-10-14 19:04:11
 //
 
 package eurofxref
 
 import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
 	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
 	"testing"
+	"time"
 )
 
 func TestEuroFxRef(t *testing.T) {
 
-	cacheDir := "./eurofxref_cache"
+	cacheDir := filepath.Join(t.TempDir(), "eurofxref_cache")
 	query := New(cacheDir, true)
 
 	if err := query.ValidateCurrencyCode("USD"); err != nil {
@@ -33,11 +42,182 @@ func TestEuroFxRef(t *testing.T) {
 	if got.RateValue != want {
 		t.Errorf("got = %.2f, want %.2f", got.RateValue, want)
 	}
+}
+
+func TestSupportedCurrencies(t *testing.T) {
+
+	currencies := New("", false).SupportedCurrencies()
+	if len(currencies) != 30 {
+		t.Errorf("got %d currencies, want 30", len(currencies))
+	}
+	if !sort.StringsAreSorted(currencies) {
+		t.Errorf("the currencies are not sorted: %v", currencies)
+	}
+	if currencies[0] != "AUD" || currencies[len(currencies)-1] != "ZAR" {
+		t.Errorf("got %v", currencies)
+	}
+}
+
+func TestOffline(t *testing.T) {
+
+	ts, query := newTestServer(t)
+	query.CacheDir = t.TempDir()
+
+	// warm the cache, then make the network unreachable
+	if _, err := query.Daily("USD"); err != nil {
+		t.Fatal(err)
+	}
+	ts.Close()
+
+	query.Offline = true
+	result, err := query.Daily("USD")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.RateValue != 1.0876 {
+		t.Errorf("got %v, want the cached 1.0876", result.RateValue)
+	}
+
+	// cached files stay usable whatever their age
+	old := time.Now().AddDate(0, 0, -7)
+	os.Chtimes(query.CachePath(query.Url), old, old)
+	if _, err := query.Daily("USD"); err != nil {
+		t.Errorf("stale cache in offline mode: %v", err)
+	}
+
+	_, err = query.History("USD")
+	var notCached *NotCachedError
+	if !errors.As(err, &notCached) {
+		t.Fatalf("got %v, want a *NotCachedError", err)
+	}
+	if notCached.Url != query.Hist90Url {
+		t.Errorf("got url %s, want %s", notCached.Url, query.Hist90Url)
+	}
+
+	query.CacheDir = ""
+	if _, err := query.Daily("USD"); !errors.As(err, &notCached) {
+		t.Errorf("got %v without a cache directory, want a *NotCachedError", err)
+	}
+}
+
+func TestCachedRates(t *testing.T) {
+
+	ts, query := newTestServer(t)
+	query.CacheDir = t.TempDir()
+
+	if _, ok := query.CachedRates(context.Background()); ok {
+		t.Fatal("got rates before the first download")
+	}
+
+	if _, err := query.Daily("USD"); err != nil {
+		t.Fatal(err)
+	}
+	ts.Close()
 
-	if _, err := os.Stat(cacheDir); !os.IsNotExist(err) {
-		if err = os.RemoveAll(cacheDir); err != nil {
-			t.Fatal(err)
+	// read again from the cached file by another client
+	other := query
+	other.state = &state{}
+	for _, client := range []EuroFxRef{query, other} {
+		table, ok := client.CachedRates(context.Background())
+		if !ok || table.Rates["USD"] != 1.0876 {
+			t.Errorf("got %+v (%v), want the cached rates", table, ok)
 		}
 	}
+}
+
+func TestCachedRatesDoNotMemoizeExpiredFile(t *testing.T) {
 
+	daily, err := os.ReadFile("testdata/eurofxref-daily.xml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	monday := strings.ReplaceAll(string(daily), "2024-03-01", "2024-03-04")
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(monday))
+	}))
+	defer ts.Close()
+
+	query := New(t.TempDir(), false)
+	query.Url = ts.URL + "/eurofxref-daily.xml"
+	query.Clock = FixedClock(time.Date(2024, 3, 4, 17, 0, 0, 0, CET))
+
+	// the file cached on Friday
+	path := query.CachePath(query.Url)
+	if err := os.WriteFile(path, daily, 0644); err != nil {
+		t.Fatal(err)
+	}
+	friday := time.Date(2024, 3, 1, 17, 0, 0, 0, CET)
+	if err := os.Chtimes(path, friday, friday); err != nil {
+		t.Fatal(err)
+	}
+
+	// a readiness probe
+	if table, ok := query.CachedRates(context.Background()); !ok || !table.Date.Equal(PublicationDate(2024, 3, 1)) {
+		t.Fatalf("got %+v (%v), want the cached rates of Friday", table, ok)
+	}
+
+	table, err := query.DailyRates()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !table.Date.Equal(PublicationDate(2024, 3, 4)) {
+		t.Errorf("got the rates of %s, want those of Monday", table.Date.Format("2006-01-02"))
+	}
+}
+
+func TestUserAgent(t *testing.T) {
+
+	userAgents := make(chan string, 2)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		userAgents <- r.UserAgent()
+		http.ServeFile(w, r, "testdata/eurofxref-daily.xml")
+	}))
+	defer ts.Close()
+
+	query := New("", false)
+	query.CacheDir = ""
+	query.Url = ts.URL + "/eurofxref-daily.xml"
+
+	if _, err := query.Daily("USD"); err != nil {
+		t.Fatal(err)
+	}
+	if got := <-userAgents; got != DefaultUserAgent {
+		t.Errorf("got User-Agent %q, want %q", got, DefaultUserAgent)
+	}
+
+	query.UserAgent = "treasury-batch/2.1"
+	if _, err := query.Daily("USD"); err != nil {
+		t.Fatal(err)
+	}
+	if got := <-userAgents; got != "treasury-batch/2.1" {
+		t.Errorf("got User-Agent %q, want treasury-batch/2.1", got)
+	}
+}
+
+func TestDefaultCacheDir(t *testing.T) {
+
+	cacheHome := t.TempDir()
+	t.Setenv("XDG_CACHE_HOME", cacheHome)
+	t.Setenv("HOME", cacheHome)
+	t.Setenv("LocalAppData", cacheHome)
+
+	want := filepath.Join(cacheHome, "eurofxref")
+	if runtime.GOOS == "darwin" {
+		want = filepath.Join(cacheHome, "Library", "Caches", "eurofxref")
+	}
+
+	query := New("", false)
+	if query.CacheDir != want || !query.CreateCacheDir {
+		t.Errorf("got cache directory %q (create %v), want %q", query.CacheDir, query.CreateCacheDir, want)
+	}
+
+	_, served := newTestServer(t)
+	query.Url = served.Url
+	query.CacheDir = filepath.Join(cacheHome, "nested", "eurofxref")
+	if _, err := query.Daily("USD"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(query.CachePath(query.Url)); err != nil {
+		t.Errorf("the nested cache directory was not created: %v", err)
+	}
 }
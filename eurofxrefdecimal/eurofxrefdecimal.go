@@ -0,0 +1,116 @@
+//
+// Copyright 2023 The GoEurofxref Authors. All rights reserved.
+// Use of this source code is governed by a MIT License
+// license that can be found in the LICENSE file.
+// Last Modification: 2026-10-14 19:05:00
+//
+
+// Package eurofxrefdecimal exposes the reference rates and the
+// conversions of eurofxref as github.com/shopspring/decimal values.
+//
+// The ECB publishes rates of at most six significant digits, which a
+// float64 holds without loss: they are converted to the shortest decimal
+// representing the float64, exactly the published value, and the
+// conversions are computed in decimal arithmetic.
+package eurofxrefdecimal
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	eurofxref "github.com/mrhdias/go-eurofxref"
+	"github.com/shopspring/decimal"
+)
+
+// DivisionPrecision is the number of decimals of the rates and values
+// computed by a division.
+var DivisionPrecision int32 = 16
+
+// FromRate returns the published value of a reference rate.
+func FromRate(rate float64) decimal.Decimal {
+	return decimal.NewFromFloat(rate)
+}
+
+// Rate returns the rate of a result.
+func Rate(result *eurofxref.QueryResult) decimal.Decimal {
+	return FromRate(result.RateValue)
+}
+
+// Rates returns the rates of a table indexed by the currency code.
+func Rates(table *eurofxref.RateTable) map[string]decimal.Decimal {
+
+	rates := make(map[string]decimal.Decimal, len(table.Rates))
+	for currency, rate := range table.Rates {
+		rates[currency] = FromRate(rate)
+	}
+
+	return rates
+}
+
+// Daily returns the latest reference rate of the currency, along with its
+// publication date.
+func Daily(ctx context.Context, source eurofxref.RateSource, currencyCode string) (decimal.Decimal,
+	time.Time, error) {
+
+	result, err := source.DailyContext(ctx, currencyCode)
+	if err != nil {
+		return decimal.Zero, time.Time{}, err
+	}
+
+	return Rate(result), result.LastUpdate, nil
+}
+
+// Conversion is the outcome of converting an amount between two
+// currencies through their euro reference rates.
+type Conversion struct {
+	From       string
+	To         string
+	Amount     decimal.Decimal
+	Rate       decimal.Decimal // units of To per unit of From
+	Value      decimal.Decimal
+	LastUpdate time.Time
+}
+
+// Convert converts an amount of the from currency into the to currency
+// using the latest reference rates of source. The value is not rounded;
+// use decimal.Decimal.Round or RoundBank on the minor units of the
+// currency.
+func Convert(ctx context.Context, source eurofxref.RateSource, amount decimal.Decimal,
+	from, to string) (*Conversion, error) {
+
+	from, to = strings.ToUpper(from), strings.ToUpper(to)
+
+	fromRate, lastUpdate, err := euroRate(ctx, source, from)
+	if err != nil {
+		return nil, err
+	}
+	toRate, toUpdate, err := euroRate(ctx, source, to)
+	if err != nil {
+		return nil, err
+	}
+	if toUpdate.After(lastUpdate) {
+		lastUpdate = toUpdate
+	}
+
+	return &Conversion{
+		From:       from,
+		To:         to,
+		Amount:     amount,
+		Rate:       toRate.DivRound(fromRate, DivisionPrecision),
+		Value:      amount.Mul(toRate).DivRound(fromRate, DivisionPrecision),
+		LastUpdate: lastUpdate,
+	}, nil
+}
+
+// euroRate returns the rate of the currency against the euro, 1 for the
+// euro itself, which is dated zero.
+func euroRate(ctx context.Context, source eurofxref.RateSource, currencyCode string) (decimal.Decimal,
+	time.Time, error) {
+
+	if currencyCode == "EUR" {
+		return decimal.NewFromInt(1), time.Time{}, nil
+	}
+
+	return Daily(ctx, source, currencyCode)
+}
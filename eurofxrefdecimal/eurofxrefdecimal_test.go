@@ -0,0 +1,60 @@
+//
+// Copyright 2023 The GoEurofxref Authors. All rights reserved.
+// Use of this source code is governed by a MIT License
+// license that can be found in the LICENSE file.
+// Last Modification: 2026-10-14 19:32:21
+//
+
+package eurofxrefdecimal
+
+import (
+	"context"
+	"testing"
+
+	eurofxref "github.com/mrhdias/go-eurofxref"
+	"github.com/mrhdias/go-eurofxref/eurofxreftest"
+	"github.com/shopspring/decimal"
+)
+
+func TestConvert(t *testing.T) {
+
+	date := eurofxref.PublicationDate(2024, 3, 1)
+	source := eurofxreftest.NewSource(date, map[string]float64{"USD": 1.0876, "GBP": 0.85578, "IDR": 17028.33})
+	ctx := context.Background()
+
+	rate, lastUpdate, err := Daily(ctx, source, "IDR")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rate.String() != "17028.33" || !lastUpdate.Equal(date) {
+		t.Errorf("got %s on %v", rate, lastUpdate)
+	}
+
+	tests := []struct {
+		amount   string
+		from, to string
+		value    string
+	}{
+		{"100", "EUR", "USD", "108.76"},
+		{"0.1", "EUR", "GBP", "0.085578"},
+		{"108.76", "USD", "EUR", "100"},
+		{"100", "GBP", "USD", "127.088737759704597"},
+	}
+
+	for _, tt := range tests {
+		conversion, err := Convert(ctx, source, decimal.RequireFromString(tt.amount), tt.from, tt.to)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if conversion.Value.String() != tt.value {
+			t.Errorf("Convert(%s, %s, %s) = %s, want %s", tt.amount, tt.from, tt.to, conversion.Value, tt.value)
+		}
+		if !conversion.LastUpdate.Equal(date) {
+			t.Errorf("Convert(%s, %s, %s) is dated %v", tt.amount, tt.from, tt.to, conversion.LastUpdate)
+		}
+	}
+
+	if _, err := Convert(ctx, source, decimal.NewFromInt(1), "EUR", "JPY"); err == nil {
+		t.Error("expected an error for a missing currency")
+	}
+}
@@ -0,0 +1,252 @@
+//
+// Copyright 2023 The GoEurofxref Authors. All rights reserved.
+// Use of this source code is governed by a MIT License
+// license that can be found in the LICENSE file.
+// Last Modification: 2026-10-14 19:32:21
+//
+
+// Package eurofxreftest provides a fake ECB server for the tests of the
+// programs using eurofxref, serving generated daily, 90-day and full
+// historical envelopes without network access.
+package eurofxreftest
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"path"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	eurofxref "github.com/mrhdias/go-eurofxref"
+)
+
+// Rates are the reference rates of the latest publication served by
+// NewServer, those of 1 March 2024, in the order of the ECB files.
+var Rates = []Rate{
+	{"USD", 1.0876}, {"JPY", 162.53}, {"BGN", 1.9558}, {"CZK", 25.3240}, {"DKK", 7.4543},
+	{"GBP", 0.85578}, {"HUF", 390.33}, {"PLN", 4.3188}, {"RON", 4.9699}, {"SEK", 11.1165},
+	{"CHF", 0.9554}, {"ISK", 149.30}, {"NOK", 11.4325}, {"TRY", 34.0630}, {"AUD", 1.6624},
+	{"BRL", 5.3797}, {"CAD", 1.4691}, {"CNY", 7.8107}, {"HKD", 8.5010}, {"IDR", 17028.33},
+	{"ILS", 3.9376}, {"INR", 90.0780}, {"KRW", 1446.14}, {"MXN", 18.4935}, {"MYR", 5.1418},
+	{"NZD", 1.7849}, {"PHP", 60.6410}, {"SGD", 1.4595}, {"THB", 38.9830}, {"ZAR", 20.6711},
+}
+
+// Rate is the reference rate of a currency.
+type Rate struct {
+	Currency string
+	Rate     float64
+}
+
+// The paths of the files served, those of the ECB.
+const (
+	DailyPath  = "/stats/eurofxref/eurofxref-daily.xml"
+	Hist90Path = "/stats/eurofxref/eurofxref-hist-90d.xml"
+	HistPath   = "/stats/eurofxref/eurofxref-hist.xml"
+)
+
+// Server is a fake ECB. The rates of the latest publication are Rates,
+// and each earlier publication day moves them by a small deterministic
+// amount, so the history is not flat. Its methods change the answers of
+// the next requests and are safe for concurrent use.
+type Server struct {
+	*httptest.Server
+
+	mu          sync.Mutex
+	date        time.Time
+	historyDays int
+	rates       []Rate
+	missing     map[string]bool
+	malformed   bool
+	status      int
+	requests    map[string]int
+}
+
+// NewServer starts a fake ECB whose latest publication is 1 March 2024,
+// with one year of history. It is closed at the end of the test.
+func NewServer(t testing.TB) *Server {
+
+	s := &Server{
+		date:        eurofxref.PublicationDate(2024, 3, 1),
+		historyDays: 365,
+		rates:       append([]Rate(nil), Rates...),
+		missing:     map[string]bool{},
+		requests:    map[string]int{},
+	}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	t.Cleanup(s.Close)
+
+	return s
+}
+
+// Configure points the URLs of efr to the server and disables its cache,
+// so the answers always come from the server.
+func (s *Server) Configure(efr *eurofxref.EuroFxRef) {
+
+	efr.Url = s.URL + DailyPath
+	efr.HistUrl = s.URL + HistPath
+	efr.Hist90Url = s.URL + Hist90Path
+	efr.CacheDir = ""
+	efr.UseZip = false
+	efr.UseDataAPI = false
+}
+
+// SetDate sets the date of the latest publication, which is moved back to
+// the previous publication day when nothing is published on date.
+func (s *Server) SetDate(date time.Time) {
+
+	date = eurofxref.PublicationDate(date.Year(), date.Month(), date.Day())
+	for !eurofxref.IsPublicationDay(date) {
+		date = date.AddDate(0, 0, -1)
+	}
+
+	s.mu.Lock()
+	s.date = date
+	s.mu.Unlock()
+}
+
+// SetHistoryDays sets the number of days covered by the full history.
+func (s *Server) SetHistoryDays(days int) {
+
+	s.mu.Lock()
+	s.historyDays = days
+	s.mu.Unlock()
+}
+
+// SetRate sets the latest rate of a currency, added if not quoted.
+func (s *Server) SetRate(currencyCode string, rate float64) {
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i := range s.rates {
+		if s.rates[i].Currency == currencyCode {
+			s.rates[i].Rate = rate
+			return
+		}
+	}
+	s.rates = append(s.rates, Rate{currencyCode, rate})
+}
+
+// RemoveCurrency stops quoting a currency in every file.
+func (s *Server) RemoveCurrency(currencyCode string) {
+
+	s.mu.Lock()
+	s.missing[currencyCode] = true
+	s.mu.Unlock()
+}
+
+// SetMalformed makes the server answer with truncated XML.
+func (s *Server) SetMalformed(malformed bool) {
+
+	s.mu.Lock()
+	s.malformed = malformed
+	s.mu.Unlock()
+}
+
+// SetStatus makes the server answer every request with the status code
+// and no content; 0 or 200 restores the normal answers.
+func (s *Server) SetStatus(statusCode int) {
+
+	s.mu.Lock()
+	s.status = statusCode
+	s.mu.Unlock()
+}
+
+// Requests returns the number of requests received for the file at
+// urlPath, one of DailyPath, Hist90Path and HistPath.
+func (s *Server) Requests(urlPath string) int {
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.requests[urlPath]
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.requests[r.URL.Path]++
+
+	if s.status != 0 && s.status != http.StatusOK {
+		w.WriteHeader(s.status)
+		return
+	}
+
+	var days int
+	switch r.URL.Path {
+	case DailyPath:
+		days = 1
+	case Hist90Path:
+		days = 90
+	case HistPath:
+		days = s.historyDays
+	default:
+		http.NotFound(w, r)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/xml")
+
+	if s.malformed {
+		io.WriteString(w, "<?xml version=\"1.0\" encoding=\"UTF-8\"?>\n<gesmes:Envelope><Cube><Cube time=")
+		return
+	}
+
+	s.writeEnvelope(w, path.Base(r.URL.Path), days)
+}
+
+// writeEnvelope writes the publications of the last days, the most recent
+// first like the ECB files.
+func (s *Server) writeEnvelope(w io.Writer, name string, days int) {
+
+	fmt.Fprint(w, `<?xml version="1.0" encoding="UTF-8"?>
+<gesmes:Envelope xmlns:gesmes="http://www.gesmes.org/xml/2002-08-01" xmlns="http://www.ecb.int/vocabulary/2002-08-01/eurofxref">
+	<gesmes:subject>Reference rates</gesmes:subject>
+	<gesmes:Sender>
+		<gesmes:name>European Central Bank</gesmes:name>
+	</gesmes:Sender>
+	<Cube>
+`)
+
+	oldest := s.date.AddDate(0, 0, 1-days)
+	step := 0
+	for date := s.date; !date.Before(oldest); date = date.AddDate(0, 0, -1) {
+		if !eurofxref.IsPublicationDay(date) {
+			continue
+		}
+		fmt.Fprintf(w, "\t\t<Cube time='%s'>\n", date.Format("2006-01-02"))
+		for _, rate := range s.rates {
+			if s.missing[rate.Currency] {
+				continue
+			}
+			fmt.Fprintf(w, "\t\t\t<Cube currency='%s' rate='%s'/>\n",
+				rate.Currency, strconv.FormatFloat(historicalRate(rate.Rate, step), 'f', -1, 64))
+		}
+		fmt.Fprint(w, "\t\t</Cube>\n")
+		step++
+	}
+
+	fmt.Fprint(w, "\t</Cube>\n</gesmes:Envelope>\n")
+}
+
+// historicalRate returns the rate of step publications before the latest,
+// within half a percent of it, with five significant digits like the
+// rates of the ECB.
+func historicalRate(rate float64, step int) float64 {
+
+	if step == 0 {
+		return rate
+	}
+
+	moved := rate * (1 + 0.005*math.Sin(float64(step)))
+	rounded, _ := strconv.ParseFloat(strconv.FormatFloat(moved, 'g', 5, 64), 64)
+
+	return rounded
+}
@@ -0,0 +1,107 @@
+//
+// Copyright 2023 The GoEurofxref Authors. All rights reserved.
+// Use of this source code is governed by a MIT License
+// license that can be found in the LICENSE file.
+// Last Modification: 2026-10-14 19:32:21
+//
+
+package eurofxreftest
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	eurofxref "github.com/mrhdias/go-eurofxref"
+)
+
+func newQuery(t *testing.T) (*Server, eurofxref.EuroFxRef) {
+
+	s := NewServer(t)
+	query := eurofxref.New("", false)
+	s.Configure(&query)
+
+	return s, query
+}
+
+func TestDaily(t *testing.T) {
+
+	s, query := newQuery(t)
+
+	result, err := query.Daily("USD")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.RateValue != 1.0876 || result.LastUpdate.Format("2006-01-02") != "2024-03-01" {
+		t.Errorf("got %v on %v", result.RateValue, result.LastUpdate)
+	}
+	if s.Requests(DailyPath) != 1 {
+		t.Errorf("got %d requests of the daily file, want 1", s.Requests(DailyPath))
+	}
+
+	s.SetRate("USD", 1.1)
+	s.SetDate(eurofxref.PublicationDate(2024, 3, 10)) // Sunday
+	result, err = query.Daily("USD")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.RateValue != 1.1 || result.LastUpdate.Format("2006-01-02") != "2024-03-08" {
+		t.Errorf("got %v on %v", result.RateValue, result.LastUpdate)
+	}
+}
+
+func TestHistory(t *testing.T) {
+
+	s, query := newQuery(t)
+	s.SetDate(time.Now())
+
+	series, err := query.History("GBP")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n := len(series.Points); n < 55 || n > 66 {
+		t.Errorf("got %d points in 90 days", n)
+	}
+	last := series.Points[len(series.Points)-1]
+	if last.Rate != 0.85578 || series.Points[0].Rate == last.Rate {
+		t.Errorf("unexpected rates %v and %v", series.Points[0].Rate, last.Rate)
+	}
+
+	from := time.Now().AddDate(0, -6, 0)
+	series, err = query.HistoryRange("GBP", from, from.AddDate(0, 0, 14))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(series.Points) < 8 || s.Requests(HistPath) != 1 {
+		t.Errorf("got %d points from %d requests of the full history",
+			len(series.Points), s.Requests(HistPath))
+	}
+}
+
+func TestFailures(t *testing.T) {
+
+	s, query := newQuery(t)
+
+	s.RemoveCurrency("GBP")
+	if _, err := query.Daily("GBP"); err == nil {
+		t.Error("expected an error for a missing currency")
+	}
+	if _, err := query.Daily("USD"); err != nil {
+		t.Error(err)
+	}
+
+	s.SetMalformed(true)
+	if _, err := query.Daily("USD"); err == nil {
+		t.Error("expected an error for malformed XML")
+	}
+	s.SetMalformed(false)
+
+	s.SetStatus(http.StatusServiceUnavailable)
+	if _, err := query.Daily("USD"); err == nil {
+		t.Error("expected an error for an unavailable server")
+	}
+	s.SetStatus(0)
+	if _, err := query.Daily("USD"); err != nil {
+		t.Error(err)
+	}
+}
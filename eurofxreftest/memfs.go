@@ -0,0 +1,107 @@
+//
+// Copyright 2023 The GoEurofxref Authors. All rights reserved.
+// Use of this source code is governed by a MIT License
+// license that can be found in the LICENSE file.
+// Last Modification: 2026-10-15 13:30:00
+//
+
+package eurofxreftest
+
+import (
+	"io/fs"
+	"sync"
+	"testing/fstest"
+	"time"
+
+	eurofxref "github.com/mrhdias/go-eurofxref"
+)
+
+// MemFS is an in-memory eurofxref.CacheFS, for the tests of the cache
+// without a temporary directory. It is safe for concurrent use.
+type MemFS struct {
+	// Clock, when set, dates the files written, e.g. the Clock of the
+	// client under test.
+	Clock eurofxref.Clock
+
+	mu    sync.Mutex
+	files fstest.MapFS
+}
+
+// NewMemFS returns an empty MemFS.
+func NewMemFS() *MemFS {
+	return &MemFS{files: fstest.MapFS{}}
+}
+
+// Open opens a snapshot of the file name, unchanged by the next writes.
+func (m *MemFS) Open(name string) (fs.File, error) {
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	snapshot := make(fstest.MapFS, len(m.files))
+	for name, file := range m.files {
+		copied := *file
+		snapshot[name] = &copied
+	}
+
+	return snapshot.Open(name)
+}
+
+// WriteFile creates or replaces the file name, modified now by Clock.
+func (m *MemFS) WriteFile(name string, data []byte) error {
+
+	if !fs.ValidPath(name) {
+		return &fs.PathError{Op: "write", Path: name, Err: fs.ErrInvalid}
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.files[name] = &fstest.MapFile{
+		Data:    append([]byte(nil), data...),
+		Mode:    0644,
+		ModTime: m.now(),
+	}
+
+	return nil
+}
+
+// now returns the time of Clock, or of the system.
+func (m *MemFS) now() time.Time {
+
+	if m.Clock != nil {
+		return m.Clock.Now()
+	}
+
+	return time.Now()
+}
+
+// Remove deletes the file name.
+func (m *MemFS) Remove(name string) error {
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.files[name]; !ok {
+		return &fs.PathError{Op: "remove", Path: name, Err: fs.ErrNotExist}
+	}
+	delete(m.files, name)
+
+	return nil
+}
+
+// SetModTime sets the modification time of the file name, e.g. to expire
+// a cached file.
+func (m *MemFS) SetModTime(name string, modTime time.Time) error {
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	file, ok := m.files[name]
+	if !ok {
+		return &fs.PathError{Op: "chtimes", Path: name, Err: fs.ErrNotExist}
+	}
+	file.ModTime = modTime
+
+	return nil
+}
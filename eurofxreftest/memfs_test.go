@@ -0,0 +1,88 @@
+//
+// Copyright 2023 The GoEurofxref Authors. All rights reserved.
+// Use of this source code is governed by a MIT License
+// license that can be found in the LICENSE file.
+// Last Modification: 2026-10-15 13:30:00
+//
+
+package eurofxreftest
+
+import (
+	"io/fs"
+	"testing"
+	"time"
+
+	eurofxref "github.com/mrhdias/go-eurofxref"
+)
+
+func TestMemFS(t *testing.T) {
+
+	s, query := newQuery(t)
+	cache := NewMemFS()
+	query.CacheFS = cache
+
+	for i := 0; i < 2; i++ {
+		if _, err := query.Daily("USD"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if s.Requests(DailyPath) != 1 {
+		t.Errorf("got %d requests of the daily file, want 1", s.Requests(DailyPath))
+	}
+
+	name := query.CachePath(query.Url)
+	if data, err := fs.ReadFile(cache, name); err != nil || len(data) == 0 {
+		t.Fatalf("the daily file was not cached as %s: %v", name, err)
+	}
+
+	// an expired copy is downloaded again
+	if err := cache.SetModTime(name, time.Now().AddDate(0, 0, -2)); err != nil {
+		t.Fatal(err)
+	}
+	fresh := eurofxref.New("", false)
+	s.Configure(&fresh)
+	fresh.CacheFS = cache
+	if _, err := fresh.Daily("USD"); err != nil {
+		t.Fatal(err)
+	}
+	if s.Requests(DailyPath) != 2 {
+		t.Errorf("got %d requests of the daily file, want 2", s.Requests(DailyPath))
+	}
+
+	offline := eurofxref.New("", false)
+	s.Configure(&offline)
+	offline.CacheFS = cache
+	offline.Offline = true
+	if _, err := offline.Daily("USD"); err != nil {
+		t.Errorf("the offline client did not read the cache: %v", err)
+	}
+}
+
+func TestMemFSClock(t *testing.T) {
+
+	s, query := newQuery(t)
+	query.Clock = eurofxref.FixedClock(time.Date(2024, 3, 1, 17, 0, 0, 0, eurofxref.CET))
+	cache := NewMemFS()
+	cache.Clock = query.Clock
+	query.CacheFS = cache
+
+	if _, err := query.Daily("USD"); err != nil {
+		t.Fatal(err)
+	}
+	info, err := fs.Stat(cache, query.CachePath(query.Url))
+	if err != nil || !info.ModTime().Equal(query.Now()) {
+		t.Fatalf("got the file %v (%v), want it dated by the clock", info, err)
+	}
+
+	// still fresh for another client at the same time
+	other := eurofxref.New("", false)
+	s.Configure(&other)
+	other.CacheFS = cache
+	other.Clock = query.Clock
+	if _, err := other.Daily("USD"); err != nil {
+		t.Fatal(err)
+	}
+	if s.Requests(DailyPath) != 1 {
+		t.Errorf("got %d requests of the daily file, want 1", s.Requests(DailyPath))
+	}
+}
@@ -0,0 +1,167 @@
+//
+// Copyright 2023 The GoEurofxref Authors. All rights reserved.
+// Use of this source code is governed by a MIT License
+// license that can be found in the LICENSE file.
+// Last Modification: 2026-10-14 19:32:21
+//
+
+package eurofxreftest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	eurofxref "github.com/mrhdias/go-eurofxref"
+)
+
+// Source is an in-memory eurofxref.RateSource answering from the
+// publications added to it, without network or filesystem access.
+type Source struct {
+	mu           sync.Mutex
+	publications []publication // sorted by date
+	err          error
+}
+
+type publication struct {
+	date  time.Time
+	rates map[string]float64
+}
+
+var _ eurofxref.RateSource = (*Source)(nil)
+
+// NewSource returns a Source whose only publication is rates on date.
+func NewSource(date time.Time, rates map[string]float64) *Source {
+
+	s := &Source{}
+	s.Add(date, rates)
+
+	return s
+}
+
+// Add adds or replaces the publication of date.
+func (s *Source) Add(date time.Time, rates map[string]float64) {
+
+	date = eurofxref.PublicationDate(date.Year(), date.Month(), date.Day())
+	copied := make(map[string]float64, len(rates))
+	for currencyCode, rate := range rates {
+		copied[strings.ToUpper(currencyCode)] = rate
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	i := sort.Search(len(s.publications), func(i int) bool {
+		return !s.publications[i].date.Before(date)
+	})
+	if i < len(s.publications) && s.publications[i].date.Equal(date) {
+		s.publications[i].rates = copied
+		return
+	}
+	s.publications = append(s.publications, publication{})
+	copy(s.publications[i+1:], s.publications[i:])
+	s.publications[i] = publication{date, copied}
+}
+
+// SetError makes every lookup fail with err, or succeed again when nil.
+func (s *Source) SetError(err error) {
+
+	s.mu.Lock()
+	s.err = err
+	s.mu.Unlock()
+}
+
+// latest returns the most recent publication.
+func (s *Source) latest() (publication, error) {
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.err != nil {
+		return publication{}, s.err
+	}
+	if len(s.publications) == 0 {
+		return publication{}, errors.New("no rates were published")
+	}
+
+	return s.publications[len(s.publications)-1], nil
+}
+
+func (pub publication) rate(currencyCode string) (float64, error) {
+
+	if currencyCode == "EUR" {
+		return 1.00, nil
+	}
+	if rate, ok := pub.rates[currencyCode]; ok {
+		return rate, nil
+	}
+
+	return 0, fmt.Errorf("no conversion rate value was returned for \"%s\" currency code",
+		currencyCode)
+}
+
+func (s *Source) DailyContext(ctx context.Context, currencyCode string) (*eurofxref.QueryResult, error) {
+
+	pub, err := s.latest()
+	if err != nil {
+		return nil, err
+	}
+
+	currencyCode = strings.ToUpper(currencyCode)
+	rate, err := pub.rate(currencyCode)
+	if err != nil {
+		return nil, err
+	}
+
+	return &eurofxref.QueryResult{
+		LastUpdate: pub.date,
+		RateValue:  rate,
+		Currency:   currencyCode,
+		Base:       "EUR",
+	}, nil
+}
+
+func (s *Source) HistoryRangeContext(ctx context.Context, currencyCode string,
+	from, to time.Time) (*eurofxref.Series, error) {
+
+	if to.Before(from) {
+		return nil, errors.New("the end of the range is before its start")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.err != nil {
+		return nil, s.err
+	}
+
+	cc := strings.ToUpper(currencyCode)
+	series := &eurofxref.Series{Currency: cc, Points: []eurofxref.Point{}}
+	for _, pub := range s.publications {
+		if pub.date.Before(from) || pub.date.After(to) {
+			continue
+		}
+		if rate, ok := pub.rates[cc]; ok {
+			series.Points = append(series.Points, eurofxref.Point{Date: pub.date, Rate: rate})
+		}
+	}
+
+	return series, nil
+}
+
+func (s *Source) ConvertContext(ctx context.Context, amount float64, from, to string,
+	options ...eurofxref.ConvertOptions) (*eurofxref.ConversionResult, error) {
+
+	pub, err := s.latest()
+	if err != nil {
+		return nil, err
+	}
+
+	table := &eurofxref.RateTable{Date: pub.date, Rates: pub.rates}
+
+	return table.Conversion(amount, from, to, options...)
+}
@@ -0,0 +1,61 @@
+//
+// Copyright 2023 The GoEurofxref Authors. All rights reserved.
+// Use of this source code is governed by a MIT License
+// license that can be found in the LICENSE file.
+// Last Modification: 2026-10-14 19:32:21
+//
+
+package eurofxreftest
+
+import (
+	"context"
+	"errors"
+	"math"
+	"testing"
+	"time"
+
+	eurofxref "github.com/mrhdias/go-eurofxref"
+)
+
+func TestSource(t *testing.T) {
+
+	ctx := context.Background()
+	day := func(d int) time.Time { return eurofxref.PublicationDate(2024, 3, d) }
+
+	s := NewSource(day(1), map[string]float64{"usd": 1.0876, "GBP": 0.85578})
+	s.Add(day(4), map[string]float64{"USD": 1.0856, "GBP": 0.8556})
+	s.Add(day(3), map[string]float64{"USD": 1.1}) // out of order
+
+	result, err := s.DailyContext(ctx, "USD")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.RateValue != 1.0856 || !result.LastUpdate.Equal(day(4)) {
+		t.Errorf("got %v on %v", result.RateValue, result.LastUpdate)
+	}
+	if _, err := s.DailyContext(ctx, "JPY"); err == nil {
+		t.Error("expected an error for a missing currency")
+	}
+
+	series, err := s.HistoryRangeContext(ctx, "GBP", day(1), day(4))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(series.Points) != 2 || !series.Points[0].Date.Equal(day(1)) || series.Points[1].Rate != 0.8556 {
+		t.Errorf("unexpected series %v", series.Points)
+	}
+
+	conversion, err := s.ConvertContext(ctx, 100, "GBP", "usd")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if math.Abs(conversion.Value-100*1.0856/0.8556) > 1e-9 || conversion.To != "USD" {
+		t.Errorf("unexpected conversion %+v", conversion)
+	}
+
+	failure := errors.New("unavailable")
+	s.SetError(failure)
+	if _, err := s.DailyContext(ctx, "USD"); !errors.Is(err, failure) {
+		t.Errorf("got %v, want %v", err, failure)
+	}
+}
@@ -0,0 +1,224 @@
+//
+// Copyright 2023 The GoEurofxref Authors. All rights reserved.
+// Use of this source code is governed by a MIT License
+// license that can be found in the LICENSE file.
+// Last Modification: 2026-10-14 19:32:21
+//
+
+package eurofxref
+
+import (
+	"bufio"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// Each calls fn for every publication in the store, from the oldest to
+// the most recent, until fn returns an error or ctx is done. The store is
+// not locked while fn runs, so slow consumers do not block syncs, and
+// each call only holds the rates of one publication.
+func (store *HistoryStore) Each(ctx context.Context,
+	fn func(date time.Time, rates map[string]float64) error) error {
+
+	store.mu.RLock()
+	dates := make([]string, 0, len(store.days))
+	for date := range store.days {
+		dates = append(dates, date)
+	}
+	store.mu.RUnlock()
+
+	sort.Strings(dates)
+
+	for _, key := range dates {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		date, err := parseDate(key)
+		if err != nil {
+			return err
+		}
+		rates, ok := store.Rates(date)
+		if !ok {
+			// removed by a concurrent change
+			continue
+		}
+
+		if err := fn(date, rates); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func sortedCurrencies(rates map[string]float64) []string {
+
+	currencies := make([]string, 0, len(rates))
+	for currency := range rates {
+		currencies = append(currencies, currency)
+	}
+	sort.Strings(currencies)
+
+	return currencies
+}
+
+// CSVOptions are the optional settings of the CSV exports.
+type CSVOptions struct {
+	Delimiter rune // field delimiter, a comma when zero
+	NoHeader  bool // omit the "date,currency,rate" header row
+}
+
+// newCSVWriter returns a CSV writer configured by the options, with the
+// header row already written.
+func newCSVWriter(w io.Writer, options []CSVOptions) (*csv.Writer, error) {
+
+	opts := CSVOptions{}
+	if len(options) == 1 {
+		opts = options[0]
+	}
+
+	cw := csv.NewWriter(w)
+	if opts.Delimiter != 0 {
+		cw.Comma = opts.Delimiter
+	}
+
+	if !opts.NoHeader {
+		if err := cw.Write([]string{"date", "currency", "rate"}); err != nil {
+			return nil, err
+		}
+	}
+
+	return cw, nil
+}
+
+// writeCSVRates writes one "date,currency,rate" row per rate, sorted by
+// currency.
+func writeCSVRates(cw *csv.Writer, date time.Time, rates map[string]float64) error {
+
+	day := date.Format("2006-01-02")
+	for _, currency := range sortedCurrencies(rates) {
+		if err := cw.Write([]string{
+			day,
+			currency,
+			strconv.FormatFloat(rates[currency], 'f', -1, 64),
+		}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ExportCSV streams the store to w as CSV, one "date,currency,rate" row
+// per rate.
+func (store *HistoryStore) ExportCSV(ctx context.Context, w io.Writer, options ...CSVOptions) error {
+
+	cw, err := newCSVWriter(w, options)
+	if err != nil {
+		return err
+	}
+
+	if err := store.Each(ctx, func(date time.Time, rates map[string]float64) error {
+		if err := writeCSVRates(cw, date, rates); err != nil {
+			return err
+		}
+		cw.Flush()
+		return cw.Error()
+	}); err != nil {
+		return err
+	}
+
+	cw.Flush()
+
+	return cw.Error()
+}
+
+// ExportDailyCSV writes the rates of the latest publication to w as CSV,
+// one "date,currency,rate" row per currency.
+func (efr EuroFxRef) ExportDailyCSV(w io.Writer, options ...CSVOptions) error {
+
+	table, err := efr.DailyRates()
+	if err != nil {
+		return err
+	}
+
+	cw, err := newCSVWriter(w, options)
+	if err != nil {
+		return err
+	}
+	if err := writeCSVRates(cw, table.Date, table.Rates); err != nil {
+		return err
+	}
+
+	cw.Flush()
+
+	return cw.Error()
+}
+
+// ExportCSV writes the series to w as CSV, one "date,currency,rate" row
+// per point. Combined with HistoryRange it exports a historical range.
+func (series *Series) ExportCSV(w io.Writer, options ...CSVOptions) error {
+
+	cw, err := newCSVWriter(w, options)
+	if err != nil {
+		return err
+	}
+
+	for _, point := range series.Points {
+		if err := cw.Write([]string{
+			point.Date.Format("2006-01-02"),
+			series.Currency,
+			strconv.FormatFloat(point.Rate, 'f', -1, 64),
+		}); err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+
+	return cw.Error()
+}
+
+// ExportJSON streams the store to w as a JSON array with one
+// {"date": ..., "rates": {...}} object per publication.
+func (store *HistoryStore) ExportJSON(ctx context.Context, w io.Writer) error {
+
+	bw := bufio.NewWriter(w)
+	if _, err := bw.WriteString("["); err != nil {
+		return err
+	}
+
+	first := true
+	if err := store.Each(ctx, func(date time.Time, rates map[string]float64) error {
+		if !first {
+			if _, err := bw.WriteString(",\n"); err != nil {
+				return err
+			}
+		}
+		first = false
+
+		object, err := json.Marshal(struct {
+			Date  string             `json:"date"`
+			Rates map[string]float64 `json:"rates"`
+		}{date.Format("2006-01-02"), rates})
+		if err != nil {
+			return err
+		}
+		_, err = bw.Write(object)
+		return err
+	}); err != nil {
+		return err
+	}
+
+	if _, err := bw.WriteString("]\n"); err != nil {
+		return err
+	}
+
+	return bw.Flush()
+}
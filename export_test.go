@@ -0,0 +1,124 @@
+//
+// Copyright 2023 The GoEurofxref Authors. All rights reserved.
+// Use of this source code is governed by a MIT License
+// license that can be found in the LICENSE file.
+// Last Modification: 2026-10-14 19:32:21
+//
+
+package eurofxref
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func newTestStore(t *testing.T) *HistoryStore {
+
+	_, query := newTestServer(t)
+
+	store, err := OpenHistoryStore("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := store.Sync(query); err != nil {
+		t.Fatal(err)
+	}
+
+	return store
+}
+
+func TestExportCSV(t *testing.T) {
+
+	store := newTestStore(t)
+
+	var buf bytes.Buffer
+	if err := store.ExportCSV(context.Background(), &buf); err != nil {
+		t.Fatal(err)
+	}
+
+	records, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(records) != 1+20*30 {
+		t.Fatalf("got %d records, want %d", len(records), 1+20*30)
+	}
+	if got := records[1]; got[0] != "2024-02-05" || got[1] != "AUD" {
+		t.Errorf("got first row %v, want the oldest publication first", got)
+	}
+	last := records[len(records)-1]
+	if last[0] != "2024-03-01" || last[1] != "ZAR" || last[2] != "20.6711" {
+		t.Errorf("got last row %v", last)
+	}
+}
+
+func TestExportJSON(t *testing.T) {
+
+	store := newTestStore(t)
+
+	var buf bytes.Buffer
+	if err := store.ExportJSON(context.Background(), &buf); err != nil {
+		t.Fatal(err)
+	}
+
+	var days []struct {
+		Date  string             `json:"date"`
+		Rates map[string]float64 `json:"rates"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &days); err != nil {
+		t.Fatal(err)
+	}
+	if len(days) != 20 || days[19].Date != "2024-03-01" || days[19].Rates["USD"] != 1.0876 {
+		t.Errorf("unexpected export %+v", days)
+	}
+}
+
+func TestExportCanceled(t *testing.T) {
+
+	store := newTestStore(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	var buf bytes.Buffer
+	if err := store.ExportCSV(ctx, &buf); !errors.Is(err, context.Canceled) {
+		t.Errorf("got %v, want context.Canceled", err)
+	}
+	if err := store.ExportJSON(ctx, &buf); !errors.Is(err, context.Canceled) {
+		t.Errorf("got %v, want context.Canceled", err)
+	}
+}
+
+func TestExportCSVOptions(t *testing.T) {
+
+	_, query := newTestServer(t)
+
+	var buf bytes.Buffer
+	if err := query.ExportDailyCSV(&buf, CSVOptions{Delimiter: ';'}); err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 31 || lines[0] != "date;currency;rate" || lines[1] != "2024-03-01;AUD;1.6624" {
+		t.Errorf("unexpected daily export %v", lines[:2])
+	}
+
+	from, _ := parseDate("2024-02-28")
+	to, _ := parseDate("2024-03-01")
+	series, err := query.HistoryRange("USD", from, to)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	buf.Reset()
+	if err := series.ExportCSV(&buf, CSVOptions{NoHeader: true}); err != nil {
+		t.Fatal(err)
+	}
+	lines = strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 3 || lines[2] != "2024-03-01,USD,1.0876" {
+		t.Errorf("unexpected range export %v", lines)
+	}
+}
@@ -0,0 +1,102 @@
+//
+// Copyright 2023 The GoEurofxref Authors. All rights reserved.
+// Use of this source code is governed by a MIT License
+// license that can be found in the LICENSE file.
+// Last Modification: 2026-10-14 18:46:08
+//
+
+package eurofxref
+
+import (
+	"fmt"
+	"math"
+
+	"golang.org/x/text/currency"
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
+	"golang.org/x/text/number"
+)
+
+// symbolPosition is where the currency symbol is written.
+type symbolPosition int
+
+const (
+	symbolBefore      symbolPosition = iota // $1,234.56
+	symbolBeforeSpace                       // € 1.234,56
+	symbolAfter                             // 1.234,56 €
+)
+
+// symbolPositions are the positions of the currency symbol of the
+// languages, and of the regions whose usage differs from their language,
+// following the CLDR currency patterns. The other languages write it
+// before the amount.
+var symbolPositions = map[string]symbolPosition{
+	"bg": symbolAfter, "cs": symbolAfter, "da": symbolAfter, "de": symbolAfter,
+	"el": symbolAfter, "es": symbolAfter, "et": symbolAfter, "fi": symbolAfter,
+	"fr": symbolAfter, "hr": symbolAfter, "hu": symbolAfter, "is": symbolAfter,
+	"it": symbolAfter, "lt": symbolAfter, "lv": symbolAfter, "nb": symbolAfter,
+	"no": symbolAfter, "pl": symbolAfter, "pt": symbolAfter, "ro": symbolAfter,
+	"ru": symbolAfter, "sk": symbolAfter, "sl": symbolAfter, "sv": symbolAfter,
+	"uk": symbolAfter,
+	"nl": symbolBeforeSpace, "de-AT": symbolBeforeSpace, "de-CH": symbolBeforeSpace,
+	"de-LI": symbolBeforeSpace, "pt-BR": symbolBeforeSpace,
+}
+
+// Format renders an amount of the currency with the conventions of the
+// locale, a BCP 47 tag such as "de-DE" or "en-US": "1.234,56 €" and
+// "$1,234.56". The amount is rounded half up to the minor units of the
+// currency, and the symbol is the one used in the locale, which can be
+// the currency code.
+func Format(amount float64, currencyCode, locale string) (string, error) {
+
+	tag, err := language.Parse(locale)
+	if err != nil {
+		return "", fmt.Errorf("invalid locale \"%s\": %v", locale, err)
+	}
+
+	unit, err := currency.ParseISO(currencyCode)
+	if err != nil {
+		return "", fmt.Errorf("invalid currency code \"%s\": %v", currencyCode, err)
+	}
+
+	rule := LookupRoundingRule(unit.String())
+	amount = rule.Round(amount)
+
+	printer := message.NewPrinter(tag)
+	digits := printer.Sprint(number.Decimal(math.Abs(amount), number.Scale(rule.MinorUnits)))
+	symbol := printer.Sprint(currency.Symbol(unit))
+
+	sign := ""
+	if amount < 0 {
+		sign = "-"
+	}
+
+	switch lookupSymbolPosition(tag) {
+	case symbolAfter:
+		return sign + digits + " " + symbol, nil
+	case symbolBeforeSpace:
+		return sign + symbol + " " + digits, nil
+	}
+
+	return sign + symbol + digits, nil
+}
+
+// lookupSymbolPosition returns the position of the currency symbol in the
+// region of the locale or, failing that, in its language.
+func lookupSymbolPosition(tag language.Tag) symbolPosition {
+
+	base, _ := tag.Base()
+	if region, confidence := tag.Region(); confidence == language.Exact {
+		if position, ok := symbolPositions[base.String()+"-"+region.String()]; ok {
+			return position
+		}
+	}
+
+	return symbolPositions[base.String()]
+}
+
+// Format renders the converted value with the conventions of the locale,
+// as the package level Format does.
+func (result *ConversionResult) Format(locale string) (string, error) {
+	return Format(result.Value, result.To, locale)
+}
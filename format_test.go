@@ -0,0 +1,47 @@
+//
+// Copyright 2023 The GoEurofxref Authors. All rights reserved.
+// Use of this source code is governed by a MIT License
+// license that can be found in the LICENSE file.
+// Last Modification: 2026-10-14 18:46:08
+//
+
+package eurofxref
+
+import "testing"
+
+func TestFormat(t *testing.T) {
+
+	tests := []struct {
+		amount   float64
+		currency string
+		locale   string
+		want     string
+	}{
+		{1234.56, "EUR", "de-DE", "1.234,56 €"},
+		{1234.56, "USD", "en-US", "$1,234.56"},
+		{1234.56, "EUR", "en-US", "€1,234.56"},
+		{-1234.567, "EUR", "pt-PT", "-1\u00a0234,57 €"},
+		{1234.56, "EUR", "de-AT", "€ 1\u00a0234,56"},
+		{1234.56, "EUR", "nl", "€ 1.234,56"},
+		{1234.56, "BRL", "pt-BR", "R$ 1.234,56"},
+		{1234.5, "JPY", "ja-JP", "￥1,235"},
+		{0.5, "GBP", "en-GB", "£0.50"},
+	}
+
+	for _, tt := range tests {
+		got, err := Format(tt.amount, tt.currency, tt.locale)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != tt.want {
+			t.Errorf("Format(%v, %s, %s) = %q, want %q", tt.amount, tt.currency, tt.locale, got, tt.want)
+		}
+	}
+
+	if _, err := Format(1, "EUR", "not a locale!"); err == nil {
+		t.Error("expected an error for an invalid locale")
+	}
+	if _, err := Format(1, "XYZ", "en-US"); err == nil {
+		t.Error("expected an error for an invalid currency")
+	}
+}
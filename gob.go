@@ -0,0 +1,126 @@
+//
+// Copyright 2023 The GoEurofxref Authors. All rights reserved.
+// Use of this source code is governed by a MIT License
+// license that can be found in the LICENSE file.
+// Last Modification: 2026-10-15 14:00:00
+//
+
+package eurofxref
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/gob"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"time"
+)
+
+// binaryCacheVersion is bumped when the layout of the binary copies
+// changes, so the files written by older versions are parsed again.
+const binaryCacheVersion = 3
+
+// binaryHeader starts the binary copy of a cached historical file, next to
+// it so the XML is decoded only once. The publications follow, encoded
+// one at a time, so that neither the writer nor the reader holds them
+// all.
+type binaryHeader struct {
+	Version int
+	Digest  [sha256.Size]byte // of the XML content
+}
+
+type binaryPublication struct {
+	Date  time.Time
+	Rates map[string]float64
+}
+
+// binaryCacheName returns the name in the cache of the binary copy of
+// the cached file at fileUrl, or an empty string if it has none: only the
+// historical files are worth it, and the strict mode always validates the
+// XML.
+func (efr EuroFxRef) binaryCacheName(fileUrl string) string {
+
+	if efr.cacheFS() == nil || efr.Strict || (fileUrl != efr.HistUrl && fileUrl != efr.Hist90Url) {
+		return ""
+	}
+
+	return cacheName(fileUrl) + ".gob"
+}
+
+// readBinaryCache calls fn with the publications of the binary copy name
+// and reports whether it was written from content. A copy failing after
+// its header is removed and the error returned, as fn cannot be called
+// again from the XML.
+func readBinaryCache(fsys CacheFS, name string, content []byte,
+	fn func(date time.Time, rates map[string]float64) error) (bool, error) {
+
+	data, err := fs.ReadFile(fsys, name)
+	if err != nil {
+		return false, nil
+	}
+
+	decoder := gob.NewDecoder(bytes.NewReader(data))
+	var header binaryHeader
+	if err := decoder.Decode(&header); err != nil {
+		return false, nil
+	}
+	if header.Version != binaryCacheVersion || header.Digest != sha256.Sum256(content) {
+		return false, nil
+	}
+
+	for {
+		var publication binaryPublication
+		err := decoder.Decode(&publication)
+		if errors.Is(err, io.EOF) {
+			return true, nil
+		}
+		if err != nil {
+			// parsed again by the next read
+			fsys.Remove(name)
+			return true, fmt.Errorf("error reading the binary cache: %v", err)
+		}
+		// gob keeps the offset of the dates, not their time zone
+		if err := fn(publication.Date.In(CET), publication.Rates); err != nil {
+			return true, err
+		}
+	}
+}
+
+// binaryCacheWriter encodes the binary copy of a historical file as its
+// publications are parsed.
+type binaryCacheWriter struct {
+	buf     bytes.Buffer
+	encoder *gob.Encoder
+	err     error
+}
+
+// newBinaryCacheWriter starts the binary copy of content.
+func newBinaryCacheWriter(content []byte) *binaryCacheWriter {
+
+	w := &binaryCacheWriter{}
+	w.encoder = gob.NewEncoder(&w.buf)
+	w.err = w.encoder.Encode(binaryHeader{Version: binaryCacheVersion, Digest: sha256.Sum256(content)})
+
+	return w
+}
+
+// add encodes the next publication.
+func (w *binaryCacheWriter) add(date time.Time, rates map[string]float64) {
+
+	if w.err == nil {
+		w.err = w.encoder.Encode(binaryPublication{Date: date, Rates: rates})
+	}
+}
+
+// writeBinaryCache writes the binary copy encoded by w, replacing the
+// previous one at once.
+func (efr EuroFxRef) writeBinaryCache(cache CacheFS, name string, w *binaryCacheWriter) error {
+
+	if w.err != nil {
+		return w.err
+	}
+
+	return efr.writeCache(cache, name, w.buf.Bytes())
+}
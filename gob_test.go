@@ -0,0 +1,107 @@
+//
+// Copyright 2023 The GoEurofxref Authors. All rights reserved.
+// Use of this source code is governed by a MIT License
+// license that can be found in the LICENSE file.
+// Last Modification: 2026-10-15 14:00:00
+//
+
+package eurofxref
+
+import (
+	"bytes"
+	"io/fs"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+func TestBinaryCache(t *testing.T) {
+
+	_, query := newTestServer(t)
+	query.CacheDir = t.TempDir()
+
+	var logs bytes.Buffer
+	query.Logger = slog.New(slog.NewTextHandler(&logs, &slog.HandlerOptions{
+		Level: slog.LevelDebug,
+	}))
+
+	want, err := query.History("USD")
+	if err != nil {
+		t.Fatal(err)
+	}
+	cache := query.cacheFS()
+	binaryName := query.binaryCacheName(query.Hist90Url)
+	if _, err := fs.Stat(cache, binaryName); err != nil {
+		t.Fatalf("the binary cache was not written: %v", err)
+	}
+
+	logs.Reset()
+	got, err := query.History("USD")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(logs.String(), "msg=parsed") || !strings.Contains(logs.String(), "binary cache hit") {
+		t.Errorf("the XML was parsed again:\n%s", logs.String())
+	}
+	if len(got.Points) != len(want.Points) || got.Points[0] != want.Points[0] {
+		t.Errorf("got %v, want %v", got.Points, want.Points)
+	}
+
+	// the rates come from the binary copy of the same content
+	content, err := readCached(cache, cacheName(query.Hist90Url))
+	if err != nil {
+		t.Fatal(err)
+	}
+	date := PublicationDate(2024, 3, 1)
+	binary := newBinaryCacheWriter(content)
+	binary.add(date, map[string]float64{"USD": 2})
+	if err := query.writeBinaryCache(cache, binaryName, binary); err != nil {
+		t.Fatal(err)
+	}
+	got, err = query.History("USD")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got.Points) != 1 || got.Points[0].Rate != 2 {
+		t.Errorf("got %v, want the rate of the binary copy", got.Points)
+	}
+
+	// but not when it was written from another content
+	binary = newBinaryCacheWriter([]byte("other"))
+	binary.add(date, map[string]float64{"USD": 2})
+	if err := query.writeBinaryCache(cache, binaryName, binary); err != nil {
+		t.Fatal(err)
+	}
+	got, err = query.History("USD")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got.Points) != len(want.Points) {
+		t.Errorf("got %d points, want %d", len(got.Points), len(want.Points))
+	}
+
+	// a copy failing after its header is removed, and the XML parsed
+	// again by the next read
+	binary = newBinaryCacheWriter(content)
+	binary.add(date, map[string]float64{"USD": 2})
+	if err := query.writeBinaryCache(cache, binaryName, binary); err != nil {
+		t.Fatal(err)
+	}
+	data, err := fs.ReadFile(cache, binaryName)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := cache.WriteFile(binaryName, data[:len(data)-4]); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := query.History("USD"); err == nil {
+		t.Error("expected an error from the truncated binary copy")
+	}
+	if got, err = query.History("USD"); err != nil || len(got.Points) != len(want.Points) {
+		t.Errorf("got %d points (%v), want %d from the XML", len(got.Points), err, len(want.Points))
+	}
+
+	if name := (EuroFxRef{CacheDir: query.CacheDir, Url: query.Url}).binaryCacheName(query.Url); name != "" {
+		t.Errorf("got a binary cache %s for the daily file", name)
+	}
+}
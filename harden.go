@@ -0,0 +1,141 @@
+//
+// Copyright 2023 The GoEurofxref Authors. All rights reserved.
+// Use of this source code is governed by a MIT License
+// license that can be found in the LICENSE file.
+// Last Modification: 2026-10-14 21:05:00
+//
+
+package eurofxref
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+)
+
+// The limits of the files read from the ECB or from a mirror, which may
+// not be trusted. The full history is below 10 MB and 4 levels deep.
+const (
+	// DefaultMaxResponseSize is the largest download accepted when
+	// MaxResponseSize is zero.
+	DefaultMaxResponseSize = 64 << 20
+	// maxDecompressedSize caps the content of the gzip and zip files,
+	// against decompression bombs.
+	maxDecompressedSize = 256 << 20
+	maxXMLDepth         = 16
+	maxXMLElements      = 1 << 22
+)
+
+func (efr EuroFxRef) maxResponseSize() int64 {
+
+	if efr.MaxResponseSize > 0 {
+		return efr.MaxResponseSize
+	}
+
+	return DefaultMaxResponseSize
+}
+
+// readLimited reads r to the end, failing past limit bytes.
+func readLimited(r io.Reader, limit int64) ([]byte, error) {
+	return io.ReadAll(limitReader(r, limit))
+}
+
+// limitReader returns a reader of r failing past limit bytes, where
+// io.LimitReader would silently truncate the content.
+func limitReader(r io.Reader, limit int64) io.Reader {
+	return &limitedReader{r: io.LimitReader(r, limit+1), limit: limit}
+}
+
+type limitedReader struct {
+	r     io.Reader
+	limit int64
+	read  int64
+}
+
+func (lr *limitedReader) Read(p []byte) (int, error) {
+
+	n, err := lr.r.Read(p)
+	lr.read += int64(n)
+	if lr.read > lr.limit {
+		return n, fmt.Errorf("the content exceeds %d bytes", lr.limit)
+	}
+
+	return n, err
+}
+
+// scanXML checks the XML of data before it is unmarshalled: it must not
+// declare a DTD, whose entities could expand, and its elements must stay
+// within maxXMLDepth levels and maxXMLElements.
+func scanXML(data []byte) error {
+
+	decoder := xml.NewDecoder(bytes.NewReader(data))
+	depth, elements := 0, 0
+
+	for {
+		token, err := decoder.RawToken()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("error when decoding the XML-encoded data: %v", err)
+		}
+
+		switch token.(type) {
+		case xml.Directive:
+			return errors.New("the XML-encoded data declares a DTD")
+		case xml.StartElement:
+			depth++
+			elements++
+			if depth > maxXMLDepth {
+				return fmt.Errorf("the XML-encoded data is nested deeper than %d elements", maxXMLDepth)
+			}
+			if elements > maxXMLElements {
+				return fmt.Errorf("the XML-encoded data has more than %d elements", maxXMLElements)
+			}
+		case xml.EndElement:
+			depth--
+		}
+	}
+}
+
+// validatePayload checks a downloaded file before it is cached, as an
+// error page served with status 200 or a truncated body would otherwise
+// be read from the cache, and fail, until the end of the day: the whole
+// file must parse, with at least one publication, and pass the strict
+// mode when set.
+func (efr EuroFxRef) validatePayload(fileUrl string, data []byte) error {
+
+	publications := 0
+	err := func() error {
+		if fileUrl == efr.ZipUrl || fileUrl == efr.HistZipUrl {
+			return decodeZipCSV(data, func(date time.Time, rates map[string]float64) error {
+				publications++
+				return nil
+			})
+		}
+		if efr.Strict {
+			if err := validateStrict(bytes.NewReader(data), fileUrl == efr.Url); err != nil {
+				return err
+			}
+		}
+		if err := scanXML(data); err != nil {
+			return err
+		}
+		return decodeCubes(bytes.NewReader(data), func(cube TimeCube) error {
+			publications++
+			_, _, err := cube.rates()
+			return err
+		})
+	}()
+	if err == nil && publications == 0 {
+		err = errors.New("the file has no reference rates")
+	}
+	if err != nil {
+		return fmt.Errorf("invalid content downloaded from \"%s\": %v", fileUrl, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,186 @@
+//
+// Copyright 2023 The GoEurofxref Authors. All rights reserved.
+// Use of this source code is governed by a MIT License
+// license that can be found in the LICENSE file.
+// Last Modification: 2026-10-15 13:15:00
+//
+
+package eurofxref
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestScanXML(t *testing.T) {
+
+	for _, name := range []string{"eurofxref-daily.xml", "eurofxref-hist-90d.xml", "eurofxref-hist.xml"} {
+		data, err := os.ReadFile("testdata/" + name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := scanXML(data); err != nil {
+			t.Errorf("%s: %v", name, err)
+		}
+	}
+
+	laughs := `<?xml version="1.0"?>
+<!DOCTYPE lolz [<!ENTITY lol "lol"><!ENTITY lol2 "&lol;&lol;&lol;&lol;">]>
+<Envelope>&lol2;</Envelope>`
+	deep := strings.Repeat("<Cube>", maxXMLDepth+1) + strings.Repeat("</Cube>", maxXMLDepth+1)
+	wide := "<Envelope>" + strings.Repeat("<Cube/>", maxXMLElements) + "</Envelope>"
+
+	for name, data := range map[string]string{"entities": laughs, "depth": deep, "elements": wide} {
+		if err := scanXML([]byte(data)); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+
+	if _, err := ParseEnvelope([]byte(laughs)); err == nil || !strings.Contains(err.Error(), "DTD") {
+		t.Errorf("got %v, want the DTD rejected", err)
+	}
+}
+
+func TestMaxResponseSize(t *testing.T) {
+
+	ts, query := newTestServer(t)
+	query.MaxResponseSize = 1024
+
+	if _, err := query.Daily("USD"); err == nil || !strings.Contains(err.Error(), "exceeds 1024 bytes") {
+		t.Errorf("got %v, want the response rejected", err)
+	}
+
+	query.MaxResponseSize = 0
+	if _, err := query.Daily("USD"); err != nil {
+		t.Error(err)
+	}
+
+	// a gzip bomb
+	bomb, err := gzipBytes(make([]byte, maxDecompressedSize+1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	bombServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write(bomb)
+	}))
+	defer bombServer.Close()
+	ts.Close()
+
+	query.Url = bombServer.URL
+	if _, err := query.Daily("USD"); err == nil || !strings.Contains(err.Error(), "exceeds") {
+		t.Errorf("got %v, want the decompressed content rejected", err)
+	}
+}
+
+func addSeeds(f *testing.F, names ...string) {
+
+	for _, name := range names {
+		data, err := os.ReadFile("testdata/" + name)
+		if err != nil {
+			f.Fatal(err)
+		}
+		f.Add(data)
+	}
+}
+
+func TestInvalidPayloadNotCached(t *testing.T) {
+
+	daily, err := os.ReadFile("testdata/eurofxref-daily.xml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	payloads := []string{
+		"<html><body>Service Unavailable</body></html>",
+		string(daily[:len(daily)/2]),
+		string(daily),
+	}
+	requests := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(payloads[min(requests, len(payloads)-1)]))
+		requests++
+	}))
+	defer ts.Close()
+
+	query := New(t.TempDir(), false)
+	query.Url = ts.URL + "/eurofxref-daily.xml"
+
+	for i := 0; i < 2; i++ {
+		if _, err := query.Daily("USD"); err == nil || !strings.Contains(err.Error(), "invalid content") {
+			t.Errorf("request %d: got %v, want the content rejected", requests, err)
+		}
+		if _, err := os.Stat(query.CachePath(query.Url)); err == nil {
+			t.Fatalf("request %d: the invalid content was cached", requests)
+		}
+	}
+
+	if _, err := query.Daily("USD"); err != nil {
+		t.Fatal(err)
+	}
+	if cached, err := os.ReadFile(query.CachePath(query.Url)); err != nil || !bytes.Equal(cached, daily) {
+		t.Errorf("the valid content was not cached: %v", err)
+	}
+}
+
+func FuzzParseEnvelope(f *testing.F) {
+
+	addSeeds(f, "eurofxref-daily.xml", "eurofxref-hist-90d.xml")
+	f.Add([]byte(`<Envelope><Cube><Cube time="2024-03-01"><Cube currency="USD" rate="x"/></Cube></Cube></Envelope>`))
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		envelope, err := ParseEnvelope(data)
+		if err != nil {
+			return
+		}
+		for _, cube := range envelope.Cube.Cube {
+			cube.rates()
+		}
+	})
+}
+
+func FuzzDecodeCubes(f *testing.F) {
+
+	addSeeds(f, "eurofxref-daily.xml", "eurofxref-hist-90d.xml")
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		decodeCubes(bytes.NewReader(data), func(cube TimeCube) error {
+			cube.rates()
+			return nil
+		})
+	})
+}
+
+func FuzzValidateStrict(f *testing.F) {
+
+	addSeeds(f, "eurofxref-daily.xml", "eurofxref-hist-90d.xml")
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		validateStrict(bytes.NewReader(data), true)
+		validateStrict(bytes.NewReader(data), false)
+	})
+}
+
+func FuzzDecodeDataAPI(f *testing.F) {
+
+	addSeeds(f, "exr-gbp-usd.xml", "exr-gbp-usd.csv")
+	f.Add([]byte("KEY,CURRENCY,TIME_PERIOD,OBS_VALUE\nEXR.D.USD.EUR.SP00.A,USD\n"))
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		decodeDataAPI(data)
+	})
+}
+
+func FuzzDecodeZipCSV(f *testing.F) {
+
+	addSeeds(f, "eurofxref.zip")
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		decodeZipCSV(data, func(date time.Time, rates map[string]float64) error {
+			return nil
+		})
+	})
+}
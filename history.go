@@ -0,0 +1,370 @@
+//
+// Copyright 2023 The GoEurofxref Authors. All rights reserved.
+// Use of this source code is governed by a MIT License
+// license that can be found in the LICENSE file.
+// Last Modification: 2026-10-14 19:32:21
+//
+
+package eurofxref
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// hist90Days is the period covered by the 90-day historical file.
+const hist90Days = 90 * 24 * time.Hour
+
+// Point is the reference rate of a currency on a publication date.
+type Point struct {
+	Date time.Time
+	Rate float64
+}
+
+// Series is the list of reference rates of a currency ordered by date.
+type Series struct {
+	Currency string
+	Points   []Point
+}
+
+// History returns the reference rates of the currency published in the
+// last 90 days, from the oldest to the most recent.
+func (efr EuroFxRef) History(currencyCode string) (*Series, error) {
+	return efr.HistoryContext(context.Background(), currencyCode)
+}
+
+// HistoryContext is like History, with the requests bound to ctx.
+func (efr EuroFxRef) HistoryContext(ctx context.Context, currencyCode string) (*Series, error) {
+
+	if err := efr.ValidateCurrencyCode(currencyCode); err != nil {
+		return nil, err
+	}
+
+	series, err := efr.series(ctx, efr.Hist90Url, currencyCode, time.Time{}, time.Time{})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(series.Points) == 0 {
+		return nil, fmt.Errorf("no historical rates were returned for \"%s\" currency code",
+			currencyCode)
+	}
+
+	return series, nil
+}
+
+// HistoryRange returns the reference rates of the currency published
+// between from and to, both inclusive, from the oldest to the most
+// recent. Only the publication dates have points, so weekends and TARGET
+// holidays are simply absent, and a range without any publication
+// returns an empty series. A discontinued currency can be queried for a
+// range starting before its last publication.
+//
+// The 90-day file is used when the range starts within the last 90 days,
+// and the full history otherwise.
+func (efr EuroFxRef) HistoryRange(currencyCode string, from, to time.Time) (*Series, error) {
+	return efr.HistoryRangeContext(context.Background(), currencyCode, from, to)
+}
+
+// HistoryRangeContext is like HistoryRange, with the requests bound to ctx.
+func (efr EuroFxRef) HistoryRangeContext(ctx context.Context, currencyCode string, from, to time.Time) (*Series, error) {
+
+	from, to = truncateDay(from), truncateDay(to)
+	if err := efr.ValidateHistoricalCurrency(currencyCode, from); err != nil {
+		return nil, err
+	}
+
+	if to.Before(from) {
+		return nil, errors.New("the end of the range is before its start")
+	}
+
+	if efr.UseDataAPI && !efr.Offline && efr.Provider == nil {
+		series, err := efr.DataAPIHistory(ctx, from, to, currencyCode)
+		if err != nil {
+			return nil, err
+		}
+		return series[strings.ToUpper(currencyCode)], nil
+	}
+
+	fileUrl := efr.HistUrl
+	if efr.Now().Sub(from) < hist90Days {
+		fileUrl = efr.Hist90Url
+	}
+
+	return efr.series(ctx, fileUrl, currencyCode, from, to)
+}
+
+// truncateDay returns the calendar date of t, in its own time zone, at
+// midnight CET, the way the publication dates are represented.
+func truncateDay(t time.Time) time.Time {
+
+	if t.IsZero() {
+		return t
+	}
+
+	return PublicationDate(t.Year(), t.Month(), t.Day())
+}
+
+// series returns the rates of the currency in the ECB file at fileUrl
+// published between from and to, which are unbounded when zero.
+func (efr EuroFxRef) series(ctx context.Context, fileUrl, currencyCode string,
+	from, to time.Time) (*Series, error) {
+
+	cc := strings.ToUpper(currencyCode)
+	series := &Series{Currency: cc, Points: []Point{}}
+
+	fn := func(date time.Time, rates map[string]float64) error {
+		if (!from.IsZero() && date.Before(from)) || (!to.IsZero() && date.After(to)) {
+			return nil
+		}
+		if rate, ok := rates[cc]; ok {
+			series.Points = append(series.Points, Point{Date: date, Rate: rate})
+		}
+		return nil
+	}
+
+	var err error
+	if efr.Provider != nil {
+		if fileUrl == efr.Hist90Url && from.IsZero() {
+			from = truncateDay(efr.Now().Add(-hist90Days))
+		}
+		err = efr.Provider.Historical(ctx, from, to, fn)
+	} else {
+		err = efr.eachPublication(ctx, fileUrl, fn)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// the ECB files list the most recent publication first
+	sort.Slice(series.Points, func(i, j int) bool {
+		return series.Points[i].Date.Before(series.Points[j].Date)
+	})
+
+	return series, nil
+}
+
+// rateOnLookback bounds the search of the previous publication, longer
+// than the longest TARGET closing (Good Friday to Easter Monday).
+const rateOnLookback = 14
+
+// FillPolicy selects the answer of the historical lookups for the dates
+// without a publication. The zero value is FillForward.
+type FillPolicy int
+
+const (
+	// FillForward carries the rates last published before the date
+	// forward.
+	FillForward FillPolicy = iota + 1
+	// NoFill fails with a *NoPublicationError, an ErrNoPublication.
+	NoFill
+)
+
+func (policy FillPolicy) String() string {
+
+	switch policy {
+	case 0, FillForward:
+		return "forward"
+	case NoFill:
+		return "none"
+	}
+
+	return fmt.Sprintf("FillPolicy(%d)", int(policy))
+}
+
+// ErrNoPublication reports a date without a publication, with NoFill.
+var ErrNoPublication = errors.New("no rates were published on the date")
+
+// NoPublicationError is returned with NoFill for a date without a
+// publication. Effective is the date of the publication FillForward
+// would have used.
+type NoPublicationError struct {
+	Date      time.Time
+	Effective time.Time
+}
+
+func (e *NoPublicationError) Error() string {
+	return fmt.Sprintf("no rates were published on %s, the previous publication is of %s",
+		e.Date.Format("2006-01-02"), e.Effective.Format("2006-01-02"))
+}
+
+func (e *NoPublicationError) Unwrap() error {
+	return ErrNoPublication
+}
+
+// fill returns the error of FillPolicy for the lookup of date answered
+// by the publication of published.
+func (efr EuroFxRef) fill(date, published time.Time) error {
+
+	if efr.FillPolicy == NoFill && !published.Equal(date) {
+		return &NoPublicationError{Date: date, Effective: published}
+	}
+
+	return nil
+}
+
+// HistoricalResult is a reference rate looked up for a given date.
+// LastUpdate is the publication date whose rate was used, which is the
+// requested date itself or, on non-publication dates, the most recent
+// publication before it.
+type HistoricalResult struct {
+	QueryResult
+	Requested time.Time
+}
+
+// Fallback reports whether the rate was published before the requested
+// date, because nothing was published on it.
+func (result *HistoricalResult) Fallback() bool {
+	return !result.LastUpdate.Equal(result.Requested)
+}
+
+// RateOn returns the reference rate of the currency on date. For dates
+// without a publication (weekends, TARGET holidays, or today before the
+// rates are out) it falls back to the most recent rate published before,
+// the rule required by most accounting regimes, or fails with NoFill as
+// FillPolicy.
+func (efr EuroFxRef) RateOn(currencyCode string, date time.Time) (*HistoricalResult, error) {
+	return efr.RateOnContext(context.Background(), currencyCode, date)
+}
+
+// RateOnContext is like RateOn, with the requests bound to ctx.
+func (efr EuroFxRef) RateOnContext(ctx context.Context, currencyCode string, date time.Time) (*HistoricalResult, error) {
+
+	date = truncateDay(date)
+
+	if strings.EqualFold(currencyCode, "EUR") {
+		return &HistoricalResult{
+			QueryResult: QueryResult{LastUpdate: date, RateValue: 1.00, Currency: "EUR", Base: "EUR"},
+			Requested:   date,
+		}, nil
+	}
+
+	// not the last rate of a discontinued currency carried forward
+	if err := efr.ValidateHistoricalCurrency(currencyCode, date); err != nil {
+		return nil, err
+	}
+
+	series, err := efr.HistoryRangeContext(ctx, currencyCode, date.AddDate(0, 0, -rateOnLookback), date)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(series.Points) == 0 {
+		return nil, fmt.Errorf("no rate was published for \"%s\" on or in the %d days before %s",
+			currencyCode, rateOnLookback, date.Format("2006-01-02"))
+	}
+
+	point := series.Points[len(series.Points)-1]
+	if err := efr.fill(date, point.Date); err != nil {
+		return nil, err
+	}
+
+	return &HistoricalResult{
+		QueryResult: QueryResult{
+			LastUpdate: point.Date,
+			RateValue:  point.Rate,
+			Currency:   series.Currency,
+			Base:       "EUR",
+		},
+		Requested: date,
+	}, nil
+}
+
+// RatesOn returns the table of the reference rates published on date or,
+// on the dates without a publication, the most recent one before, as
+// RateOn does for a single currency, following FillPolicy.
+func (efr EuroFxRef) RatesOn(date time.Time) (*RateTable, error) {
+	return efr.RatesOnContext(context.Background(), date)
+}
+
+// RatesOnContext is like RatesOn, with the requests bound to ctx.
+func (efr EuroFxRef) RatesOnContext(ctx context.Context, date time.Time) (*RateTable, error) {
+
+	date = truncateDay(date)
+	from := date.AddDate(0, 0, -rateOnLookback)
+
+	var table *RateTable
+	fn := func(published time.Time, rates map[string]float64) error {
+		if published.Before(from) || published.After(date) {
+			return nil
+		}
+		if table == nil || published.After(table.Date) {
+			table = &RateTable{Date: published, Rates: rates}
+		}
+		return nil
+	}
+
+	var err error
+	if efr.Provider != nil {
+		err = efr.Provider.Historical(ctx, from, date, fn)
+	} else {
+		fileUrl := efr.HistUrl
+		if efr.Now().Sub(from) < hist90Days {
+			fileUrl = efr.Hist90Url
+		}
+		err = efr.eachPublication(ctx, fileUrl, fn)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if table == nil {
+		return nil, fmt.Errorf("no rates were published on or in the %d days before %s",
+			rateOnLookback, date.Format("2006-01-02"))
+	}
+	if err := efr.fill(date, table.Date); err != nil {
+		return nil, err
+	}
+
+	return table, nil
+}
+
+// RateChange is the movement of a reference rate between two dates.
+// From and To are the lookups of RateOn, so their LastUpdate is the
+// publication actually used when a date had none.
+type RateChange struct {
+	Currency string
+	From     HistoricalResult
+	To       HistoricalResult
+	Absolute float64
+	// Percent is the change relative to the rate of From, in percent.
+	Percent float64
+}
+
+// Change returns the absolute and percentage change of the reference
+// rate of the currency between from and to.
+func (efr EuroFxRef) Change(currencyCode string, from, to time.Time) (*RateChange, error) {
+	return efr.ChangeContext(context.Background(), currencyCode, from, to)
+}
+
+// ChangeContext is like Change, with the requests bound to ctx.
+func (efr EuroFxRef) ChangeContext(ctx context.Context, currencyCode string, from, to time.Time) (*RateChange, error) {
+
+	if truncateDay(to).Before(truncateDay(from)) {
+		return nil, errors.New("the end of the range is before its start")
+	}
+
+	start, err := efr.RateOnContext(ctx, currencyCode, from)
+	if err != nil {
+		return nil, err
+	}
+
+	end, err := efr.RateOnContext(ctx, currencyCode, to)
+	if err != nil {
+		return nil, err
+	}
+
+	change := &RateChange{
+		Currency: strings.ToUpper(currencyCode),
+		From:     *start,
+		To:       *end,
+		Absolute: end.RateValue - start.RateValue,
+	}
+	change.Percent = change.Absolute / start.RateValue * 100
+
+	return change, nil
+}
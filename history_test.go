@@ -0,0 +1,329 @@
+//
+// Copyright 2023 The GoEurofxref Authors. All rights reserved.
+// Use of this source code is governed by a MIT License
+// license that can be found in the LICENSE file.
+// Last Modification: 2026-10-14 19:32:21
+//
+
+package eurofxref
+
+import (
+	"errors"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// newTestServer serves the ECB sample files in the testdata directory.
+func newTestServer(t *testing.T) (*httptest.Server, EuroFxRef) {
+
+	ts := httptest.NewServer(http.FileServer(http.Dir("testdata")))
+	t.Cleanup(ts.Close)
+
+	query := New("", false)
+	query.CacheDir = ""
+	query.Url = ts.URL + "/eurofxref-daily.xml"
+	query.HistUrl = ts.URL + "/eurofxref-hist.xml"
+	query.Hist90Url = ts.URL + "/eurofxref-hist-90d.xml"
+	query.ZipUrl = ts.URL + "/eurofxref.zip"
+	query.HistZipUrl = ts.URL + "/eurofxref-hist.zip"
+
+	return ts, query
+}
+
+func TestHistory(t *testing.T) {
+
+	_, query := newTestServer(t)
+
+	series, err := query.History("usd")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if series.Currency != "USD" {
+		t.Errorf("got currency %s, want USD", series.Currency)
+	}
+	if len(series.Points) != 10 {
+		t.Fatalf("got %d points, want 10", len(series.Points))
+	}
+	for i := 1; i < len(series.Points); i++ {
+		if !series.Points[i-1].Date.Before(series.Points[i].Date) {
+			t.Fatalf("points are not sorted by date: %v", series.Points)
+		}
+	}
+
+	last := series.Points[len(series.Points)-1]
+	if got := last.Date.Format("2006-01-02"); got != "2024-03-01" {
+		t.Errorf("got last date %s, want 2024-03-01", got)
+	}
+	if last.Rate != 1.0876 {
+		t.Errorf("got last rate %v, want 1.0876", last.Rate)
+	}
+
+	if _, err := query.History("XXX"); err == nil {
+		t.Error("expected an error for an unknown currency")
+	}
+}
+
+func TestDailyRates(t *testing.T) {
+
+	_, query := newTestServer(t)
+
+	table, err := query.DailyRates()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(table.Rates) != 30 {
+		t.Errorf("got %d rates, want 30", len(table.Rates))
+	}
+	if got := table.Date.Format("2006-01-02"); got != "2024-03-01" {
+		t.Errorf("got publication date %s, want 2024-03-01", got)
+	}
+	if table.Rates["GBP"] != 0.85578 {
+		t.Errorf("got GBP %v, want 0.85578", table.Rates["GBP"])
+	}
+}
+
+func TestDailyMulti(t *testing.T) {
+
+	ts, query := newTestServer(t)
+
+	requests := 0
+	handler := ts.Config.Handler
+	ts.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		handler.ServeHTTP(w, r)
+	})
+
+	results, err := query.DailyMulti("usd", "GBP", "EUR")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if requests != 1 {
+		t.Errorf("the daily file was requested %d times, want 1", requests)
+	}
+	if len(results) != 3 || results["USD"].RateValue != 1.0876 || results["GBP"].RateValue != 0.85578 ||
+		results["EUR"].RateValue != 1 {
+		t.Errorf("unexpected results %v", results)
+	}
+	if !results["EUR"].LastUpdate.Equal(results["USD"].LastUpdate) {
+		t.Errorf("EUR is dated %v, want the publication date", results["EUR"].LastUpdate)
+	}
+
+	if _, err := query.DailyMulti("USD", "XXX"); err == nil {
+		t.Error("expected an error for an unsupported currency")
+	}
+}
+
+func TestHistoryRange(t *testing.T) {
+
+	_, query := newTestServer(t)
+
+	day := func(s string) time.Time {
+		d, err := parseDate(s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return d
+	}
+
+	tests := []struct {
+		from, to    string
+		first, last string
+		n           int
+	}{
+		{"2024-02-05", "2024-03-01", "2024-02-05", "2024-03-01", 20},
+		// weekend bounds
+		{"2024-02-10", "2024-02-18", "2024-02-12", "2024-02-16", 5},
+		{"2024-02-26", "2024-02-26", "2024-02-26", "2024-02-26", 1},
+	}
+
+	for _, tt := range tests {
+		series, err := query.HistoryRange("USD", day(tt.from), day(tt.to))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(series.Points) != tt.n {
+			t.Errorf("%s..%s: got %d points, want %d", tt.from, tt.to, len(series.Points), tt.n)
+			continue
+		}
+		if got := series.Points[0].Date.Format("2006-01-02"); got != tt.first {
+			t.Errorf("%s..%s: got first date %s, want %s", tt.from, tt.to, got, tt.first)
+		}
+		if got := series.Points[tt.n-1].Date.Format("2006-01-02"); got != tt.last {
+			t.Errorf("%s..%s: got last date %s, want %s", tt.from, tt.to, got, tt.last)
+		}
+	}
+
+	// a weekend only range has no publication
+	series, err := query.HistoryRange("USD", day("2024-02-17"), day("2024-02-18"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(series.Points) != 0 {
+		t.Errorf("got %d points on a weekend, want 0", len(series.Points))
+	}
+
+	if _, err := query.HistoryRange("USD", day("2024-03-01"), day("2024-02-01")); err == nil {
+		t.Error("expected an error for a reversed range")
+	}
+}
+
+func TestRateOn(t *testing.T) {
+
+	_, query := newTestServer(t)
+
+	tests := []struct {
+		date     string
+		used     string
+		fallback bool
+	}{
+		{"2024-02-29", "2024-02-29", false},
+		{"2024-02-25", "2024-02-23", true}, // Sunday
+		{"2024-02-24", "2024-02-23", true}, // Saturday
+	}
+
+	for _, tt := range tests {
+		date, _ := parseDate(tt.date)
+		result, err := query.RateOn("GBP", date)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := result.LastUpdate.Format("2006-01-02"); got != tt.used {
+			t.Errorf("RateOn(%s) used %s, want %s", tt.date, got, tt.used)
+		}
+		if result.Fallback() != tt.fallback {
+			t.Errorf("RateOn(%s).Fallback() = %v, want %v", tt.date, result.Fallback(), tt.fallback)
+		}
+		if result.Requested.Format("2006-01-02") != tt.date {
+			t.Errorf("RateOn(%s) requested %s", tt.date, result.Requested)
+		}
+	}
+
+	// before the oldest publication of the test history
+	date, _ := parseDate("2023-12-01")
+	if _, err := query.RateOn("GBP", date); err == nil {
+		t.Error("expected an error without any publication in the lookback")
+	}
+}
+
+func TestNoFill(t *testing.T) {
+
+	_, query := newTestServer(t)
+	query.FillPolicy = NoFill
+
+	date, _ := parseDate("2024-02-29")
+	if result, err := query.RateOn("GBP", date); err != nil || result.Fallback() {
+		t.Errorf("got %+v (%v), want the rate of the publication day", result, err)
+	}
+
+	saturday, _ := parseDate("2024-02-24")
+	lookups := map[string]func() error{
+		"RateOn": func() error {
+			_, err := query.RateOn("GBP", saturday)
+			return err
+		},
+		"RatesOn": func() error {
+			_, err := query.RatesOn(saturday)
+			return err
+		},
+		"ConvertOn": func() error {
+			_, err := query.ConvertOn(100, "USD", "GBP", saturday)
+			return err
+		},
+	}
+	for name, lookup := range lookups {
+		err := lookup()
+		var noPublication *NoPublicationError
+		if !errors.Is(err, ErrNoPublication) || !errors.As(err, &noPublication) ||
+			noPublication.Effective.Format("2006-01-02") != "2024-02-23" {
+			t.Errorf("%s: got %v, want an ErrNoPublication with the publication of 2024-02-23", name, err)
+		}
+	}
+}
+
+func TestRatesOn(t *testing.T) {
+
+	_, query := newTestServer(t)
+
+	date, _ := parseDate("2024-02-25") // Sunday
+	table, err := query.RatesOn(date)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := table.Date.Format("2006-01-02"); got != "2024-02-23" {
+		t.Errorf("got the publication of %s, want 2024-02-23", got)
+	}
+	if !table.Has("USD") || !table.Has("GBP") {
+		t.Errorf("got currencies %v", table.Currencies())
+	}
+
+	date, _ = parseDate("2023-12-01")
+	if _, err := query.RatesOn(date); err == nil {
+		t.Error("expected an error without any publication in the lookback")
+	}
+}
+
+func TestChange(t *testing.T) {
+
+	_, query := newTestServer(t)
+
+	from, _ := parseDate("2024-02-24") // Saturday
+	to, _ := parseDate("2024-03-01")
+
+	change, err := query.Change("usd", from, to)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if change.Currency != "USD" || change.From.LastUpdate.Format("2006-01-02") != "2024-02-23" ||
+		!change.From.Fallback() || change.To.Fallback() {
+		t.Errorf("unexpected publications %+v", change)
+	}
+	if math.Abs(change.Absolute-0.0053) > 1e-9 || math.Abs(change.Percent-0.48969786565647) > 1e-9 {
+		t.Errorf("unexpected change %v (%v%%)", change.Absolute, change.Percent)
+	}
+
+	if _, err := query.Change("USD", to, from); err == nil {
+		t.Error("expected an error for a reversed range")
+	}
+}
+
+func TestQueryResultCurrency(t *testing.T) {
+
+	_, query := newTestServer(t)
+
+	daily, err := query.Daily("usd")
+	if err != nil {
+		t.Fatal(err)
+	}
+	euro, err := query.Daily("EUR")
+	if err != nil {
+		t.Fatal(err)
+	}
+	historical, err := query.RateOn("gbp", PublicationDate(2024, 2, 29))
+	if err != nil {
+		t.Fatal(err)
+	}
+	inverse, err := query.InverseRate("USD")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		result         *QueryResult
+		currency, base string
+	}{
+		{daily, "USD", "EUR"},
+		{euro, "EUR", "EUR"},
+		{&historical.QueryResult, "GBP", "EUR"},
+		{inverse, "EUR", "USD"},
+	}
+	for _, tt := range tests {
+		if tt.result.Currency != tt.currency || tt.result.Base != tt.base {
+			t.Errorf("got %s/%s, want %s/%s", tt.result.Base, tt.result.Currency, tt.base, tt.currency)
+		}
+	}
+}
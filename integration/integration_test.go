@@ -0,0 +1,286 @@
+//
+// Copyright 2023 The GoEurofxref Authors. All rights reserved.
+// Use of this source code is governed by a MIT License
+// license that can be found in the LICENSE file.
+// Last Modification: 2026-10-15 17:55:00
+//
+
+//go:build integration
+
+// Package integration exercises the fetch, cache, sync, serve and notify
+// paths together against a mirror of the ECB files running in a
+// container.
+//
+// Run it with:
+//
+//	go test -tags integration ./integration/
+//
+// The mirror is an nginx container serving the testdata directory, started
+// with docker unless EUROFXREF_MIRROR_URL points to a running mirror.
+package integration
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	eurofxref "github.com/mrhdias/go-eurofxref"
+	"github.com/mrhdias/go-eurofxref/server"
+)
+
+const mirrorImage = "nginx:1.27-alpine"
+
+var mirrorUrl string
+
+func TestMain(m *testing.M) {
+
+	mirrorUrl = os.Getenv("EUROFXREF_MIRROR_URL")
+
+	stop := func() {}
+	if mirrorUrl == "" {
+		var err error
+		if mirrorUrl, stop, err = startMirror(); err != nil {
+			fmt.Fprintf(os.Stderr, "integration: %v\n", err)
+			os.Exit(1)
+		}
+	}
+
+	code := m.Run()
+	stop()
+	os.Exit(code)
+}
+
+// startMirror runs the mirror container on a random port and waits
+// until it serves the daily file.
+func startMirror() (string, func(), error) {
+
+	testdata, err := filepath.Abs("../testdata")
+	if err != nil {
+		return "", nil, err
+	}
+
+	out, err := exec.Command("docker", "run", "--rm", "-d",
+		"-p", "127.0.0.1::80",
+		"-v", testdata+":/usr/share/nginx/html:ro",
+		mirrorImage).Output()
+	if err != nil {
+		return "", nil, fmt.Errorf("could not start the mirror container: %v", err)
+	}
+	id := strings.TrimSpace(string(out))
+	stop := func() { exec.Command("docker", "rm", "-f", id).Run() }
+
+	out, err = exec.Command("docker", "port", id, "80/tcp").Output()
+	if err != nil {
+		stop()
+		return "", nil, fmt.Errorf("could not get the mirror port: %v", err)
+	}
+	url := "http://" + strings.TrimSpace(strings.Split(string(out), "\n")[0])
+
+	for deadline := time.Now().Add(30 * time.Second); time.Now().Before(deadline); {
+		if resp, err := http.Get(url + "/eurofxref-daily.xml"); err == nil {
+			resp.Body.Close()
+			if resp.StatusCode == http.StatusOK {
+				return url, stop, nil
+			}
+		}
+		time.Sleep(200 * time.Millisecond)
+	}
+
+	stop()
+	return "", nil, fmt.Errorf("the mirror at %s did not become ready", url)
+}
+
+func newQuery(t *testing.T) eurofxref.EuroFxRef {
+
+	query := eurofxref.New(filepath.Join(t.TempDir(), "cache"), true)
+	query.Url = mirrorUrl + "/eurofxref-daily.xml"
+	query.HistUrl = mirrorUrl + "/eurofxref-hist.xml"
+	query.Hist90Url = mirrorUrl + "/eurofxref-hist-90d.xml"
+
+	return query
+}
+
+func TestFetchAndCache(t *testing.T) {
+
+	query := newQuery(t)
+
+	result, err := query.Daily("USD")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.RateValue != 1.0876 {
+		t.Errorf("got %v, want 1.0876", result.RateValue)
+	}
+
+	cached := query.CachePath(query.Url)
+	if _, err := os.Stat(cached); err != nil {
+		t.Fatalf("the daily file was not cached: %v", err)
+	}
+
+	// the cached copy answers without network access
+	query.Offline = true
+	if _, err := query.Daily("USD"); err != nil {
+		t.Errorf("the cached file was not used: %v", err)
+	}
+}
+
+func TestSync(t *testing.T) {
+
+	query := newQuery(t)
+	store, err := eurofxref.OpenHistoryStore(filepath.Join(t.TempDir(), "history.xml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i, wantAdded := range []int{20, 0} {
+		report, err := store.Sync(query)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(report.Added) != wantAdded {
+			t.Errorf("sync %d: got %d added publications, want %d", i, len(report.Added), wantAdded)
+		}
+	}
+}
+
+func TestServe(t *testing.T) {
+
+	ts := httptest.NewServer(server.New(newQuery(t), true))
+	defer ts.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	req, _ := http.NewRequestWithContext(ctx, "GET", ts.URL+"/rates/latest", nil)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+
+	var latest struct {
+		Date  string             `json:"date"`
+		Rates map[string]float64 `json:"rates"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&latest); err != nil {
+		t.Fatal(err)
+	}
+	if latest.Date != "2024-03-01" || len(latest.Rates) != 30 {
+		t.Errorf("unexpected latest rates %+v", latest)
+	}
+
+	resp, err = http.Get(ts.URL + "/?currency=GBP")
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("got dashboard status %d, want %d", resp.StatusCode, http.StatusOK)
+	}
+}
+
+// firstDay matches the rates of the most recent day of a file.
+var firstDay = regexp.MustCompile(`(?s)\s*<Cube time='[^']+'>.*?</Cube>`)
+
+// newPreviousServer serves the last 90 days file of the mirror without
+// its most recent day, as the daily file published the day before the
+// one of the mirror.
+func newPreviousServer(t *testing.T) *httptest.Server {
+
+	resp, err := http.Get(mirrorUrl + "/eurofxref-hist-90d.xml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	loc := firstDay.FindIndex(data)
+	if loc == nil {
+		t.Fatal("the last 90 days file of the mirror has no rates")
+	}
+	previous := append(append([]byte{}, data[:loc[0]]...), data[loc[1]:]...)
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write(previous)
+	}))
+	t.Cleanup(ts.Close)
+
+	return ts
+}
+
+func TestNotifyWebhook(t *testing.T) {
+
+	const secret = "integration"
+
+	received := make(chan eurofxref.WebhookPayload, 2)
+	hook := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		if err != nil || !eurofxref.VerifySignature(secret, body, r.Header.Get(eurofxref.SignatureHeader)) {
+			http.Error(w, "invalid signature", http.StatusUnauthorized)
+			t.Errorf("got a request with the signature %q", r.Header.Get(eurofxref.SignatureHeader))
+			return
+		}
+		var payload eurofxref.WebhookPayload
+		if err := json.Unmarshal(body, &payload); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			t.Error(err)
+			return
+		}
+		received <- payload
+	}))
+	defer hook.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	query := newQuery(t)
+	query.Url = newPreviousServer(t).URL
+	rules := []eurofxref.AlertRule{{Currency: "USD", Below: 1.09}}
+	if err := query.Notify(ctx, rules, &eurofxref.Webhook{URL: hook.URL, Secret: secret, Retries: -1}); err != nil {
+		t.Fatal(err)
+	}
+
+	// the baseline, then the publication of the mirror
+	for _, url := range []string{query.Url, mirrorUrl + "/eurofxref-daily.xml"} {
+		query.Url = url
+		if _, err := query.DailyRatesContext(ctx); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	for _, want := range []string{"publication", "alert"} {
+		select {
+		case payload := <-received:
+			if payload.Event != want || payload.Date != "2024-03-01" || payload.Previous != "2024-02-29" {
+				t.Fatalf("got the %s payload of %s replacing %s, want a %s", payload.Event, payload.Date, payload.Previous, want)
+			}
+			var usd *eurofxref.WebhookChange
+			for i := range payload.Changes {
+				if payload.Changes[i].Currency == "USD" {
+					usd = &payload.Changes[i]
+				}
+			}
+			if usd == nil || usd.Previous != 1.0796 || usd.Rate != 1.0876 {
+				t.Errorf("unexpected changes %+v", payload.Changes)
+			}
+			if want == "alert" && (len(payload.Alerts) != 1 || payload.Alerts[0] != "USD below 1.09") {
+				t.Errorf("got the alerts %v", payload.Alerts)
+			}
+		case <-ctx.Done():
+			t.Fatalf("the webhook was not notified of the %s", want)
+		}
+	}
+}
@@ -0,0 +1,67 @@
+//
+// Copyright 2023 The GoEurofxref Authors. All rights reserved.
+// Use of this source code is governed by a MIT License
+// license that can be found in the LICENSE file.
+// Last Modification: 2026-10-14 19:27:43
+//
+
+package eurofxref
+
+import (
+	"errors"
+	"math"
+	"strconv"
+)
+
+// InverseDigits is the number of significant digits of an inverse rate.
+// The ECB publishes its rates with five significant digits, so the
+// quotient 1/x carries no more information than that; one guard digit is
+// kept. Rounding drops the float64 noise of the division, so the inverse
+// of 1.0876 is 0.919456 and not 0.9194556822361163.
+const InverseDigits = 6
+
+// Inverse returns the units of EUR per unit of the foreign currency,
+// rounded to InverseDigits significant digits. The inverse of a zero rate
+// is zero.
+func (result QueryResult) Inverse() float64 {
+
+	if result.RateValue == 0 {
+		return 0
+	}
+
+	return roundSignificant(1/result.RateValue, InverseDigits)
+}
+
+// InverseRate returns the latest reference rate of the currency quoted
+// the other way round, as EUR per unit of the currency: the Base of the
+// result is the currency and its Currency the euro.
+func (efr EuroFxRef) InverseRate(currencyCode string) (*QueryResult, error) {
+
+	result, err := efr.Daily(currencyCode)
+	if err != nil {
+		return nil, err
+	}
+
+	if result.RateValue == 0 {
+		return nil, errors.New("the reference rate is zero and has no inverse")
+	}
+
+	return &QueryResult{
+		LastUpdate: result.LastUpdate,
+		RateValue:  result.Inverse(),
+		Currency:   "EUR",
+		Base:       result.Currency,
+	}, nil
+}
+
+// roundSignificant rounds x to the given number of significant digits.
+func roundSignificant(x float64, digits int) float64 {
+
+	if x == 0 || math.IsInf(x, 0) || math.IsNaN(x) {
+		return x
+	}
+
+	rounded, _ := strconv.ParseFloat(strconv.FormatFloat(x, 'g', digits, 64), 64)
+
+	return rounded
+}
@@ -0,0 +1,47 @@
+//
+// Copyright 2023 The GoEurofxref Authors. All rights reserved.
+// Use of this source code is governed by a MIT License
+// license that can be found in the LICENSE file.
+// Last Modification: 2026-10-14 18:16:04
+//
+
+package eurofxref
+
+import "testing"
+
+func TestInverse(t *testing.T) {
+
+	tests := []struct {
+		rate    float64
+		inverse float64
+	}{
+		{1.0876, 0.919456},
+		{390.33, 0.00256193},
+		{0.85578, 1.16852},
+		{1, 1},
+		{0, 0},
+	}
+
+	for _, tt := range tests {
+		if got := (QueryResult{RateValue: tt.rate}).Inverse(); got != tt.inverse {
+			t.Errorf("Inverse of %v = %v, want %v", tt.rate, got, tt.inverse)
+		}
+	}
+}
+
+func TestInverseRate(t *testing.T) {
+
+	_, query := newTestServer(t)
+
+	result, err := query.InverseRate("HUF")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.RateValue != 0.00256193 || result.LastUpdate.Format("2006-01-02") != "2024-03-01" {
+		t.Errorf("unexpected inverse rate %+v", result)
+	}
+
+	if _, err := query.InverseRate("XXX"); err == nil {
+		t.Error("expected an error for an unsupported currency")
+	}
+}
@@ -0,0 +1,98 @@
+//
+// Copyright 2023 The GoEurofxref Authors. All rights reserved.
+// Use of this source code is governed by a MIT License
+// license that can be found in the LICENSE file.
+// Last Modification: 2026-10-14 19:27:43
+//
+
+package eurofxref
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// isoCurrencies are the alphabetic codes of ISO 4217, but for XTS (the
+// code of tests) and XXX (no currency).
+var isoCurrencies = func() map[string]void {
+	currencies := map[string]void{}
+	for _, currencyCode := range strings.Fields(`
+		AED AFN ALL AMD ANG AOA ARS AUD AWG AZN BAM BBD BDT BGN BHD BIF BMD
+		BND BOB BOV BRL BSD BTN BWP BYN BZD CAD CDF CHE CHF CHW CLF CLP CNY
+		COP COU CRC CUP CVE CZK DJF DKK DOP DZD EGP ERN ETB EUR FJD FKP GBP
+		GEL GHS GIP GMD GNF GTQ GYD HKD HNL HTG HUF IDR ILS INR IQD IRR ISK
+		JMD JOD JPY KES KGS KHR KMF KPW KRW KWD KYD KZT LAK LBP LKR LRD LSL
+		LYD MAD MDL MGA MKD MMK MNT MOP MRU MUR MVR MWK MXN MXV MYR MZN NAD
+		NGN NIO NOK NPR NZD OMR PAB PEN PGK PHP PKR PLN PYG QAR RON RSD RUB
+		RWF SAR SBD SCR SDG SEK SGD SHP SLE SOS SRD SSP STN SVC SYP SZL THB
+		TJS TMT TND TOP TRY TTD TWD TZS UAH UGX USD USN UYI UYU UYW UZS VED
+		VES VND VUV WST XAF XAG XAU XBA XBB XBC XBD XCD XCG XDR XOF XPD XPF
+		XPT XSU XUA YER ZAR ZMW ZWG`) {
+		currencies[currencyCode] = void{}
+	}
+	return currencies
+}()
+
+// IsISOCurrency reports whether the code is a current ISO 4217 currency
+// code, or one of the currencies discontinued by the ECB.
+func IsISOCurrency(currencyCode string) bool {
+
+	cc := strings.ToUpper(currencyCode)
+	if _, ok := isoCurrencies[cc]; ok {
+		return true
+	}
+	_, ok := discontinuedCurrencies[cc]
+
+	return ok
+}
+
+var (
+	// ErrInvalidCurrency is the class of the errors of the codes that are
+	// not currency codes at all, such as "US$".
+	ErrInvalidCurrency = errors.New("invalid currency code")
+	// ErrUnsupportedCurrency is the class of the errors of the ISO 4217
+	// codes the ECB does not publish, such as "AED", or no longer does.
+	ErrUnsupportedCurrency = errors.New("currency not published by the ECB")
+)
+
+// InvalidCurrencyError is returned for a code that is not an ISO 4217
+// currency code. It is an ErrInvalidCurrency.
+type InvalidCurrencyError struct {
+	Code string
+}
+
+func (e *InvalidCurrencyError) Error() string {
+
+	switch {
+	case e.Code == "":
+		return "no currency code specified"
+	case len(e.Code) != 3:
+		return fmt.Sprintf("the \"%s\" currency code has a wrong number of characters", e.Code)
+	}
+
+	return fmt.Sprintf("\"%s\" is not an ISO 4217 currency code", e.Code)
+}
+
+func (e *InvalidCurrencyError) Unwrap() error {
+	return ErrInvalidCurrency
+}
+
+// UnsupportedCurrencyError is returned for an ISO 4217 currency code that
+// is not part of the reference list. It is an ErrUnsupportedCurrency.
+type UnsupportedCurrencyError struct {
+	Code string
+}
+
+func (e *UnsupportedCurrencyError) Error() string {
+
+	if strings.EqualFold(e.Code, "EUR") {
+		return "all currencies quoted against the euro (base currency)"
+	}
+
+	return fmt.Sprintf("the currency code \"%s\" is not part of the reference list", e.Code)
+}
+
+func (e *UnsupportedCurrencyError) Unwrap() error {
+	return ErrUnsupportedCurrency
+}
@@ -0,0 +1,59 @@
+//
+// Copyright 2023 The GoEurofxref Authors. All rights reserved.
+// Use of this source code is governed by a MIT License
+// license that can be found in the LICENSE file.
+// Last Modification: 2026-10-14 19:26:32
+//
+
+package eurofxref
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestValidateCurrencyCodeErrors(t *testing.T) {
+
+	query := New("", false)
+
+	tests := []struct {
+		code string
+		want error
+	}{
+		{"USD", nil},
+		{"usd", nil},
+		{"AED", ErrUnsupportedCurrency},
+		{"sar", ErrUnsupportedCurrency},
+		{"UAH", ErrUnsupportedCurrency},
+		{"EUR", ErrUnsupportedCurrency},
+		{"HRK", ErrUnsupportedCurrency},
+		{"", ErrInvalidCurrency},
+		{"usd ", ErrInvalidCurrency},
+		{"US$", ErrInvalidCurrency},
+		{"XYZ", ErrInvalidCurrency},
+		{"XXX", ErrInvalidCurrency},
+	}
+
+	for _, test := range tests {
+		err := query.ValidateCurrencyCode(test.code)
+		if (test.want == nil) != (err == nil) || (test.want != nil && !errors.Is(err, test.want)) {
+			t.Errorf("ValidateCurrencyCode(%q) = %v, want %v", test.code, err, test.want)
+		}
+	}
+
+	var unsupported *UnsupportedCurrencyError
+	if err := query.ValidateCurrencyCode("AED"); !errors.As(err, &unsupported) || unsupported.Code != "AED" ||
+		err.Error() != `the currency code "AED" is not part of the reference list` {
+		t.Errorf("got %v, want an *UnsupportedCurrencyError", err)
+	}
+	var invalid *InvalidCurrencyError
+	if err := query.ValidateCurrencyCode("US$"); !errors.As(err, &invalid) || invalid.Code != "US$" {
+		t.Errorf("got %v, want an *InvalidCurrencyError", err)
+	}
+
+	// the currencies added at runtime need not be ISO codes
+	query.AddCurrency("XYZ")
+	if err := query.ValidateCurrencyCode("XYZ"); err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,85 @@
+//
+// Copyright 2023 The GoEurofxref Authors. All rights reserved.
+// Use of this source code is governed by a MIT License
+// license that can be found in the LICENSE file.
+// Last Modification: 2026-10-14 19:27:43
+//
+
+package eurofxref
+
+import (
+	"context"
+	"errors"
+	"iter"
+	"strings"
+	"time"
+)
+
+// errStopIteration ends the decoding when the consumer of an iterator
+// breaks out of its loop.
+var errStopIteration = errors.New("iteration stopped")
+
+// All returns an iterator over the dates and rates of the series, from
+// the oldest to the most recent.
+func (series *Series) All() iter.Seq2[time.Time, float64] {
+
+	return func(yield func(time.Time, float64) bool) {
+		for _, point := range series.Points {
+			if !yield(point.Date, point.Rate) {
+				return
+			}
+		}
+	}
+}
+
+// HistorySeq returns an iterator over the reference rates of the currency
+// published between from and to, both inclusive, decoded lazily from the
+// full history while the loop runs. Unlike HistoryRange the points come
+// in the order of the file, from the most recent to the oldest, and
+// nothing is kept once yielded. A zero from or to leaves that side of the
+// range open.
+//
+// An error ends the iteration and is yielded with a zero Point.
+func (efr EuroFxRef) HistorySeq(ctx context.Context, currencyCode string,
+	from, to time.Time) iter.Seq2[Point, error] {
+
+	return func(yield func(Point, error) bool) {
+		if err := efr.ValidateHistoricalCurrency(currencyCode, from); err != nil {
+			yield(Point{}, err)
+			return
+		}
+
+		cc := strings.ToUpper(currencyCode)
+		from, to := truncateDay(from), truncateDay(to)
+
+		err := efr.eachPublication(ctx, efr.HistUrl, func(date time.Time, rates map[string]float64) error {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			if (!from.IsZero() && date.Before(from)) || (!to.IsZero() && date.After(to)) {
+				return nil
+			}
+			if rate, ok := rates[cc]; ok && !yield(Point{Date: date, Rate: rate}, nil) {
+				return errStopIteration
+			}
+			return nil
+		})
+		if err != nil && !errors.Is(err, errStopIteration) {
+			yield(Point{}, err)
+		}
+	}
+}
+
+// All returns an iterator over the publications in the store, from the
+// oldest to the most recent, with the same guarantees as Each.
+func (store *HistoryStore) All(ctx context.Context) iter.Seq2[time.Time, map[string]float64] {
+
+	return func(yield func(time.Time, map[string]float64) bool) {
+		store.Each(ctx, func(date time.Time, rates map[string]float64) error {
+			if !yield(date, rates) {
+				return errStopIteration
+			}
+			return nil
+		})
+	}
+}
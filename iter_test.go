@@ -0,0 +1,95 @@
+//
+// Copyright 2023 The GoEurofxref Authors. All rights reserved.
+// Use of this source code is governed by a MIT License
+// license that can be found in the LICENSE file.
+// Last Modification: 2026-10-14 19:32:21
+//
+
+package eurofxref
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestSeriesAll(t *testing.T) {
+
+	series := &Series{Currency: "USD", Points: []Point{{march(1), 1}, {march(4), 2}, {march(5), 3}}}
+
+	sum := 0.0
+	for date, rate := range series.All() {
+		if date.After(march(4)) {
+			break
+		}
+		sum += rate
+	}
+	if sum != 3 {
+		t.Errorf("got sum %v, want 3", sum)
+	}
+}
+
+func TestHistorySeq(t *testing.T) {
+
+	_, query := newTestServer(t)
+
+	from, _ := parseDate("2024-02-26")
+	to, _ := parseDate("2024-02-29")
+
+	var dates []string
+	for point, err := range query.HistorySeq(context.Background(), "usd", from, to) {
+		if err != nil {
+			t.Fatal(err)
+		}
+		dates = append(dates, point.Date.Format("2006-01-02"))
+	}
+	want := []string{"2024-02-29", "2024-02-28", "2024-02-27", "2024-02-26"}
+	if len(dates) != len(want) {
+		t.Fatalf("got dates %v, want %v", dates, want)
+	}
+	for i := range want {
+		if dates[i] != want[i] {
+			t.Fatalf("got dates %v, want %v", dates, want)
+		}
+	}
+
+	count := 0
+	for _, err := range query.HistorySeq(context.Background(), "USD", time.Time{}, time.Time{}) {
+		if err != nil {
+			t.Fatal(err)
+		}
+		if count++; count == 3 {
+			break
+		}
+	}
+	if count != 3 {
+		t.Errorf("the loop ran %d times, want 3", count)
+	}
+
+	for _, err := range query.HistorySeq(context.Background(), "XXX", time.Time{}, time.Time{}) {
+		if err == nil {
+			t.Error("expected an error for an unsupported currency")
+		}
+	}
+}
+
+func TestHistoryStoreAll(t *testing.T) {
+
+	store := newTestStore(t)
+
+	var previous time.Time
+	count := 0
+	for date, rates := range store.All(context.Background()) {
+		if !previous.IsZero() && !date.After(previous) {
+			t.Fatalf("%v does not follow %v", date, previous)
+		}
+		if len(rates) == 0 {
+			t.Errorf("no rates on %v", date)
+		}
+		previous = date
+		count++
+	}
+	if count != store.Len() || count == 0 {
+		t.Errorf("iterated over %d publications, want %d", count, store.Len())
+	}
+}
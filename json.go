@@ -0,0 +1,370 @@
+//
+// Copyright 2023 The GoEurofxref Authors. All rights reserved.
+// Use of this source code is governed by a MIT License
+// license that can be found in the LICENSE file.
+// Last Modification: 2026-10-14 19:32:21
+//
+
+package eurofxref
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// The JSON encodings below write dates as ISO 8601 calendar dates
+// ("2024-03-01") and rates as decimal strings ("1.0876"), so the values
+// published by the ECB survive clients that parse numbers as float32 or
+// as arbitrary precision decimals. Rates are also accepted as numbers.
+
+// jsonDate is a publication date encoded as "2006-01-02".
+type jsonDate time.Time
+
+func (date jsonDate) MarshalJSON() ([]byte, error) {
+	return []byte(`"` + time.Time(date).Format("2006-01-02") + `"`), nil
+}
+
+func (date *jsonDate) UnmarshalJSON(data []byte) error {
+
+	var text string
+	if err := json.Unmarshal(data, &text); err != nil {
+		return fmt.Errorf("invalid date %s: %v", data, err)
+	}
+
+	parsed, err := parseDate(text)
+	if err != nil {
+		return fmt.Errorf("invalid date %q: %v", text, err)
+	}
+	*date = jsonDate(parsed)
+
+	return nil
+}
+
+// jsonRate is a rate encoded as a decimal string.
+type jsonRate float64
+
+func (rate jsonRate) MarshalJSON() ([]byte, error) {
+	return []byte(`"` + strconv.FormatFloat(float64(rate), 'f', -1, 64) + `"`), nil
+}
+
+func (rate *jsonRate) UnmarshalJSON(data []byte) error {
+
+	text := string(bytes.Trim(data, `"`))
+	value, err := strconv.ParseFloat(text, 64)
+	if err != nil {
+		return fmt.Errorf("invalid rate %s: %v", data, err)
+	}
+	*rate = jsonRate(value)
+
+	return nil
+}
+
+type queryResultJSON struct {
+	Currency string   `json:"currency,omitempty"`
+	Base     string   `json:"base,omitempty"`
+	Date     jsonDate `json:"date"`
+	Rate     jsonRate `json:"rate"`
+	Embedded bool     `json:"embedded,omitempty"`
+	Stale    bool     `json:"stale,omitempty"`
+	Age      string   `json:"age,omitempty"`
+}
+
+// formatAge returns the age of stale rates as "26h3m0s", or an empty
+// string for the fresh ones.
+func formatAge(stale bool, age time.Duration) string {
+
+	if !stale {
+		return ""
+	}
+
+	return age.Round(time.Second).String()
+}
+
+// parseAge is the inverse of formatAge.
+func parseAge(age string) (time.Duration, error) {
+
+	if age == "" {
+		return 0, nil
+	}
+
+	return time.ParseDuration(age)
+}
+
+// MarshalJSON encodes the result as
+// {"currency":"USD","base":"EUR","date":"2024-03-01","rate":"1.0876"},
+// with "embedded":true for the embedded rates, and "stale":true and the
+// "age" of the stale ones.
+func (result QueryResult) MarshalJSON() ([]byte, error) {
+	return json.Marshal(queryResultJSON{
+		result.Currency,
+		result.Base,
+		jsonDate(result.LastUpdate),
+		jsonRate(result.RateValue),
+		result.Embedded,
+		result.Stale,
+		formatAge(result.Stale, result.Age),
+	})
+}
+
+func (result *QueryResult) UnmarshalJSON(data []byte) error {
+
+	var wire queryResultJSON
+	if err := json.Unmarshal(data, &wire); err != nil {
+		return err
+	}
+	result.LastUpdate = time.Time(wire.Date)
+	result.RateValue = float64(wire.Rate)
+	result.Currency = wire.Currency
+	result.Base = wire.Base
+	result.Embedded = wire.Embedded
+	result.Stale = wire.Stale
+	age, err := parseAge(wire.Age)
+	if err != nil {
+		return fmt.Errorf("invalid age %q: %v", wire.Age, err)
+	}
+	result.Age = age
+
+	return nil
+}
+
+type historicalResultJSON struct {
+	Currency  string   `json:"currency,omitempty"`
+	Base      string   `json:"base,omitempty"`
+	Date      jsonDate `json:"date"`
+	Rate      jsonRate `json:"rate"`
+	Requested jsonDate `json:"requested"`
+}
+
+// MarshalJSON encodes the result as a QueryResult with the requested date.
+func (result HistoricalResult) MarshalJSON() ([]byte, error) {
+	return json.Marshal(historicalResultJSON{
+		result.Currency,
+		result.Base,
+		jsonDate(result.LastUpdate),
+		jsonRate(result.RateValue),
+		jsonDate(result.Requested),
+	})
+}
+
+func (result *HistoricalResult) UnmarshalJSON(data []byte) error {
+
+	var wire historicalResultJSON
+	if err := json.Unmarshal(data, &wire); err != nil {
+		return err
+	}
+	result.LastUpdate = time.Time(wire.Date)
+	result.RateValue = float64(wire.Rate)
+	result.Requested = time.Time(wire.Requested)
+	result.Currency = wire.Currency
+	result.Base = wire.Base
+
+	return nil
+}
+
+type pointJSON struct {
+	Date jsonDate `json:"date"`
+	Rate jsonRate `json:"rate"`
+}
+
+// MarshalJSON encodes the point as {"date":"2024-03-01","rate":"1.0876"}.
+func (point Point) MarshalJSON() ([]byte, error) {
+	return json.Marshal(pointJSON{jsonDate(point.Date), jsonRate(point.Rate)})
+}
+
+func (point *Point) UnmarshalJSON(data []byte) error {
+
+	var wire pointJSON
+	if err := json.Unmarshal(data, &wire); err != nil {
+		return err
+	}
+	point.Date = time.Time(wire.Date)
+	point.Rate = float64(wire.Rate)
+
+	return nil
+}
+
+type seriesJSON struct {
+	Currency string  `json:"currency"`
+	Points   []Point `json:"points"`
+}
+
+// MarshalJSON encodes the series as {"currency":"USD","points":[...]}.
+func (series Series) MarshalJSON() ([]byte, error) {
+
+	points := series.Points
+	if points == nil {
+		points = []Point{}
+	}
+
+	return json.Marshal(seriesJSON{series.Currency, points})
+}
+
+func (series *Series) UnmarshalJSON(data []byte) error {
+
+	var wire seriesJSON
+	if err := json.Unmarshal(data, &wire); err != nil {
+		return err
+	}
+	series.Currency = wire.Currency
+	series.Points = wire.Points
+
+	return nil
+}
+
+type rateTableJSON struct {
+	Base     string              `json:"base"`
+	Date     jsonDate            `json:"date"`
+	Rates    map[string]jsonRate `json:"rates"`
+	Embedded bool                `json:"embedded,omitempty"`
+	Stale    bool                `json:"stale,omitempty"`
+	Age      string              `json:"age,omitempty"`
+}
+
+// MarshalJSON encodes the table as
+// {"base":"EUR","date":"2024-03-01","rates":{"USD":"1.0876",...}}, with
+// the flags and the age of the embedded and stale rates as for a
+// QueryResult.
+func (table RateTable) MarshalJSON() ([]byte, error) {
+
+	rates := make(map[string]jsonRate, len(table.Rates))
+	for currency, rate := range table.Rates {
+		rates[currency] = jsonRate(rate)
+	}
+
+	return json.Marshal(rateTableJSON{"EUR", jsonDate(table.Date), rates, table.Embedded,
+		table.Stale, formatAge(table.Stale, table.Age)})
+}
+
+func (table *RateTable) UnmarshalJSON(data []byte) error {
+
+	var wire rateTableJSON
+	if err := json.Unmarshal(data, &wire); err != nil {
+		return err
+	}
+	if wire.Base != "" && wire.Base != "EUR" {
+		return fmt.Errorf("unsupported base currency %q", wire.Base)
+	}
+
+	table.Date = time.Time(wire.Date)
+	table.Embedded = wire.Embedded
+	table.Stale = wire.Stale
+	age, err := parseAge(wire.Age)
+	if err != nil {
+		return fmt.Errorf("invalid age %q: %v", wire.Age, err)
+	}
+	table.Age = age
+	table.Rates = make(map[string]float64, len(wire.Rates))
+	for currency, rate := range wire.Rates {
+		table.Rates[currency] = float64(rate)
+	}
+
+	return nil
+}
+
+type conversionResultJSON struct {
+	From           string       `json:"from"`
+	To             string       `json:"to"`
+	Amount         jsonRate     `json:"amount"`
+	Date           jsonDate     `json:"date"`
+	FromRate       jsonRate     `json:"from_rate"`
+	ToRate         jsonRate     `json:"to_rate"`
+	Rate           jsonRate     `json:"rate"`
+	MarkupPercent  jsonRate     `json:"markup_percent"`
+	MarkupPips     jsonRate     `json:"markup_pips"`
+	EffectiveRate  jsonRate     `json:"effective_rate"`
+	UnroundedValue jsonRate     `json:"unrounded_value"`
+	Rounding       string       `json:"rounding"`
+	RoundingMode   RoundingMode `json:"rounding_mode"`
+	Value          jsonRate     `json:"value"`
+	Embedded       bool         `json:"embedded,omitempty"`
+	Stale          bool         `json:"stale,omitempty"`
+	Age            string       `json:"age,omitempty"`
+}
+
+// MarshalJSON encodes the result with its audit trail, the amounts and
+// rates as decimal strings:
+// {"from":"EUR","to":"USD","amount":"100","date":"2024-03-01",
+// "from_rate":"1","to_rate":"1.0876",...,"rounding":"cash",...}.
+// The rounding is "none", "minor-units" or "cash".
+func (result ConversionResult) MarshalJSON() ([]byte, error) {
+
+	rounding := "none"
+	switch {
+	case result.Options.CashRounding:
+		rounding = "cash"
+	case result.Options.Rounding:
+		rounding = "minor-units"
+	}
+
+	mode := result.Options.RoundingMode
+	if mode == 0 {
+		mode = RoundHalfUp
+	}
+
+	return json.Marshal(conversionResultJSON{
+		From:           result.From,
+		To:             result.To,
+		Amount:         jsonRate(result.Amount),
+		Date:           jsonDate(result.LastUpdate),
+		FromRate:       jsonRate(result.FromRate),
+		ToRate:         jsonRate(result.ToRate),
+		Rate:           jsonRate(result.Rate),
+		MarkupPercent:  jsonRate(result.Options.MarkupPercent),
+		MarkupPips:     jsonRate(result.Options.MarkupPips),
+		EffectiveRate:  jsonRate(result.EffectiveRate),
+		UnroundedValue: jsonRate(result.UnroundedValue),
+		Rounding:       rounding,
+		RoundingMode:   mode,
+		Value:          jsonRate(result.Value),
+		Embedded:       result.Embedded,
+		Stale:          result.Stale,
+		Age:            formatAge(result.Stale, result.Age),
+	})
+}
+
+func (result *ConversionResult) UnmarshalJSON(data []byte) error {
+
+	var wire conversionResultJSON
+	if err := json.Unmarshal(data, &wire); err != nil {
+		return err
+	}
+
+	*result = ConversionResult{
+		From:           wire.From,
+		To:             wire.To,
+		Amount:         float64(wire.Amount),
+		Rate:           float64(wire.Rate),
+		Value:          float64(wire.Value),
+		LastUpdate:     time.Time(wire.Date),
+		EffectiveRate:  float64(wire.EffectiveRate),
+		FromRate:       float64(wire.FromRate),
+		ToRate:         float64(wire.ToRate),
+		UnroundedValue: float64(wire.UnroundedValue),
+		Embedded:       wire.Embedded,
+		Stale:          wire.Stale,
+		Options: ConvertOptions{
+			CashRounding:  wire.Rounding == "cash",
+			Rounding:      wire.Rounding == "minor-units",
+			MarkupPercent: float64(wire.MarkupPercent),
+			MarkupPips:    float64(wire.MarkupPips),
+			RoundingMode:  wire.RoundingMode,
+		},
+	}
+
+	switch wire.Rounding {
+	case "", "none", "cash", "minor-units":
+	default:
+		return fmt.Errorf("unknown rounding %q", wire.Rounding)
+	}
+
+	age, err := parseAge(wire.Age)
+	if err != nil {
+		return fmt.Errorf("invalid age %q: %v", wire.Age, err)
+	}
+	result.Age = age
+
+	return nil
+}
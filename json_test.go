@@ -0,0 +1,104 @@
+//
+// Copyright 2023 The GoEurofxref Authors. All rights reserved.
+// Use of this source code is governed by a MIT License
+// license that can be found in the LICENSE file.
+// Last Modification: 2026-10-14 19:32:21
+//
+
+package eurofxref
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestQueryResultJSON(t *testing.T) {
+
+	date := PublicationDate(2024, 3, 1)
+	data, err := json.Marshal(&QueryResult{LastUpdate: date, RateValue: 0.85578})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != `{"date":"2024-03-01","rate":"0.85578"}` {
+		t.Errorf("unexpected encoding %s", data)
+	}
+
+	var result QueryResult
+	if err := json.Unmarshal([]byte(`{"date":"2024-03-01","rate":0.85578}`), &result); err != nil {
+		t.Fatal(err)
+	}
+	if !result.LastUpdate.Equal(date) || result.RateValue != 0.85578 {
+		t.Errorf("unexpected decoding %+v", result)
+	}
+
+	data, err = json.Marshal(QueryResult{LastUpdate: date, RateValue: 1.0876, Currency: "USD", Base: "EUR"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != `{"currency":"USD","base":"EUR","date":"2024-03-01","rate":"1.0876"}` {
+		t.Errorf("unexpected encoding %s", data)
+	}
+	if err := json.Unmarshal(data, &result); err != nil || result.Currency != "USD" || result.Base != "EUR" {
+		t.Errorf("unexpected decoding %+v, %v", result, err)
+	}
+
+	if err := json.Unmarshal([]byte(`{"date":"01/03/2024","rate":"1"}`), &result); err == nil {
+		t.Error("expected an error for a non ISO date")
+	}
+}
+
+func TestHistoricalResultJSON(t *testing.T) {
+
+	in := HistoricalResult{
+		QueryResult: QueryResult{LastUpdate: PublicationDate(2024, 3, 1), RateValue: 1.0876},
+		Requested:   PublicationDate(2024, 3, 3),
+	}
+	data, err := json.Marshal(in)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != `{"date":"2024-03-01","rate":"1.0876","requested":"2024-03-03"}` {
+		t.Errorf("unexpected encoding %s", data)
+	}
+
+	var out HistoricalResult
+	if err := json.Unmarshal(data, &out); err != nil {
+		t.Fatal(err)
+	}
+	if out != in {
+		t.Errorf("round trip returned %+v, want %+v", out, in)
+	}
+}
+
+func TestSeriesJSON(t *testing.T) {
+
+	_, query := newTestServer(t)
+
+	series, err := query.History("USD")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := json.Marshal(series)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var decoded Series
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if decoded.Currency != "USD" || len(decoded.Points) != len(series.Points) {
+		t.Fatalf("unexpected round trip %s", data)
+	}
+	for i, point := range decoded.Points {
+		if !point.Date.Equal(series.Points[i].Date) || point.Rate != series.Points[i].Rate {
+			t.Errorf("point %d is %+v, want %+v", i, point, series.Points[i])
+		}
+	}
+
+	data, _ = json.Marshal(Series{Currency: "USD"})
+	if string(data) != `{"currency":"USD","points":[]}` {
+		t.Errorf("unexpected empty series %s", data)
+	}
+}
@@ -0,0 +1,70 @@
+//
+// Copyright 2023 The GoEurofxref Authors. All rights reserved.
+// Use of this source code is governed by a MIT License
+// license that can be found in the LICENSE file.
+// Last Modification: 2026-10-14 18:30:30
+//
+
+package eurofxref
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// lockRetryInterval is the wait between two attempts to take a lock held
+// by another process.
+const lockRetryInterval = 50 * time.Millisecond
+
+// lockFile takes the advisory lock of lockPath, shared by the processes
+// using the same cache directory, waiting until it is released or ctx is
+// done. The returned function releases it.
+func lockFile(ctx context.Context, lockPath string) (func(), error) {
+
+	for {
+		unlock, ok, err := tryLockFile(lockPath)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			return unlock, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(lockRetryInterval):
+		}
+	}
+}
+
+// writeFileAtomic writes data to a temporary file renamed to filePath, so
+// that the readers never see a partially written file.
+func writeFileAtomic(filePath string, data []byte) error {
+
+	tmp, err := os.CreateTemp(filepath.Dir(filePath), filepath.Base(filePath)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	_, writeErr := tmp.Write(data)
+	if err := tmp.Close(); writeErr == nil {
+		writeErr = err
+	}
+	if writeErr != nil {
+		return writeErr
+	}
+	if err := os.Chmod(tmp.Name(), 0644); err != nil {
+		return err
+	}
+
+	if err := os.Rename(tmp.Name(), filePath); err != nil {
+		return fmt.Errorf("error renaming %s: %v", tmp.Name(), err)
+	}
+
+	return nil
+}
@@ -0,0 +1,39 @@
+//
+// Copyright 2023 The GoEurofxref Authors. All rights reserved.
+// Use of this source code is governed by a MIT License
+// license that can be found in the LICENSE file.
+// Last Modification: 2026-10-14 18:30:30
+//
+
+//go:build !unix
+
+package eurofxref
+
+import (
+	"errors"
+	"os"
+	"time"
+)
+
+// staleLockAge is the age after which a lock file is assumed to be left
+// by a process that died holding it.
+const staleLockAge = 10 * time.Minute
+
+// tryLockFile creates lockPath exclusively without blocking; the lock is
+// held while the file exists.
+func tryLockFile(lockPath string) (func(), bool, error) {
+
+	f, err := os.OpenFile(lockPath, os.O_RDWR|os.O_CREATE|os.O_EXCL, 0644)
+	if errors.Is(err, os.ErrExist) {
+		if info, err := os.Stat(lockPath); err == nil && time.Since(info.ModTime()) > staleLockAge {
+			os.Remove(lockPath)
+		}
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	f.Close()
+
+	return func() { os.Remove(lockPath) }, true, nil
+}
@@ -0,0 +1,81 @@
+//
+// Copyright 2023 The GoEurofxref Authors. All rights reserved.
+// Use of this source code is governed by a MIT License
+// license that can be found in the LICENSE file.
+// Last Modification: 2026-10-14 18:30:30
+//
+
+package eurofxref
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestLockFile(t *testing.T) {
+
+	lockPath := filepath.Join(t.TempDir(), "eurofxref-daily.xml.lock")
+
+	unlock, err := lockFile(context.Background(), lockPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+	if _, err := lockFile(ctx, lockPath); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("got %v while the lock is held, want context.DeadlineExceeded", err)
+	}
+
+	unlock()
+
+	unlock, err = lockFile(context.Background(), lockPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	unlock()
+}
+
+func TestSharedCacheDownloadsOnce(t *testing.T) {
+
+	var downloads atomic.Int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		downloads.Add(1)
+		time.Sleep(50 * time.Millisecond)
+		http.ServeFile(w, r, "testdata/eurofxref-daily.xml")
+	}))
+	defer ts.Close()
+
+	cacheDir := t.TempDir()
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 8)
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			// independent clients, as separate processes would be
+			query := New(cacheDir, false)
+			query.Url = ts.URL + "/eurofxref-daily.xml"
+			if _, err := query.Daily("USD"); err != nil {
+				errs <- err
+			}
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		t.Error(err)
+	}
+	if got := downloads.Load(); got != 1 {
+		t.Errorf("the file was downloaded %d times, want 1", got)
+	}
+}
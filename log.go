@@ -0,0 +1,95 @@
+//
+// Copyright 2023 The GoEurofxref Authors. All rights reserved.
+// Use of this source code is governed by a MIT License
+// license that can be found in the LICENSE file.
+// Last Modification: 2026-10-14 18:37:15
+//
+
+package eurofxref
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"strings"
+)
+
+// discardHandler is a slog.Handler that drops every record.
+type discardHandler struct{}
+
+func (discardHandler) Enabled(context.Context, slog.Level) bool  { return false }
+func (discardHandler) Handle(context.Context, slog.Record) error { return nil }
+func (h discardHandler) WithAttrs([]slog.Attr) slog.Handler      { return h }
+func (h discardHandler) WithGroup(string) slog.Handler           { return h }
+
+var discardLogger = slog.New(discardHandler{})
+
+// DebugCategory is a set of debug events.
+type DebugCategory uint
+
+const (
+	// DebugRequests are the completed downloads and refreshes.
+	DebugRequests DebugCategory = 1 << iota
+	// DebugCache are the hits, expirations and writes of the cache.
+	DebugCache
+	// DebugParse are the parsed files.
+	DebugParse
+	// DebugContent is the content of every file read, which can be
+	// megabytes for the full history.
+	DebugContent
+
+	// DefaultDebugCategories are the debug events written when
+	// DebugCategories is zero.
+	DefaultDebugCategories = DebugRequests | DebugCache | DebugParse
+)
+
+func (category DebugCategory) String() string {
+
+	names := []string{}
+	for i, name := range []string{"requests", "cache", "parse", "content"} {
+		if category&(1<<i) != 0 {
+			names = append(names, name)
+		}
+	}
+
+	return strings.Join(names, "|")
+}
+
+// logger returns the logger of the fetch, cache and parse events: Logger
+// when set, a debug logger writing text to DebugOutput (the standard
+// output when nil) when the Debug option is enabled, or a logger that
+// discards them.
+func (efr EuroFxRef) logger() *slog.Logger {
+
+	if efr.Logger != nil {
+		return efr.Logger
+	}
+
+	if efr.Debug {
+		output := efr.DebugOutput
+		if output == nil {
+			output = os.Stdout
+		}
+		return slog.New(slog.NewTextHandler(output, &slog.HandlerOptions{
+			Level: slog.LevelDebug,
+		}))
+	}
+
+	return discardLogger
+}
+
+// debug logs a debug event of the category when DebugCategories selects
+// it.
+func (efr EuroFxRef) debug(category DebugCategory, msg string, attrs ...slog.Attr) {
+
+	categories := efr.DebugCategories
+	if categories == 0 {
+		categories = DefaultDebugCategories
+	}
+	if categories&category == 0 {
+		return
+	}
+
+	attrs = append(attrs, slog.String("category", category.String()))
+	efr.logger().LogAttrs(context.Background(), slog.LevelDebug, msg, attrs...)
+}
@@ -0,0 +1,86 @@
+//
+// Copyright 2023 The GoEurofxref Authors. All rights reserved.
+// Use of this source code is governed by a MIT License
+// license that can be found in the LICENSE file.
+// Last Modification: 2026-10-14 18:52:42
+//
+
+package eurofxref
+
+import (
+	"bytes"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+func TestLogger(t *testing.T) {
+
+	_, query := newTestServer(t)
+	query.CacheDir = t.TempDir()
+
+	var logs bytes.Buffer
+	query.Logger = slog.New(slog.NewTextHandler(&logs, &slog.HandlerOptions{
+		Level: slog.LevelDebug,
+	}))
+
+	for i := 0; i < 3; i++ {
+		if i == 2 {
+			// without the parsed rates in memory, the cache is read
+			query.state = &state{}
+		}
+		if _, err := query.Daily("USD"); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	for _, want := range []string{"msg=fetching", "msg=cached", "msg=\"memo hit\"", "msg=\"cache hit\"", "msg=parsed"} {
+		if !strings.Contains(logs.String(), want) {
+			t.Errorf("the logs do not contain %s:\n%s", want, logs.String())
+		}
+	}
+	if strings.Count(logs.String(), "msg=fetching") != 1 {
+		t.Errorf("the second query was not answered from the cache:\n%s", logs.String())
+	}
+
+	query.Url += ".missing"
+	logs.Reset()
+	if _, err := query.Daily("USD"); err == nil {
+		t.Fatal("expected an error for a missing file")
+	}
+	if !strings.Contains(logs.String(), "level=WARN msg=\"fetch failed\"") {
+		t.Errorf("the failure was not logged:\n%s", logs.String())
+	}
+}
+
+func TestDebugOutput(t *testing.T) {
+
+	_, query := newTestServer(t)
+	query.CacheDir = t.TempDir()
+
+	var output bytes.Buffer
+	query.Debug = true
+	query.DebugOutput = &output
+
+	if _, err := query.Daily("USD"); err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"category=requests", "category=cache", "category=parse"} {
+		if !strings.Contains(output.String(), want) {
+			t.Errorf("the output does not contain %s:\n%s", want, output.String())
+		}
+	}
+	if strings.Contains(output.String(), "msg=content") {
+		t.Errorf("the content was written by default:\n%s", output.String())
+	}
+
+	output.Reset()
+	query.state = &state{}
+	query.DebugCategories = DebugContent
+	if _, err := query.Daily("USD"); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(output.String(), "msg=content") || strings.Contains(output.String(), "category=cache") {
+		t.Errorf("unexpected output for the content only:\n%s", output.String())
+	}
+}
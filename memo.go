@@ -0,0 +1,107 @@
+//
+// Copyright 2023 The GoEurofxref Authors. All rights reserved.
+// Use of this source code is governed by a MIT License
+// license that can be found in the LICENSE file.
+// Last Modification: 2026-10-15 13:00:00
+//
+
+package eurofxref
+
+import (
+	"io/fs"
+	"time"
+)
+
+// memoEntry is a parsed daily file kept in memory.
+type memoEntry struct {
+	table *RateTable
+	until time.Time // valid until then
+}
+
+// memoKey identifies the daily file read by the client.
+func (efr EuroFxRef) memoKey() string {
+
+	if efr.UseZip {
+		return efr.CacheDir + "\x00" + efr.ZipUrl
+	}
+
+	return efr.CacheDir + "\x00" + efr.Url
+}
+
+// dailyCacheExpired reports whether the cached daily file is missing or
+// was not downloaded today, as fetch decides.
+func (efr EuroFxRef) dailyCacheExpired() bool {
+
+	fileUrl := efr.Url
+	if efr.UseZip {
+		fileUrl = efr.ZipUrl
+	}
+	fileStat, err := fs.Stat(efr.cacheFS(), cacheName(fileUrl))
+	if err != nil {
+		return true
+	}
+
+	return !sameLocalDay(fileStat.ModTime(), efr.Now()) || fileStat.Size() == 0
+}
+
+// memoUntil returns when the rates loaded at now must be read again: at
+// the next publication, or earlier when the cached file expires at the
+// end of the local day.
+func memoUntil(now time.Time) time.Time {
+
+	local := now.Local()
+	midnight := time.Date(local.Year(), local.Month(), local.Day()+1, 0, 0, 0, 0, time.Local)
+	if next := NextPublicationTime(now); next.Before(midnight) {
+		return next
+	}
+
+	return midnight
+}
+
+// memoized returns a copy of the table parsed for key, if it is still
+// valid.
+func (s *state) memoized(key string, now time.Time) (*RateTable, bool) {
+
+	if s == nil {
+		return nil, false
+	}
+
+	s.memoMu.Lock()
+	defer s.memoMu.Unlock()
+
+	entry, ok := s.memo[key]
+	if !ok || !now.Before(entry.until) {
+		return nil, false
+	}
+
+	return entry.table.clone(), true
+}
+
+// memoize keeps a copy of the table parsed for key.
+func (s *state) memoize(key string, table *RateTable, now time.Time) {
+
+	if s == nil {
+		return
+	}
+
+	s.memoMu.Lock()
+	defer s.memoMu.Unlock()
+
+	if s.memo == nil {
+		s.memo = map[string]memoEntry{}
+	}
+	s.memo[key] = memoEntry{table: table.clone(), until: memoUntil(now)}
+}
+
+// forget drops the table parsed for key.
+func (s *state) forget(key string) {
+
+	if s == nil {
+		return
+	}
+
+	s.memoMu.Lock()
+	defer s.memoMu.Unlock()
+
+	delete(s.memo, key)
+}
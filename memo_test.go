@@ -0,0 +1,75 @@
+//
+// Copyright 2023 The GoEurofxref Authors. All rights reserved.
+// Use of this source code is governed by a MIT License
+// license that can be found in the LICENSE file.
+// Last Modification: 2026-10-14 18:52:42
+//
+
+package eurofxref
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestMemoizedDailyRates(t *testing.T) {
+
+	_, query := newTestServer(t)
+	query.CacheDir = t.TempDir()
+
+	if _, err := query.Daily("USD"); err != nil {
+		t.Fatal(err)
+	}
+
+	// the second query neither reads the cache nor parses the file again
+	files, err := filepath.Glob(filepath.Join(query.CacheDir, "*.xml"))
+	if err != nil || len(files) != 1 {
+		t.Fatalf("got cached files %v (%v), want one", files, err)
+	}
+	if err := os.WriteFile(files[0], []byte("not xml"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := query.Daily("USD")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.RateValue != 1.0876 {
+		t.Errorf("got %v, want 1.0876", result.RateValue)
+	}
+
+	// the copies returned do not share the memoized rates
+	table, err := query.DailyRates()
+	if err != nil {
+		t.Fatal(err)
+	}
+	table.Rates["USD"] = 0
+	if result, err := query.Daily("USD"); err != nil || result.RateValue != 1.0876 {
+		t.Errorf("got %v (%v), want 1.0876", result, err)
+	}
+
+	// without a cache directory the server is asked every time
+	query.CacheDir = ""
+	query.Url += ".missing"
+	if _, err := query.Daily("USD"); err == nil {
+		t.Error("expected an error without a cache directory")
+	}
+}
+
+func TestMemoUntil(t *testing.T) {
+
+	// on a Friday after the publication, the cache expires at midnight
+	friday := time.Date(2024, 3, 1, 17, 0, 0, 0, CET)
+	until := memoUntil(friday)
+	if !until.After(friday) || until.Sub(friday) > 24*time.Hour {
+		t.Errorf("got %v, want before the next day", until)
+	}
+
+	// before the publication, the rates are read again when it is out
+	morning := time.Date(2024, 3, 1, 9, 0, 0, 0, CET)
+	if next := NextPublicationTime(morning); memoUntil(morning).After(next) {
+		t.Errorf("got %v, want at most %v", memoUntil(morning), next)
+	}
+}
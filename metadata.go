@@ -0,0 +1,74 @@
+//
+// Copyright 2023 The GoEurofxref Authors. All rights reserved.
+// Use of this source code is governed by a MIT License
+// license that can be found in the LICENSE file.
+// Last Modification: 2026-10-14 19:27:43
+//
+
+package eurofxref
+
+import (
+	"fmt"
+	"strings"
+)
+
+// CurrencyMetadata is the ISO 4217 description of a currency.
+type CurrencyMetadata struct {
+	Code       string // alphabetic code, e.g. "USD"
+	Numeric    string // three digit numeric code, e.g. "840"
+	Name       string
+	MinorUnits int // number of decimals
+	Symbol     string
+}
+
+// String returns the label of the currency, e.g.
+// "USD — US Dollar, 2 decimals, $".
+func (m CurrencyMetadata) String() string {
+	return fmt.Sprintf("%s — %s, %d decimals, %s", m.Code, m.Name, m.MinorUnits, m.Symbol)
+}
+
+var currencyMetadata = map[string]CurrencyMetadata{
+	"EUR": {"EUR", "978", "Euro", 2, "€"},
+	"USD": {"USD", "840", "US Dollar", 2, "$"},
+	"JPY": {"JPY", "392", "Japanese Yen", 0, "¥"},
+	"BGN": {"BGN", "975", "Bulgarian Lev", 2, "лв"},
+	"CZK": {"CZK", "203", "Czech Koruna", 2, "Kč"},
+	"DKK": {"DKK", "208", "Danish Krone", 2, "kr"},
+	"GBP": {"GBP", "826", "Pound Sterling", 2, "£"},
+	"HUF": {"HUF", "348", "Hungarian Forint", 2, "Ft"},
+	"PLN": {"PLN", "985", "Polish Zloty", 2, "zł"},
+	"RON": {"RON", "946", "Romanian Leu", 2, "lei"},
+	"SEK": {"SEK", "752", "Swedish Krona", 2, "kr"},
+	"CHF": {"CHF", "756", "Swiss Franc", 2, "CHF"},
+	"ISK": {"ISK", "352", "Iceland Krona", 0, "kr"},
+	"NOK": {"NOK", "578", "Norwegian Krone", 2, "kr"},
+	"TRY": {"TRY", "949", "Turkish Lira", 2, "₺"},
+	"AUD": {"AUD", "036", "Australian Dollar", 2, "A$"},
+	"BRL": {"BRL", "986", "Brazilian Real", 2, "R$"},
+	"CAD": {"CAD", "124", "Canadian Dollar", 2, "C$"},
+	"CNY": {"CNY", "156", "Yuan Renminbi", 2, "¥"},
+	"HKD": {"HKD", "344", "Hong Kong Dollar", 2, "HK$"},
+	"IDR": {"IDR", "360", "Indonesian Rupiah", 2, "Rp"},
+	"ILS": {"ILS", "376", "New Israeli Sheqel", 2, "₪"},
+	"INR": {"INR", "356", "Indian Rupee", 2, "₹"},
+	"KRW": {"KRW", "410", "South Korean Won", 0, "₩"},
+	"MXN": {"MXN", "484", "Mexican Peso", 2, "Mex$"},
+	"MYR": {"MYR", "458", "Malaysian Ringgit", 2, "RM"},
+	"NZD": {"NZD", "554", "New Zealand Dollar", 2, "NZ$"},
+	"PHP": {"PHP", "608", "Philippine Peso", 2, "₱"},
+	"SGD": {"SGD", "702", "Singapore Dollar", 2, "S$"},
+	"THB": {"THB", "764", "Thai Baht", 2, "฿"},
+	"ZAR": {"ZAR", "710", "South African Rand", 2, "R"},
+}
+
+// CurrencyInfo returns the ISO 4217 metadata of a currency.
+func CurrencyInfo(currencyCode string) (CurrencyMetadata, error) {
+
+	metadata, ok := currencyMetadata[strings.ToUpper(currencyCode)]
+	if !ok {
+		return CurrencyMetadata{}, fmt.Errorf("no metadata for the currency code \"%s\"",
+			currencyCode)
+	}
+
+	return metadata, nil
+}
@@ -0,0 +1,36 @@
+//
+// Copyright 2023 The GoEurofxref Authors. All rights reserved.
+// Use of this source code is governed by a MIT License
+// license that can be found in the LICENSE file.
+// Last Modification: 2026-10-14 19:23:24
+//
+
+package eurofxref
+
+import "testing"
+
+func TestCurrencyInfo(t *testing.T) {
+
+	info, err := CurrencyInfo("usd")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := info.String(), "USD — US Dollar, 2 decimals, $"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+
+	if info, _ := CurrencyInfo("JPY"); info.MinorUnits != 0 || info.Numeric != "392" {
+		t.Errorf("unexpected JPY metadata %+v", info)
+	}
+
+	// every currency of the reference list has metadata
+	for _, currencyCode := range New("", false).SupportedCurrencies() {
+		if _, err := CurrencyInfo(currencyCode); err != nil {
+			t.Error(err)
+		}
+	}
+
+	if _, err := CurrencyInfo("XXX"); err == nil {
+		t.Error("expected an error for an unknown currency")
+	}
+}
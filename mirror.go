@@ -0,0 +1,235 @@
+//
+// Copyright 2023 The GoEurofxref Authors. All rights reserved.
+// Use of this source code is governed by a MIT License
+// license that can be found in the LICENSE file.
+// Last Modification: 2026-10-15 11:10:00
+//
+
+package eurofxref
+
+import (
+	"context"
+	"log/slog"
+	"net/url"
+	"path"
+	"strings"
+	"time"
+)
+
+// The backoff of a failing mirror doubles from mirrorBackoffMin with each
+// consecutive failure, up to mirrorBackoffMax.
+const (
+	mirrorBackoffMin = 30 * time.Second
+	mirrorBackoffMax = 10 * time.Minute
+)
+
+// mirrorHealth tracks the consecutive failures of a mirror.
+type mirrorHealth struct {
+	failures int
+	until    time.Time // skipped until then
+}
+
+// mirrorUrls returns the URLs of the file at fileUrl to try in order: the
+// URL itself, then the same file name under each of the Mirrors.
+func (efr EuroFxRef) mirrorUrls(fileUrl string) []string {
+
+	urls := []string{fileUrl}
+	if len(efr.Mirrors) == 0 {
+		return urls
+	}
+
+	u, err := url.Parse(fileUrl)
+	if err != nil {
+		return urls
+	}
+	filename := path.Base(u.Path)
+	for _, mirror := range efr.Mirrors {
+		urls = append(urls, strings.TrimSuffix(mirror, "/")+"/"+filename)
+	}
+
+	return urls
+}
+
+// getMirrored downloads the file at fileUrl, failing over to the mirrors
+// when it fails. The URLs in backoff are skipped unless all of them are.
+// The error of the last attempt is returned when every URL failed. As
+// with getConditional, the content is nil when it is unchanged since the
+// previous validators.
+func (efr EuroFxRef) getMirrored(ctx context.Context, fileUrl string,
+	previous *validators) ([]byte, *validators, error) {
+
+	urls := efr.mirrorUrls(fileUrl)
+	if len(urls) == 1 {
+		return efr.getConditional(ctx, fileUrl, previous)
+	}
+
+	now := efr.Now()
+	candidates := []string{}
+	for _, u := range urls {
+		if efr.state.mirrorAvailable(u, now) {
+			candidates = append(candidates, u)
+		}
+	}
+	if len(candidates) == 0 {
+		candidates = urls
+	}
+	if efr.HedgeDelay > 0 && len(candidates) > 1 {
+		return efr.getHedged(ctx, candidates, previous)
+	}
+
+	var lastErr error
+	for _, u := range candidates {
+		contentBytes, current, err := efr.getConditional(ctx, u, previous)
+		if err == nil {
+			efr.state.mirrorSucceeded(u)
+			return contentBytes, current, nil
+		}
+		lastErr = err
+		// cancelled by the caller, not a failure of the mirror
+		if ctx.Err() != nil {
+			break
+		}
+
+		backoff := efr.state.mirrorFailed(u, efr.Now())
+		efr.logger().Warn("mirror failed",
+			slog.String("url", u),
+			slog.Duration("backoff", backoff),
+			slog.Any("error", err))
+	}
+
+	return nil, nil, lastErr
+}
+
+// getHedged is getMirrored with HedgeDelay: it requests the first of
+// urls, then the next one each time HedgeDelay passes without an answer
+// or an attempt fails, and returns the first content downloaded, the
+// other requests being cancelled.
+func (efr EuroFxRef) getHedged(ctx context.Context, urls []string,
+	previous *validators) ([]byte, *validators, error) {
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	type attempt struct {
+		url          string
+		contentBytes []byte
+		current      *validators
+		err          error
+	}
+	results := make(chan attempt, len(urls))
+
+	timer := time.NewTimer(efr.HedgeDelay)
+	defer timer.Stop()
+
+	next, pending := 0, 0
+	request := func() {
+		u := urls[next]
+		next++
+		pending++
+		go func() {
+			contentBytes, current, err := efr.getConditional(ctx, u, previous)
+			results <- attempt{url: u, contentBytes: contentBytes, current: current, err: err}
+		}()
+		timer.Reset(efr.HedgeDelay)
+	}
+	request()
+
+	var lastErr error
+	for pending > 0 {
+		select {
+		case <-timer.C:
+			if next < len(urls) {
+				efr.debug(DebugRequests, "hedged request", slog.String("url", urls[next]))
+				request()
+			}
+		case result := <-results:
+			pending--
+			if result.err == nil {
+				efr.state.mirrorSucceeded(result.url)
+				return result.contentBytes, result.current, nil
+			}
+			lastErr = result.err
+			if ctx.Err() != nil {
+				return nil, nil, lastErr
+			}
+
+			backoff := efr.state.mirrorFailed(result.url, efr.Now())
+			efr.logger().Warn("mirror failed",
+				slog.String("url", result.url),
+				slog.Duration("backoff", backoff),
+				slog.Any("error", result.err))
+
+			if next < len(urls) {
+				request()
+			}
+		}
+	}
+
+	return nil, nil, lastErr
+}
+
+// mirrorKey identifies a mirror by the host serving it.
+func mirrorKey(fileUrl string) string {
+
+	if u, err := url.Parse(fileUrl); err == nil && u.Host != "" {
+		return u.Host
+	}
+
+	return fileUrl
+}
+
+func (s *state) mirrorAvailable(fileUrl string, now time.Time) bool {
+
+	if s == nil {
+		return true
+	}
+
+	s.mirrorMu.Lock()
+	defer s.mirrorMu.Unlock()
+
+	health, ok := s.mirrors[mirrorKey(fileUrl)]
+
+	return !ok || !now.Before(health.until)
+}
+
+// mirrorFailed records a failure and returns the backoff of the mirror.
+func (s *state) mirrorFailed(fileUrl string, now time.Time) time.Duration {
+
+	if s == nil {
+		return 0
+	}
+
+	s.mirrorMu.Lock()
+	defer s.mirrorMu.Unlock()
+
+	if s.mirrors == nil {
+		s.mirrors = map[string]*mirrorHealth{}
+	}
+	key := mirrorKey(fileUrl)
+	health, ok := s.mirrors[key]
+	if !ok {
+		health = &mirrorHealth{}
+		s.mirrors[key] = health
+	}
+
+	health.failures++
+	backoff := mirrorBackoffMax
+	if health.failures <= 5 {
+		backoff = min(mirrorBackoffMin<<(health.failures-1), mirrorBackoffMax)
+	}
+	health.until = now.Add(backoff)
+
+	return backoff
+}
+
+func (s *state) mirrorSucceeded(fileUrl string) {
+
+	if s == nil {
+		return
+	}
+
+	s.mirrorMu.Lock()
+	defer s.mirrorMu.Unlock()
+
+	delete(s.mirrors, mirrorKey(fileUrl))
+}
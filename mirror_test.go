@@ -0,0 +1,146 @@
+//
+// Copyright 2023 The GoEurofxref Authors. All rights reserved.
+// Use of this source code is governed by a MIT License
+// license that can be found in the LICENSE file.
+// Last Modification: 2026-10-15 11:10:00
+//
+
+package eurofxref
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestMirrorFailover(t *testing.T) {
+
+	var primaryHits atomic.Int32
+	primary := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		primaryHits.Add(1)
+		http.Error(w, "maintenance", http.StatusServiceUnavailable)
+	}))
+	defer primary.Close()
+
+	mirror := httptest.NewServer(http.StripPrefix("/ecb", http.FileServer(http.Dir("testdata"))))
+	defer mirror.Close()
+
+	query := New("", false)
+	query.CacheDir = ""
+	query.Url = primary.URL + "/stats/eurofxref/eurofxref-daily.xml"
+	query.Mirrors = []string{"http://127.0.0.1:1/unreachable", mirror.URL + "/ecb/"}
+
+	for i := 0; i < 2; i++ {
+		result, err := query.Daily("USD")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if result.RateValue != 1.0876 {
+			t.Errorf("got %v from the mirror, want 1.0876", result.RateValue)
+		}
+	}
+
+	if got := primaryHits.Load(); got != 1 {
+		t.Errorf("the primary was requested %d times, want 1 while in backoff", got)
+	}
+
+	query.Mirrors = nil
+	if _, err := query.Daily("USD"); err == nil {
+		t.Error("expected the error of the primary without mirrors")
+	}
+}
+
+func TestHedgedRequests(t *testing.T) {
+
+	release := make(chan struct{})
+	defer close(release)
+	slow := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+	}))
+	defer slow.Close()
+
+	mirror := httptest.NewServer(http.StripPrefix("/ecb", http.FileServer(http.Dir("testdata"))))
+	defer mirror.Close()
+
+	query := New("", false)
+	query.CacheDir = ""
+	query.Timeout = 0
+	query.Url = slow.URL + "/stats/eurofxref/eurofxref-daily.xml"
+	query.Mirrors = []string{mirror.URL + "/ecb/"}
+	query.HedgeDelay = 20 * time.Millisecond
+
+	start := time.Now()
+	result, err := query.Daily("USD")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.RateValue != 1.0876 {
+		t.Errorf("got %v from the mirror, want 1.0876", result.RateValue)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("the hedged request took %v", elapsed)
+	}
+
+	// the cancelled request of the slow endpoint is not a failure
+	if !query.state.mirrorAvailable(query.Url, time.Now()) {
+		t.Error("the slow endpoint was put in backoff")
+	}
+}
+
+func TestMirrorBackoff(t *testing.T) {
+
+	s := &state{}
+	now := time.Now()
+	fileUrl := "https://mirror.example.com/eurofxref-daily.xml"
+
+	want := []time.Duration{30 * time.Second, time.Minute, 2 * time.Minute, 4 * time.Minute,
+		8 * time.Minute, 10 * time.Minute, 10 * time.Minute}
+	for i, backoff := range want {
+		if got := s.mirrorFailed(fileUrl, now); got != backoff {
+			t.Errorf("failure %d: got backoff %v, want %v", i+1, got, backoff)
+		}
+	}
+
+	if s.mirrorAvailable(fileUrl, now.Add(9*time.Minute)) {
+		t.Error("the mirror should be in backoff")
+	}
+	if !s.mirrorAvailable(fileUrl, now.Add(10*time.Minute)) {
+		t.Error("the mirror should be available after its backoff")
+	}
+
+	s.mirrorSucceeded(fileUrl)
+	if got := s.mirrorFailed(fileUrl, now); got != 30*time.Second {
+		t.Errorf("a success should reset the backoff, got %v", got)
+	}
+}
+
+func TestMirrorCancelled(t *testing.T) {
+
+	ctx, cancel := context.WithCancel(context.Background())
+	primary := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		cancel()
+		<-r.Context().Done()
+	}))
+	defer primary.Close()
+
+	mirror := httptest.NewServer(http.StripPrefix("/ecb", http.FileServer(http.Dir("testdata"))))
+	defer mirror.Close()
+
+	query := New("", false)
+	query.CacheDir = ""
+	query.Url = primary.URL + "/stats/eurofxref/eurofxref-daily.xml"
+	query.Mirrors = []string{mirror.URL + "/ecb/"}
+
+	if _, err := query.DailyRatesContext(ctx); err == nil {
+		t.Fatal("expected the error of the cancelled request")
+	}
+	if !query.state.mirrorAvailable(query.Url, time.Now()) {
+		t.Error("the cancellation was counted as a failure of the endpoint")
+	}
+}
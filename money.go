@@ -0,0 +1,60 @@
+//
+// Copyright 2023 The GoEurofxref Authors. All rights reserved.
+// Use of this source code is governed by a MIT License
+// license that can be found in the LICENSE file.
+// Last Modification: 2026-10-14 18:44:28
+//
+
+package eurofxref
+
+import (
+	"context"
+	"math"
+
+	"github.com/Rhymond/go-money"
+)
+
+// ConvertMoney converts the amount of m into the to currency with the
+// rates of the latest publication, for the code handling amounts with
+// github.com/Rhymond/go-money. The result is rounded to the minor units
+// of to, as go-money defines them, with the rounding mode of the options
+// or of the client.
+func (efr EuroFxRef) ConvertMoney(m *money.Money, to string, options ...ConvertOptions) (*money.Money, error) {
+	return efr.ConvertMoneyContext(context.Background(), m, to, options...)
+}
+
+// ConvertMoneyContext is like ConvertMoney, with the requests bound to ctx.
+func (efr EuroFxRef) ConvertMoneyContext(ctx context.Context, m *money.Money, to string,
+	options ...ConvertOptions) (*money.Money, error) {
+
+	opts := ConvertOptions{}
+	if len(options) == 1 {
+		opts = options[0]
+	}
+	// rounded once, to the units of go-money
+	opts.Rounding = false
+	if opts.RoundingMode == 0 {
+		opts.RoundingMode = efr.RoundingMode
+	}
+
+	result, err := efr.ConvertContext(ctx, m.AsMajorUnits(), m.Currency().Code, to, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return result.moneyValue(opts.RoundingMode), nil
+}
+
+// Money returns the converted value as a go-money amount, rounded half up
+// to the minor units of the target currency.
+func (result *ConversionResult) Money() *money.Money {
+	return result.moneyValue(RoundHalfUp)
+}
+
+func (result *ConversionResult) moneyValue(mode RoundingMode) *money.Money {
+
+	fraction := money.New(0, result.To).Currency().Fraction
+	value := roundTo(result.Value, math.Pow10(-fraction), fraction, mode)
+
+	return money.New(int64(math.Round(value*math.Pow10(fraction))), result.To)
+}
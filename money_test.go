@@ -0,0 +1,57 @@
+//
+// Copyright 2023 The GoEurofxref Authors. All rights reserved.
+// Use of this source code is governed by a MIT License
+// license that can be found in the LICENSE file.
+// Last Modification: 2026-10-14 18:44:28
+//
+
+package eurofxref
+
+import (
+	"testing"
+
+	"github.com/Rhymond/go-money"
+)
+
+func TestConvertMoney(t *testing.T) {
+
+	_, query := newTestServer(t)
+
+	tests := []struct {
+		amount int64
+		from   string
+		to     string
+		mode   RoundingMode
+		want   int64
+	}{
+		{10000, "EUR", "USD", 0, 10876},       // 100.00 EUR are 108.76 USD
+		{10000, "USD", "EUR", 0, 9195},        // 91.945568...
+		{1000, "EUR", "JPY", 0, 1625},         // 1625.3 JPY, without minor units
+		{25, "EUR", "USD", RoundTruncate, 27}, // 0.2719 USD
+		{25, "EUR", "USD", RoundHalfUp, 27},
+	}
+
+	for _, tt := range tests {
+		converted, err := query.ConvertMoney(money.New(tt.amount, tt.from), tt.to,
+			ConvertOptions{RoundingMode: tt.mode})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if converted.Amount() != tt.want || converted.Currency().Code != tt.to {
+			t.Errorf("ConvertMoney(%d %s, %s) = %d %s, want %d", tt.amount, tt.from, tt.to,
+				converted.Amount(), converted.Currency().Code, tt.want)
+		}
+	}
+
+	if _, err := query.ConvertMoney(money.New(100, "EUR"), "XXX"); err == nil {
+		t.Error("expected an error for an unknown currency")
+	}
+
+	result, err := query.Convert(100, "EUR", "GBP")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m := result.Money(); m.Amount() != 8558 || m.Currency().Code != "GBP" {
+		t.Errorf("got %s from a conversion of 85.578 GBP", m.Display())
+	}
+}
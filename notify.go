@@ -0,0 +1,164 @@
+//
+// Copyright 2023 The GoEurofxref Authors. All rights reserved.
+// Use of this source code is governed by a MIT License
+// license that can be found in the LICENSE file.
+// Last Modification: 2026-10-14 19:38:01
+//
+
+package eurofxref
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// EventKind is the kind of an Event.
+type EventKind int
+
+const (
+	// EventPublication is a new publication with changed rates.
+	EventPublication EventKind = iota + 1
+	// EventAlert is a new publication breaking alert rules.
+	EventAlert
+)
+
+func (kind EventKind) String() string {
+
+	switch kind {
+	case EventPublication:
+		return "publication"
+	case EventAlert:
+		return "alert"
+	}
+
+	return fmt.Sprintf("EventKind(%d)", int(kind))
+}
+
+// Alert is an alert rule broken by the rate of a publication.
+type Alert struct {
+	Rule AlertRule
+	Rate float64
+	Date time.Time
+}
+
+// String describes the alert as "USD above 1.1".
+func (alert Alert) String() string {
+
+	currency := strings.ToUpper(alert.Rule.Currency)
+	if alert.Rule.Above != 0 && alert.Rate > alert.Rule.Above {
+		return fmt.Sprintf("%s above %s", currency, strconv.FormatFloat(alert.Rule.Above, 'f', -1, 64))
+	}
+
+	return fmt.Sprintf("%s below %s", currency, strconv.FormatFloat(alert.Rule.Below, 'f', -1, 64))
+}
+
+// CheckAlerts returns the alerts of the rules broken by the rates of the
+// table.
+func CheckAlerts(rules []AlertRule, table *RateTable) []Alert {
+
+	alerts := []Alert{}
+	for _, rule := range rules {
+		rate, ok := table.Get(rule.Currency)
+		result := &QueryResult{Currency: strings.ToUpper(rule.Currency), RateValue: rate}
+		if ok && rule.Triggered(result) {
+			alerts = append(alerts, Alert{Rule: rule, Rate: rate, Date: table.Date})
+		}
+	}
+
+	return alerts
+}
+
+// Event is notified to the Notifiers: a new publication, and the alerts
+// it triggered for an EventAlert.
+type Event struct {
+	Kind        EventKind
+	Publication Publication
+	Alerts      []Alert
+}
+
+// Notifier is notified of the events of a client, e.g. to post them to a
+// chat, a pager or a message queue. Notify should return once the event
+// is delivered, or has failed for good.
+type Notifier interface {
+	Notify(ctx context.Context, event Event) error
+}
+
+// NotifierFunc adapts a function to a Notifier.
+type NotifierFunc func(ctx context.Context, event Event) error
+
+func (fn NotifierFunc) Notify(ctx context.Context, event Event) error {
+	return fn(ctx, event)
+}
+
+// LogNotifier writes the events to Logger, slog.Default() when nil.
+type LogNotifier struct {
+	Logger *slog.Logger
+}
+
+func (notifier LogNotifier) Notify(ctx context.Context, event Event) error {
+
+	logger := notifier.Logger
+	if logger == nil {
+		logger = slog.Default()
+	}
+
+	attrs := []slog.Attr{
+		slog.String("event", event.Kind.String()),
+		slog.String("publication", event.Publication.Date.Format("2006-01-02")),
+		slog.Any("changed", event.Publication.Changed),
+	}
+	level := slog.LevelInfo
+	if event.Kind == EventAlert {
+		level = slog.LevelWarn
+		alerts := make([]string, 0, len(event.Alerts))
+		for _, alert := range event.Alerts {
+			alerts = append(alerts, alert.String())
+		}
+		attrs = append(attrs, slog.Any("alerts", alerts))
+	}
+	logger.LogAttrs(ctx, level, "eurofxref "+event.Kind.String(), attrs...)
+
+	return nil
+}
+
+// Notify notifies the notifiers of every new publication detected by the
+// client, as Subscribe delivers them, and of the alerts of the rules it
+// triggers, until ctx is done. The notifiers are called concurrently and
+// their errors logged. It returns once subscribed, so that it can be
+// called before Start.
+func (efr EuroFxRef) Notify(ctx context.Context, rules []AlertRule, notifiers ...Notifier) error {
+
+	if efr.state == nil {
+		return errors.New("the client must be created with New to notify the events")
+	}
+
+	publications := efr.Subscribe(ctx)
+	go func() {
+		for publication := range publications {
+			events := []Event{{Kind: EventPublication, Publication: publication}}
+			table := &RateTable{Date: publication.Date, Rates: publication.Rates}
+			if alerts := CheckAlerts(rules, table); len(alerts) > 0 {
+				events = append(events, Event{Kind: EventAlert, Publication: publication, Alerts: alerts})
+			}
+
+			for _, notifier := range notifiers {
+				go func(notifier Notifier) {
+					for _, event := range events {
+						if err := notifier.Notify(ctx, event); err != nil {
+							efr.logger().Error("notification failed", slog.String("event", event.Kind.String()),
+								slog.String("publication", publication.Date.Format("2006-01-02")),
+								slog.Any("error", err))
+						}
+					}
+				}(notifier)
+			}
+		}
+	}()
+
+	return nil
+}
@@ -0,0 +1,123 @@
+//
+// Copyright 2023 The GoEurofxref Authors. All rights reserved.
+// Use of this source code is governed by a MIT License
+// license that can be found in the LICENSE file.
+// Last Modification: 2026-10-14 19:38:01
+//
+
+package eurofxref
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestCheckAlerts(t *testing.T) {
+
+	table := &RateTable{Date: PublicationDate(2024, 3, 1), Rates: map[string]float64{"USD": 1.0876, "GBP": 0.85578}}
+	rules := []AlertRule{
+		{Currency: "usd", Above: 1.08},
+		{Currency: "GBP", Below: 0.85},
+		{Currency: "JPY", Above: 150},
+	}
+
+	alerts := CheckAlerts(rules, table)
+	if len(alerts) != 1 || alerts[0].String() != "USD above 1.08" || !alerts[0].Date.Equal(table.Date) {
+		t.Errorf("got the alerts %v, want USD above 1.08", alerts)
+	}
+	if got := (Alert{Rule: AlertRule{Currency: "GBP", Below: 0.86}, Rate: 0.85578}).String(); got != "GBP below 0.86" {
+		t.Errorf("got %q", got)
+	}
+}
+
+func TestNotify(t *testing.T) {
+
+	daily := `<gesmes:Envelope xmlns:gesmes="http://www.gesmes.org/xml/2002-08-01" xmlns="http://www.ecb.int/vocabulary/2002-08-01/eurofxref">
+	<Cube><Cube time='DATE'><Cube currency='USD' rate='RATE'/></Cube></Cube>
+</gesmes:Envelope>`
+	var publication atomic.Value
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(publication.Load().(string)))
+	}))
+	defer ts.Close()
+
+	query := New("", false)
+	query.CacheDir = ""
+	query.Url = ts.URL
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	events := make(chan Event, 2)
+	logged := &syncBuffer{}
+	notifiers := []Notifier{
+		NotifierFunc(func(ctx context.Context, event Event) error {
+			events <- event
+			return nil
+		}),
+		LogNotifier{Logger: slog.New(slog.NewTextHandler(logged, nil))},
+	}
+	if err := query.Notify(ctx, []AlertRule{{Currency: "USD", Above: 1.085}}, notifiers...); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, rates := range [][2]string{{"2024-02-29", "1.0796"}, {"2024-03-01", "1.0876"}} {
+		publication.Store(strings.NewReplacer("DATE", rates[0], "RATE", rates[1]).Replace(daily))
+		if _, err := query.DailyRates(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	for _, want := range []EventKind{EventPublication, EventAlert} {
+		select {
+		case event := <-events:
+			if event.Kind != want || event.Publication.Date.Format("2006-01-02") != "2024-03-01" {
+				t.Errorf("got the %v event of %v, want a %v event", event.Kind, event.Publication.Date, want)
+			}
+			if want == EventAlert && (len(event.Alerts) != 1 || event.Alerts[0].String() != "USD above 1.085") {
+				t.Errorf("got the alerts %v", event.Alerts)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatalf("the %v event was not notified", want)
+		}
+	}
+
+	// the log notifier runs concurrently
+	deadline := time.Now().Add(5 * time.Second)
+	for !strings.Contains(logged.String(), "USD above 1.085") && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if !strings.Contains(logged.String(), "level=WARN") {
+		t.Errorf("unexpected log %q", logged.String())
+	}
+}
+
+// syncBuffer is a bytes.Buffer safe for concurrent use.
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.buf.String()
+}
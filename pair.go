@@ -0,0 +1,90 @@
+//
+// Copyright 2023 The GoEurofxref Authors. All rights reserved.
+// Use of this source code is governed by a MIT License
+// license that can be found in the LICENSE file.
+// Last Modification: 2026-10-15 13:45:00
+//
+
+package eurofxref
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Pair is a currency pair, quoted as units of Quote per unit of Base:
+// USD/GBP is the price of one US dollar in pounds.
+type Pair struct {
+	Base  Currency
+	Quote Currency
+}
+
+// ParsePair parses a pair written as "USD/GBP", in any case.
+func ParsePair(s string) (Pair, error) {
+
+	base, quote, ok := strings.Cut(strings.TrimSpace(s), "/")
+	pair := Pair{
+		Base:  Currency(strings.ToUpper(strings.TrimSpace(base))),
+		Quote: Currency(strings.ToUpper(strings.TrimSpace(quote))),
+	}
+	if !ok || !isCurrencyCode(pair.Base.String()) || !isCurrencyCode(pair.Quote.String()) {
+		return Pair{}, fmt.Errorf("invalid currency pair \"%s\": want BASE/QUOTE, e.g. USD/GBP", s)
+	}
+
+	return pair, nil
+}
+
+// isCurrencyCode reports whether s has the form of a currency code,
+// three upper case letters.
+func isCurrencyCode(s string) bool {
+
+	if len(s) != 3 {
+		return false
+	}
+	for _, c := range s {
+		if c < 'A' || c > 'Z' {
+			return false
+		}
+	}
+
+	return true
+}
+
+// String returns the pair as "USD/GBP".
+func (pair Pair) String() string {
+	return pair.Base.String() + "/" + pair.Quote.String()
+}
+
+// Invert returns the pair with the base and quote currencies swapped.
+func (pair Pair) Invert() Pair {
+	return Pair{Base: pair.Quote, Quote: pair.Base}
+}
+
+// Rate returns the cross rate of the pair through the euro rates of
+// table, in units of Quote per unit of Base.
+func (pair Pair) Rate(table *RateTable) (float64, error) {
+	return table.Convert(1, pair.Base.String(), pair.Quote.String())
+}
+
+// Convert converts an amount of the base currency into the quote
+// currency with the rates of table, as RateTable.Conversion does.
+func (pair Pair) Convert(table *RateTable, amount float64,
+	options ...ConvertOptions) (*ConversionResult, error) {
+	return table.Conversion(amount, pair.Base.String(), pair.Quote.String(), options...)
+}
+
+// MarshalText and UnmarshalText encode the pair as "USD/GBP".
+func (pair Pair) MarshalText() ([]byte, error) {
+	return []byte(pair.String()), nil
+}
+
+func (pair *Pair) UnmarshalText(text []byte) error {
+
+	parsed, err := ParsePair(string(text))
+	if err != nil {
+		return err
+	}
+	*pair = parsed
+
+	return nil
+}
@@ -0,0 +1,78 @@
+//
+// Copyright 2023 The GoEurofxref Authors. All rights reserved.
+// Use of this source code is governed by a MIT License
+// license that can be found in the LICENSE file.
+// Last Modification: 2026-10-14 19:29:07
+//
+
+package eurofxref
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+)
+
+func TestParsePair(t *testing.T) {
+
+	pair, err := ParsePair(" usd / GBP ")
+	if err != nil || pair != (Pair{USD, GBP}) || pair.String() != "USD/GBP" {
+		t.Errorf("got %v (%v), want USD/GBP", pair, err)
+	}
+	if inverted := pair.Invert(); inverted != (Pair{GBP, USD}) {
+		t.Errorf("got the inverse %v, want GBP/USD", inverted)
+	}
+
+	for _, s := range []string{"", "USDGBP", "USD/", "/GBP", "US$/GBP", "USD/GBP/EUR", "USDX/GBP"} {
+		if _, err := ParsePair(s); err == nil {
+			t.Errorf("ParsePair(%q): expected an error", s)
+		}
+	}
+}
+
+func TestPairRate(t *testing.T) {
+
+	table := &RateTable{Rates: map[string]float64{"USD": 1.0876, "GBP": 0.8565}}
+
+	tests := []struct {
+		pair Pair
+		want float64
+	}{
+		{Pair{USD, GBP}, 0.8565 / 1.0876},
+		{Pair{GBP, USD}, 1.0876 / 0.8565},
+		{Pair{EUR, USD}, 1.0876},
+		{Pair{USD, EUR}, 1 / 1.0876},
+	}
+	for _, test := range tests {
+		rate, err := test.pair.Rate(table)
+		if err != nil || math.Abs(rate-test.want) > 1e-12 {
+			t.Errorf("%v: got %v (%v), want %v", test.pair, rate, err, test.want)
+		}
+	}
+
+	if _, err := (Pair{USD, JPY}).Rate(table); err == nil {
+		t.Error("expected an error for a currency without a rate")
+	}
+
+	conversion, err := Pair{USD, GBP}.Convert(table, 100, ConvertOptions{Rounding: true})
+	if err != nil || conversion.Value != 78.75 {
+		t.Errorf("got %+v (%v), want 78.75 GBP", conversion, err)
+	}
+}
+
+func TestPairJSON(t *testing.T) {
+
+	var decoded struct{ Pair Pair }
+	if err := json.Unmarshal([]byte(`{"Pair":"chf/jpy"}`), &decoded); err != nil || decoded.Pair != (Pair{CHF, JPY}) {
+		t.Errorf("got %v (%v), want CHF/JPY", decoded.Pair, err)
+	}
+
+	data, err := json.Marshal(decoded)
+	if err != nil || string(data) != `{"Pair":"CHF/JPY"}` {
+		t.Errorf("got %s (%v)", data, err)
+	}
+
+	if err := json.Unmarshal([]byte(`{"Pair":"CHF-JPY"}`), &decoded); err == nil {
+		t.Error("expected an error for an invalid pair")
+	}
+}
@@ -0,0 +1,71 @@
+//
+// Copyright 2023 The GoEurofxref Authors. All rights reserved.
+// Use of this source code is governed by a MIT License
+// license that can be found in the LICENSE file.
+// Last Modification: 2026-10-14 19:32:21
+//
+
+// Package parquetexport exports the historical reference rates of a
+// eurofxref.HistoryStore as Apache Parquet.
+//
+// It is a separate package so that the Parquet dependencies are only
+// linked into the programs that use it.
+package parquetexport
+
+import (
+	"context"
+	"io"
+	"sort"
+	"time"
+
+	eurofxref "github.com/mrhdias/go-eurofxref"
+	"github.com/parquet-go/parquet-go"
+)
+
+// Row is a reference rate as stored in the Parquet file.
+type Row struct {
+	Date     time.Time `parquet:"date,timestamp(millisecond)"`
+	Currency string    `parquet:"currency,dict"`
+	Rate     float64   `parquet:"rate"`
+}
+
+// RowGroupSize is the number of rows buffered before a row group is
+// flushed to the writer, which bounds the memory used by an export.
+const RowGroupSize = 64 * 1024
+
+// Export streams the store to w as a Parquet file of Row records sorted
+// by date and currency.
+func Export(ctx context.Context, w io.Writer, store *eurofxref.HistoryStore) error {
+
+	writer := parquet.NewGenericWriter[Row](w)
+	buffered := 0
+
+	if err := store.Each(ctx, func(date time.Time, rates map[string]float64) error {
+		currencies := make([]string, 0, len(rates))
+		for currency := range rates {
+			currencies = append(currencies, currency)
+		}
+		sort.Strings(currencies)
+
+		// the timestamps are read as UTC, so the day is kept at midnight UTC
+		utcDate := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC)
+		rows := make([]Row, 0, len(currencies))
+		for _, currency := range currencies {
+			rows = append(rows, Row{Date: utcDate, Currency: currency, Rate: rates[currency]})
+		}
+		if _, err := writer.Write(rows); err != nil {
+			return err
+		}
+
+		buffered += len(rows)
+		if buffered >= RowGroupSize {
+			buffered = 0
+			return writer.Flush()
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	return writer.Close()
+}
@@ -0,0 +1,58 @@
+//
+// Copyright 2023 The GoEurofxref Authors. All rights reserved.
+// Use of this source code is governed by a MIT License
+// license that can be found in the LICENSE file.
+// Last Modification: 2026-10-14 18:31:28
+//
+
+package parquetexport
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	eurofxref "github.com/mrhdias/go-eurofxref"
+	"github.com/parquet-go/parquet-go"
+)
+
+func TestExport(t *testing.T) {
+
+	ecb := httptest.NewServer(http.FileServer(http.Dir("../testdata")))
+	defer ecb.Close()
+
+	source := eurofxref.New("", false)
+	source.CacheDir = ""
+	source.HistUrl = ecb.URL + "/eurofxref-hist.xml"
+
+	store, err := eurofxref.OpenHistoryStore("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := store.Sync(source); err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	if err := Export(context.Background(), &buf, store); err != nil {
+		t.Fatal(err)
+	}
+
+	reader := parquet.NewGenericReader[Row](bytes.NewReader(buf.Bytes()))
+	defer reader.Close()
+
+	if n := reader.NumRows(); n != 20*30 {
+		t.Fatalf("got %d rows, want %d", n, 20*30)
+	}
+
+	rows := make([]Row, 20*30)
+	if n, _ := reader.Read(rows); n != len(rows) {
+		t.Fatalf("read %d rows, want %d", n, len(rows))
+	}
+	last := rows[len(rows)-1]
+	if last.Date.Format("2006-01-02") != "2024-03-01" || last.Currency != "ZAR" || last.Rate != 20.6711 {
+		t.Errorf("got last row %+v", last)
+	}
+}
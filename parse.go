@@ -0,0 +1,96 @@
+//
+// Copyright 2023 The GoEurofxref Authors. All rights reserved.
+// Use of this source code is governed by a MIT License
+// license that can be found in the LICENSE file.
+// Last Modification: 2026-10-14 20:35:00
+//
+
+package eurofxref
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"io"
+)
+
+// ParseDaily returns the rates of the daily file of the ECB, e.g. read
+// from an internal mirror or an object store rather than downloaded by a
+// client. The content can be gzipped.
+func ParseDaily(data []byte) (*RateTable, error) {
+	return DecodeDaily(bytes.NewReader(data))
+}
+
+// ParseHist returns the publications of a historical file of the ECB,
+// the full history or the last 90 days, from the most recent as in the
+// file. The content can be gzipped.
+func ParseHist(data []byte) ([]*RateTable, error) {
+
+	tables := []*RateTable{}
+	if err := DecodeHist(bytes.NewReader(data), func(table *RateTable) error {
+		tables = append(tables, table)
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	return tables, nil
+}
+
+// DecodeDaily is ParseDaily reading the file from r, which is read up to
+// the end of the first publication only.
+func DecodeDaily(r io.Reader) (*RateTable, error) {
+
+	var table *RateTable
+	err := DecodeHist(r, func(first *RateTable) error {
+		table = first
+		return errStopIteration
+	})
+	if err != nil && !errors.Is(err, errStopIteration) {
+		return nil, err
+	}
+	if table == nil {
+		return nil, errors.New("the envelope has no reference rates")
+	}
+
+	return table, nil
+}
+
+// DecodeHist reads a historical file of the ECB from r and calls fn for
+// each publication, from the most recent as in the file, stopping at the
+// first error of fn. Only one publication is held in memory at a time,
+// so the files streamed from disk or from an object store are not
+// buffered. The content can be gzipped.
+func DecodeHist(r io.Reader, fn func(table *RateTable) error) error {
+
+	r, err := gunzipReader(r)
+	if err != nil {
+		return err
+	}
+
+	return decodeCubes(limitReader(r, maxDecompressedSize), func(cube TimeCube) error {
+		table, err := cube.Table()
+		if err != nil {
+			return err
+		}
+		return fn(table)
+	})
+}
+
+// gunzipReader is gunzip for a reader: it returns a reader of the
+// decompressed content when r starts with the gzip magic number, and of
+// the content of r otherwise.
+func gunzipReader(r io.Reader) (io.Reader, error) {
+
+	br := bufio.NewReader(r)
+	magic, err := br.Peek(2)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return nil, err
+	}
+	if len(magic) < 2 || magic[0] != 0x1f || magic[1] != 0x8b {
+		return br, nil
+	}
+
+	return gzip.NewReader(br)
+}
@@ -0,0 +1,96 @@
+//
+// Copyright 2023 The GoEurofxref Authors. All rights reserved.
+// Use of this source code is governed by a MIT License
+// license that can be found in the LICENSE file.
+// Last Modification: 2026-10-14 20:35:00
+//
+
+package eurofxref
+
+import (
+	"errors"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestParseDaily(t *testing.T) {
+
+	data, err := os.ReadFile("testdata/eurofxref-daily.xml")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	table, err := ParseDaily(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if table.Date.IsZero() || len(table.Rates) != 30 || table.Rates["USD"] <= 0 {
+		t.Errorf("unexpected daily rates %+v", table)
+	}
+
+	gzipped, err := gzipBytes(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if table, err := ParseDaily(gzipped); err != nil || len(table.Rates) != 30 {
+		t.Errorf("got %+v (%v), want the rates of the gzipped file", table, err)
+	}
+
+	if _, err := ParseDaily([]byte("<html><body>Service Unavailable</body></html>")); err == nil {
+		t.Error("expected an error parsing a page that is not an ECB file")
+	}
+}
+
+func TestParseHist(t *testing.T) {
+
+	data, err := os.ReadFile("testdata/eurofxref-hist-90d.xml")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tables, err := ParseHist(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tables) < 2 || !tables[0].Date.After(tables[1].Date) {
+		t.Fatalf("got %d publications, want them from the most recent", len(tables))
+	}
+}
+
+func TestDecodeHist(t *testing.T) {
+
+	file, err := os.Open("testdata/eurofxref-hist.xml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+
+	stop := errors.New("stop")
+	tables := 0
+	err = DecodeHist(file, func(table *RateTable) error {
+		tables++
+		if tables == 3 {
+			return stop
+		}
+		return nil
+	})
+	if !errors.Is(err, stop) || tables != 3 {
+		t.Errorf("got %d publications (%v), want the decoding stopped at the third", tables, err)
+	}
+
+	daily, err := os.Open("testdata/eurofxref-daily.xml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer daily.Close()
+
+	if table, err := DecodeDaily(daily); err != nil || len(table.Rates) != 30 {
+		t.Errorf("got %+v (%v), want the daily rates", table, err)
+	}
+
+	laughs := `<!DOCTYPE Envelope [<!ENTITY lol "lol">]><Envelope><Cube><Cube time="2024-03-01"><Cube currency="USD" rate="1.08"/></Cube></Cube></Envelope>`
+	if _, err := DecodeDaily(strings.NewReader(laughs)); err == nil || !strings.Contains(err.Error(), "DTD") {
+		t.Errorf("got %v, want the DTD rejected", err)
+	}
+}
@@ -0,0 +1,175 @@
+//
+// Copyright 2023 The GoEurofxref Authors. All rights reserved.
+// Use of this source code is governed by a MIT License
+// license that can be found in the LICENSE file.
+// Last Modification: 2026-10-14 19:10:05
+//
+
+package eurofxref
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+// RateProvider is a source of euro reference rates. Set as the Provider of
+// an EuroFxRef, it answers the daily and historical queries, which keep
+// their validation, conversion and scheduling on top of it.
+type RateProvider interface {
+	// Latest returns the rates of the most recent publication indexed by
+	// upper case currency code, along with the publication date.
+	Latest(ctx context.Context) (map[string]float64, time.Time, error)
+	// Historical calls fn with the rates of each publication between from
+	// and to, both inclusive, from the most recent to the oldest like the
+	// ECB files, until fn returns an error. A zero from or to leaves that
+	// side of the range open.
+	Historical(ctx context.Context, from, to time.Time,
+		fn func(date time.Time, rates map[string]float64) error) error
+}
+
+// xmlProvider is the RateProvider of the ECB XML files.
+type xmlProvider struct {
+	efr EuroFxRef
+}
+
+// NewXMLProvider returns the RateProvider of the ECB XML files, downloaded
+// and cached as configured by efr, e.g. to combine it with other
+// providers. The Provider of efr is ignored.
+func NewXMLProvider(efr EuroFxRef) RateProvider {
+
+	efr.Provider = nil
+
+	return xmlProvider{efr}
+}
+
+func (p xmlProvider) Latest(ctx context.Context) (map[string]float64, time.Time, error) {
+	table, err := p.efr.fetchDailyRates(ctx, false)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+
+	return table.Rates, table.Date, nil
+}
+
+func (p xmlProvider) Historical(ctx context.Context, from, to time.Time,
+	fn func(date time.Time, rates map[string]float64) error) error {
+
+	from, to = truncateDay(from), truncateDay(to)
+
+	fileUrl := p.efr.HistUrl
+	if !from.IsZero() && p.efr.Now().Sub(from) < hist90Days {
+		fileUrl = p.efr.Hist90Url
+	}
+
+	return p.efr.eachPublication(ctx, fileUrl, func(date time.Time, rates map[string]float64) error {
+		if (!from.IsZero() && date.Before(from)) || (!to.IsZero() && date.After(to)) {
+			return nil
+		}
+		return fn(date, rates)
+	})
+}
+
+// NamedProvider is a provider of a CompositeProvider, named to report which
+// one answered.
+type NamedProvider struct {
+	Name     string
+	Provider RateProvider
+}
+
+// CompositeProvider is a RateProvider asking its providers in order of
+// priority, falling back to the next one when a provider fails. A
+// historical query only falls back while nothing was yielded, so fn never
+// sees the same publication twice.
+type CompositeProvider struct {
+	Providers []NamedProvider
+	// Logger receives a warning for each fallback. They are discarded
+	// when it is nil.
+	Logger *slog.Logger
+
+	mu       sync.Mutex
+	answered string
+}
+
+// NewCompositeProvider returns a CompositeProvider with the providers
+// from the highest to the lowest priority.
+func NewCompositeProvider(providers ...NamedProvider) *CompositeProvider {
+	return &CompositeProvider{Providers: providers}
+}
+
+// Answered returns the name of the provider of the last successful
+// answer, empty before any.
+func (p *CompositeProvider) Answered() string {
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return p.answered
+}
+
+func (p *CompositeProvider) logger() *slog.Logger {
+
+	if p.Logger != nil {
+		return p.Logger
+	}
+
+	return slog.New(discardHandler{})
+}
+
+func (p *CompositeProvider) setAnswered(name string) {
+
+	p.mu.Lock()
+	p.answered = name
+	p.mu.Unlock()
+}
+
+func (p *CompositeProvider) Latest(ctx context.Context) (map[string]float64, time.Time, error) {
+
+	var lastErr error = errors.New("the composite provider has no providers")
+	for _, named := range p.Providers {
+		rates, lastUpdate, err := named.Provider.Latest(ctx)
+		if err == nil {
+			p.setAnswered(named.Name)
+			return rates, lastUpdate, nil
+		}
+		lastErr = fmt.Errorf("provider \"%s\": %v", named.Name, err)
+		p.logger().Warn("provider failed", slog.String("provider", named.Name), slog.Any("error", err))
+		if ctx.Err() != nil {
+			break
+		}
+	}
+
+	return nil, time.Time{}, lastErr
+}
+
+func (p *CompositeProvider) Historical(ctx context.Context, from, to time.Time,
+	fn func(date time.Time, rates map[string]float64) error) error {
+
+	var lastErr error = errors.New("the composite provider has no providers")
+	for _, named := range p.Providers {
+		yielded := false
+		var fnErr error
+		err := named.Provider.Historical(ctx, from, to, func(date time.Time, rates map[string]float64) error {
+			yielded = true
+			fnErr = fn(date, rates)
+			return fnErr
+		})
+		if err == nil || (fnErr != nil && errors.Is(err, fnErr)) {
+			p.setAnswered(named.Name)
+			return err
+		}
+		if yielded {
+			return fmt.Errorf("provider \"%s\": %v", named.Name, err)
+		}
+		lastErr = fmt.Errorf("provider \"%s\": %v", named.Name, err)
+		p.logger().Warn("provider failed", slog.String("provider", named.Name), slog.Any("error", err))
+		if ctx.Err() != nil {
+			break
+		}
+	}
+
+	return lastErr
+}
@@ -0,0 +1,206 @@
+//
+// Copyright 2023 The GoEurofxref Authors. All rights reserved.
+// Use of this source code is governed by a MIT License
+// license that can be found in the LICENSE file.
+// Last Modification: 2026-10-14 19:32:21
+//
+
+package eurofxref
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// mapProvider serves publications held in memory, the most recent first.
+type mapProvider []publication
+
+func (p mapProvider) Latest(ctx context.Context) (map[string]float64, time.Time, error) {
+	return p[0].rates, p[0].date, nil
+}
+
+func (p mapProvider) Historical(ctx context.Context, from, to time.Time,
+	fn func(date time.Time, rates map[string]float64) error) error {
+
+	for _, pub := range p {
+		if (!from.IsZero() && pub.date.Before(from)) || (!to.IsZero() && pub.date.After(to)) {
+			continue
+		}
+		if err := fn(pub.date, pub.rates); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func TestProvider(t *testing.T) {
+
+	today := truncateDay(time.Now())
+	friday := today.AddDate(0, 0, -1)
+
+	query := New("", false)
+	query.CacheDir = ""
+	query.Url = "http://ecb.invalid/eurofxref-daily.xml"
+	query.HistUrl = "http://ecb.invalid/eurofxref-hist.xml"
+	query.Hist90Url = "http://ecb.invalid/eurofxref-hist-90d.xml"
+	query.Provider = mapProvider{
+		{today, map[string]float64{"USD": 1.10, "GBP": 0.85}},
+		{friday, map[string]float64{"USD": 1.05, "GBP": 0.84}},
+		{today.AddDate(-1, 0, 0), map[string]float64{"USD": 1.00, "GBP": 0.80}},
+	}
+
+	result, err := query.Daily("USD")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.RateValue != 1.10 || !result.LastUpdate.Equal(today) {
+		t.Errorf("unexpected daily rate %+v", result)
+	}
+
+	conversion, err := query.Convert(100, "GBP", "USD")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if conversion.Rate != 1.10/0.85 {
+		t.Errorf("got conversion rate %v", conversion.Rate)
+	}
+
+	series, err := query.History("USD")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(series.Points) != 2 || series.Points[0].Rate != 1.05 {
+		t.Errorf("the 90-day history is %v", series.Points)
+	}
+
+	onFriday, err := query.RateOn("GBP", friday)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if onFriday.RateValue != 0.84 {
+		t.Errorf("got %v on %v", onFriday.RateValue, friday)
+	}
+
+	store, err := OpenHistoryStore("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report, err := store.Sync(query); err != nil || len(report.Added) != 3 {
+		t.Errorf("synced %v from the provider: %v", report, err)
+	}
+}
+
+func TestXMLProvider(t *testing.T) {
+
+	_, query := newTestServer(t)
+
+	provider := NewXMLProvider(query)
+
+	rates, lastUpdate, err := provider.Latest(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rates["USD"] != 1.0876 || lastUpdate.Format("2006-01-02") != "2024-03-01" {
+		t.Errorf("unexpected latest rates %v on %v", rates["USD"], lastUpdate)
+	}
+
+	from, _ := parseDate("2024-02-05")
+	to, _ := parseDate("2024-02-09")
+	var dates []string
+	if err := provider.Historical(context.Background(), from, to, func(date time.Time, rates map[string]float64) error {
+		dates = append(dates, date.Format("2006-01-02"))
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if len(dates) != 5 || dates[0] != "2024-02-09" {
+		t.Errorf("got publications %v", dates)
+	}
+
+	// a provider wrapping the files of another client
+	other := New("", false)
+	other.CacheDir = ""
+	other.Provider = provider
+	if result, err := other.Daily("GBP"); err != nil || result.RateValue != 0.85578 {
+		t.Errorf("got %v, %v through the XML provider", result, err)
+	}
+}
+
+// failingProvider fails every query, after yielding the publications of
+// its mapProvider for the historical ones.
+type failingProvider struct {
+	mapProvider
+}
+
+func (p failingProvider) Latest(ctx context.Context) (map[string]float64, time.Time, error) {
+	return nil, time.Time{}, errors.New("upstream unavailable")
+}
+
+func (p failingProvider) Historical(ctx context.Context, from, to time.Time,
+	fn func(date time.Time, rates map[string]float64) error) error {
+
+	if err := p.mapProvider.Historical(ctx, from, to, fn); err != nil {
+		return err
+	}
+
+	return errors.New("connection reset")
+}
+
+func TestCompositeProvider(t *testing.T) {
+
+	date := PublicationDate(2024, 3, 1)
+	internal := mapProvider{{date, map[string]float64{"USD": 1.0876}}}
+
+	composite := NewCompositeProvider(
+		NamedProvider{"ecb", failingProvider{}},
+		NamedProvider{"internal", internal},
+	)
+
+	rates, _, err := composite.Latest(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rates["USD"] != 1.0876 || composite.Answered() != "internal" {
+		t.Errorf("got %v answered by %q", rates, composite.Answered())
+	}
+
+	count := 0
+	if err := composite.Historical(context.Background(), time.Time{}, time.Time{},
+		func(time.Time, map[string]float64) error {
+			count++
+			return nil
+		}); err != nil {
+		t.Fatal(err)
+	}
+	if count != 1 || composite.Answered() != "internal" {
+		t.Errorf("got %d publications answered by %q", count, composite.Answered())
+	}
+
+	// a failure after yielding does not fall back
+	composite.Providers[0].Provider = failingProvider{internal}
+	count = 0
+	err = composite.Historical(context.Background(), time.Time{}, time.Time{},
+		func(time.Time, map[string]float64) error {
+			count++
+			return nil
+		})
+	if err == nil || count != 1 {
+		t.Errorf("got %d publications and error %v, want 1 and the error of ecb", count, err)
+	}
+
+	// the errors of fn stop the query without falling back
+	stop := errors.New("stop")
+	if err := composite.Historical(context.Background(), time.Time{}, time.Time{},
+		func(time.Time, map[string]float64) error {
+			return stop
+		}); !errors.Is(err, stop) {
+		t.Errorf("got %v, want the error of fn", err)
+	}
+
+	if _, _, err := NewCompositeProvider().Latest(context.Background()); err == nil {
+		t.Error("expected an error without providers")
+	}
+}
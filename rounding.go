@@ -0,0 +1,172 @@
+//
+// Copyright 2023 The GoEurofxref Authors. All rights reserved.
+// Use of this source code is governed by a MIT License
+// license that can be found in the LICENSE file.
+// Last Modification: 2026-10-14 19:27:43
+//
+
+package eurofxref
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"sync"
+)
+
+// RoundingRule describes how the amounts of a currency are rounded.
+//
+// MinorUnits is the number of decimals used in accounting and
+// CashIncrement the smallest amount that can be paid in cash, when it
+// differs from one minor unit (e.g. 0.05 for the Swiss franc).
+type RoundingRule struct {
+	MinorUnits    int
+	CashIncrement float64
+}
+
+// defaultRoundingRule applies to the currencies without a registered rule.
+var defaultRoundingRule = RoundingRule{MinorUnits: 2}
+
+var (
+	roundingRulesMu sync.RWMutex
+	roundingRules   = map[string]RoundingRule{
+		"EUR": {MinorUnits: 2},
+		"AUD": {MinorUnits: 2, CashIncrement: 0.05},
+		"CAD": {MinorUnits: 2, CashIncrement: 0.05},
+		"CHF": {MinorUnits: 2, CashIncrement: 0.05},
+		"CZK": {MinorUnits: 2, CashIncrement: 1},
+		"DKK": {MinorUnits: 2, CashIncrement: 0.5},
+		"HKD": {MinorUnits: 2, CashIncrement: 0.1},
+		"HUF": {MinorUnits: 2, CashIncrement: 5},
+		"IDR": {MinorUnits: 2, CashIncrement: 100},
+		"ILS": {MinorUnits: 2, CashIncrement: 0.1},
+		"ISK": {MinorUnits: 0},
+		"JPY": {MinorUnits: 0},
+		"KRW": {MinorUnits: 0},
+		"NOK": {MinorUnits: 2, CashIncrement: 1},
+		"NZD": {MinorUnits: 2, CashIncrement: 0.1},
+		"SEK": {MinorUnits: 2, CashIncrement: 1},
+		"SGD": {MinorUnits: 2, CashIncrement: 0.05},
+		"ZAR": {MinorUnits: 2, CashIncrement: 0.1},
+	}
+)
+
+// RegisterRoundingRule sets the rounding rule of a currency, replacing
+// the built-in one.
+func RegisterRoundingRule(currencyCode string, rule RoundingRule) {
+
+	roundingRulesMu.Lock()
+	defer roundingRulesMu.Unlock()
+
+	roundingRules[strings.ToUpper(currencyCode)] = rule
+}
+
+// LookupRoundingRule returns the rounding rule of a currency, or the
+// default two decimals rule when none is registered.
+func LookupRoundingRule(currencyCode string) RoundingRule {
+
+	roundingRulesMu.RLock()
+	defer roundingRulesMu.RUnlock()
+
+	if rule, ok := roundingRules[strings.ToUpper(currencyCode)]; ok {
+		return rule
+	}
+
+	return defaultRoundingRule
+}
+
+// RoundingMode selects how the amounts halfway between two multiples of
+// the increment are rounded, as accounting standards differ between
+// jurisdictions. The zero value is RoundHalfUp.
+type RoundingMode int
+
+const (
+	// RoundHalfUp rounds half away from zero: 0.125 to 0.13 and -0.125
+	// to -0.13. It is the commercial rounding of most of the euro area.
+	RoundHalfUp RoundingMode = iota + 1
+	// RoundHalfEven rounds half to the even multiple (banker's
+	// rounding): 0.125 to 0.12 and 0.135 to 0.14.
+	RoundHalfEven
+	// RoundTruncate drops the digits beyond the increment, rounding
+	// toward zero.
+	RoundTruncate
+)
+
+func (mode RoundingMode) String() string {
+
+	switch mode {
+	case 0, RoundHalfUp:
+		return "half-up"
+	case RoundHalfEven:
+		return "half-even"
+	case RoundTruncate:
+		return "truncate"
+	}
+
+	return fmt.Sprintf("RoundingMode(%d)", int(mode))
+}
+
+func (mode RoundingMode) MarshalText() ([]byte, error) {
+	return []byte(mode.String()), nil
+}
+
+func (mode *RoundingMode) UnmarshalText(text []byte) error {
+
+	for _, m := range []RoundingMode{RoundHalfUp, RoundHalfEven, RoundTruncate} {
+		if string(text) == m.String() {
+			*mode = m
+			return nil
+		}
+	}
+
+	return fmt.Errorf("unknown rounding mode %q", text)
+}
+
+// Round rounds an amount half up to the minor units of the currency.
+func (rule RoundingRule) Round(amount float64) float64 {
+	return rule.RoundMode(amount, RoundHalfUp)
+}
+
+// RoundMode rounds an amount to the minor units of the currency with the
+// rounding mode.
+func (rule RoundingRule) RoundMode(amount float64, mode RoundingMode) float64 {
+	return roundTo(amount, math.Pow10(-rule.MinorUnits), rule.MinorUnits, mode)
+}
+
+// CashRound rounds an amount half up to the cash increment of the
+// currency.
+func (rule RoundingRule) CashRound(amount float64) float64 {
+	return rule.CashRoundMode(amount, RoundHalfUp)
+}
+
+// CashRoundMode rounds an amount to the cash increment of the currency
+// with the rounding mode.
+func (rule RoundingRule) CashRoundMode(amount float64, mode RoundingMode) float64 {
+
+	if rule.CashIncrement <= 0 {
+		return rule.RoundMode(amount, mode)
+	}
+
+	return roundTo(amount, rule.CashIncrement, rule.MinorUnits, mode)
+}
+
+// roundTo rounds an amount to a multiple of increment with the rounding
+// mode, dropping the binary noise beyond the given decimals. The quotient
+// is first rounded to nine decimals, so that 1.005 halves as written
+// instead of as its binary value 1.00499999999999989...
+func roundTo(amount, increment float64, decimals int, mode RoundingMode) float64 {
+
+	quotient := math.Round(amount/increment*1e9) / 1e9
+	switch mode {
+	case RoundHalfEven:
+		quotient = math.RoundToEven(quotient)
+	case RoundTruncate:
+		quotient = math.Trunc(quotient)
+	default:
+		quotient = math.Round(quotient)
+	}
+
+	scale := math.Pow10(decimals)
+
+	return math.Round(quotient*increment*scale) / scale
+}
@@ -0,0 +1,76 @@
+//
+// Copyright 2023 The GoEurofxref Authors. All rights reserved.
+// Use of this source code is governed by a MIT License
+// license that can be found in the LICENSE file.
+// Last Modification: 2026-10-14 18:43:19
+//
+
+package eurofxref
+
+import "testing"
+
+func TestRoundingRules(t *testing.T) {
+
+	tests := []struct {
+		currency    string
+		amount      float64
+		round, cash float64
+	}{
+		{"CHF", 10.024, 10.02, 10.00},
+		{"CHF", 10.026, 10.03, 10.05},
+		{"HUF", 1234.56, 1234.56, 1235},
+		{"JPY", 1234.5, 1235, 1235},
+		{"USD", 1.005001, 1.01, 1.01},
+		{"SEK", 99.49, 99.49, 99},
+	}
+
+	for _, tt := range tests {
+		rule := LookupRoundingRule(tt.currency)
+		if got := rule.Round(tt.amount); got != tt.round {
+			t.Errorf("%s Round(%v) = %v, want %v", tt.currency, tt.amount, got, tt.round)
+		}
+		if got := rule.CashRound(tt.amount); got != tt.cash {
+			t.Errorf("%s CashRound(%v) = %v, want %v", tt.currency, tt.amount, got, tt.cash)
+		}
+	}
+
+	RegisterRoundingRule("usd", RoundingRule{MinorUnits: 2, CashIncrement: 0.05})
+	defer RegisterRoundingRule("USD", defaultRoundingRule)
+	if got := LookupRoundingRule("USD").CashRound(1.02); got != 1.00 {
+		t.Errorf("got %v with the registered rule, want 1", got)
+	}
+}
+
+func TestRoundingModes(t *testing.T) {
+
+	tests := []struct {
+		currency string
+		amount   float64
+		mode     RoundingMode
+		round    float64
+		cash     float64
+	}{
+		{"EUR", 0.125, RoundHalfUp, 0.13, 0.13},
+		{"EUR", 0.125, RoundHalfEven, 0.12, 0.12},
+		{"EUR", 0.135, RoundHalfEven, 0.14, 0.14},
+		{"EUR", -0.125, RoundHalfUp, -0.13, -0.13},
+		{"EUR", 1.005, RoundHalfUp, 1.01, 1.01},
+		{"EUR", 1.019, RoundTruncate, 1.01, 1.01},
+		{"EUR", -1.019, RoundTruncate, -1.01, -1.01},
+		{"CHF", 10.075, RoundHalfEven, 10.08, 10.1},
+		{"CHF", 10.025, RoundHalfEven, 10.02, 10},
+		{"CHF", 10.049, RoundTruncate, 10.04, 10},
+		{"JPY", 2.5, RoundHalfEven, 2, 2},
+		{"JPY", 2.5, 0, 3, 3},
+	}
+
+	for _, tt := range tests {
+		rule := LookupRoundingRule(tt.currency)
+		if got := rule.RoundMode(tt.amount, tt.mode); got != tt.round {
+			t.Errorf("%s RoundMode(%v, %v) = %v, want %v", tt.currency, tt.amount, tt.mode, got, tt.round)
+		}
+		if got := rule.CashRoundMode(tt.amount, tt.mode); got != tt.cash {
+			t.Errorf("%s CashRoundMode(%v, %v) = %v, want %v", tt.currency, tt.amount, tt.mode, got, tt.cash)
+		}
+	}
+}
@@ -0,0 +1,87 @@
+//
+// Copyright 2023 The GoEurofxref Authors. All rights reserved.
+// Use of this source code is governed by a MIT License
+// license that can be found in the LICENSE file.
+// Last Modification: 2026-10-14 17:57:51
+//
+
+package rpc
+
+import (
+	"context"
+	"log/slog"
+	"strconv"
+	"time"
+
+	"github.com/mrhdias/go-eurofxref/server"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// UnaryInterceptor records the calls in metrics and writes an access log
+// entry per call to logger, like server.Instrument does for the HTTP
+// endpoints. Either of them can be nil.
+func UnaryInterceptor(logger *slog.Logger, metrics *server.Metrics) grpc.UnaryServerInterceptor {
+
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler) (any, error) {
+
+		start := time.Now()
+		resp, err := handler(ctx, req)
+		latency := time.Since(start)
+		code := status.Code(err)
+
+		// client errors are not failures of the service
+		failed := code != codes.OK && code != codes.InvalidArgument && code != codes.NotFound
+
+		if metrics != nil {
+			metrics.Observe(info.FullMethod, latency, failed)
+		}
+
+		if logger != nil {
+			level := slog.LevelInfo
+			if failed {
+				level = slog.LevelError
+			}
+			logger.LogAttrs(ctx, level, "call",
+				slog.String("method", info.FullMethod),
+				slog.String("code", code.String()),
+				slog.Duration("duration", latency),
+			)
+		}
+
+		return resp, err
+	}
+}
+
+// APIKeyInterceptor rejects the calls without a known API key in the
+// x-api-key metadata and accounts the usage of the others in keys.
+func APIKeyInterceptor(keys *server.APIKeys) grpc.UnaryServerInterceptor {
+
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler) (any, error) {
+
+		key := ""
+		if md, ok := metadata.FromIncomingContext(ctx); ok {
+			if values := md.Get("x-api-key"); len(values) > 0 {
+				key = values[0]
+			}
+		}
+
+		ok, remaining := keys.Use(key)
+		if !ok {
+			return nil, status.Error(codes.Unauthenticated, "missing or unknown api key")
+		}
+
+		if keys.Quota > 0 {
+			grpc.SetHeader(ctx, metadata.Pairs(
+				"x-quota-limit", strconv.FormatUint(keys.Quota, 10),
+				"x-quota-remaining", strconv.FormatInt(max(remaining, 0), 10),
+			))
+		}
+
+		return handler(ctx, req)
+	}
+}
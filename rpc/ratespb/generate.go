@@ -0,0 +1,12 @@
+//
+// Copyright 2023 The GoEurofxref Authors. All rights reserved.
+// Use of this source code is governed by a MIT License
+// license that can be found in the LICENSE file.
+// Last Modification: 2026-10-14 19:31:50
+//
+
+// Package ratespb contains the protocol buffer and gRPC definitions of
+// the RatesService generated from rates.proto.
+package ratespb
+
+//go:generate buf generate --template buf.gen.yaml
@@ -0,0 +1,147 @@
+//
+// Copyright 2023 The GoEurofxref Authors. All rights reserved.
+// Use of this source code is governed by a MIT License
+// license that can be found in the LICENSE file.
+// Last Modification: 2026-10-15 14:30:00
+//
+
+// Package rpc implements the gRPC RatesService defined in
+// ratespb/rates.proto, backed by the cache of the eurofxref package.
+package rpc
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	eurofxref "github.com/mrhdias/go-eurofxref"
+	"github.com/mrhdias/go-eurofxref/rpc/ratespb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Server implements ratespb.RatesServiceServer with the rates of Source.
+type Server struct {
+	ratespb.UnimplementedRatesServiceServer
+	Source eurofxref.EuroFxRef
+}
+
+func New(source eurofxref.EuroFxRef) *Server {
+	return &Server{Source: source}
+}
+
+// Register registers the RatesService on registrar, usually a
+// *grpc.Server.
+func (s *Server) Register(registrar grpc.ServiceRegistrar) {
+	ratespb.RegisterRatesServiceServer(registrar, s)
+}
+
+func (s *Server) validate(currencyCode string) error {
+
+	if strings.EqualFold(currencyCode, "EUR") {
+		return nil
+	}
+	if err := s.Source.ValidateCurrencyCode(currencyCode); err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	return nil
+}
+
+func (s *Server) GetDaily(ctx context.Context, req *ratespb.GetDailyRequest) (*ratespb.Rate, error) {
+
+	if err := s.validate(req.GetCurrency()); err != nil {
+		return nil, err
+	}
+
+	result, err := s.Source.DailyContext(ctx, req.GetCurrency())
+	if err != nil {
+		return nil, status.Error(codes.Unavailable, err.Error())
+	}
+
+	return &ratespb.Rate{
+		Currency: strings.ToUpper(req.GetCurrency()),
+		Date:     dateTimestamp(result.LastUpdate),
+		Rate:     result.RateValue,
+	}, nil
+}
+
+func (s *Server) GetHistory(ctx context.Context, req *ratespb.GetHistoryRequest) (*ratespb.GetHistoryResponse, error) {
+
+	from, to := req.GetFrom().AsTime(), req.GetTo().AsTime()
+	if req.GetFrom() != nil && req.GetTo() != nil && to.Before(from) {
+		return nil, status.Error(codes.InvalidArgument, "the end of the range is before its start")
+	}
+	ranged := req.GetFrom() != nil || req.GetTo() != nil
+	if req.GetTo() == nil {
+		to = s.Source.Now()
+	}
+	switch {
+	case req.GetFrom() != nil:
+	case ranged:
+		// the euro reference rates start in 1999
+		from = eurofxref.PublicationDate(1999, 1, 1)
+	default:
+		// the last 90 days
+		from = to.AddDate(0, 0, -90)
+	}
+
+	// a discontinued currency is answered until its last publication
+	if err := s.Source.ValidateHistoricalCurrency(req.GetCurrency(), from); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	var series *eurofxref.Series
+	var err error
+	if ranged {
+		series, err = s.Source.HistoryRangeContext(ctx, req.GetCurrency(), from, to)
+	} else {
+		series, err = s.Source.HistoryContext(ctx, req.GetCurrency())
+	}
+	if err != nil {
+		return nil, status.Error(codes.Unavailable, err.Error())
+	}
+
+	resp := &ratespb.GetHistoryResponse{Currency: series.Currency}
+	for _, point := range series.Points {
+		resp.Rates = append(resp.Rates, &ratespb.Rate{
+			Currency: series.Currency,
+			Date:     dateTimestamp(point.Date),
+			Rate:     point.Rate,
+		})
+	}
+
+	return resp, nil
+}
+
+func (s *Server) Convert(ctx context.Context, req *ratespb.ConvertRequest) (*ratespb.ConvertResponse, error) {
+
+	for _, currencyCode := range []string{req.GetFrom(), req.GetTo()} {
+		if err := s.validate(currencyCode); err != nil {
+			return nil, err
+		}
+	}
+
+	result, err := s.Source.ConvertContext(ctx, req.GetAmount(), req.GetFrom(), req.GetTo())
+	if err != nil {
+		return nil, status.Error(codes.Unavailable, err.Error())
+	}
+
+	return &ratespb.ConvertResponse{
+		From:   result.From,
+		To:     result.To,
+		Amount: result.Amount,
+		Rate:   result.Rate,
+		Value:  result.Value,
+		Date:   dateTimestamp(result.LastUpdate),
+	}, nil
+}
+
+// dateTimestamp returns the timestamp of a publication date at midnight
+// UTC, so that the calendar day survives the conversion to UTC of the
+// protobuf timestamps.
+func dateTimestamp(date time.Time) *timestamppb.Timestamp {
+	return timestamppb.New(time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC))
+}
@@ -0,0 +1,133 @@
+//
+// Copyright 2023 The GoEurofxref Authors. All rights reserved.
+// Use of this source code is governed by a MIT License
+// license that can be found in the LICENSE file.
+// Last Modification: 2026-10-15 14:30:00
+//
+
+package rpc
+
+import (
+	"context"
+	"math"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	eurofxref "github.com/mrhdias/go-eurofxref"
+	"github.com/mrhdias/go-eurofxref/rpc/ratespb"
+	"github.com/mrhdias/go-eurofxref/server"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func newTestClient(t *testing.T, metrics *server.Metrics) ratespb.RatesServiceClient {
+
+	ecb := httptest.NewServer(http.FileServer(http.Dir("../testdata")))
+	t.Cleanup(ecb.Close)
+
+	source := eurofxref.New("", false)
+	source.CacheDir = ""
+	source.Url = ecb.URL + "/eurofxref-daily.xml"
+	source.Hist90Url = ecb.URL + "/eurofxref-hist-90d.xml"
+	source.HistUrl = ecb.URL + "/eurofxref-hist.xml"
+
+	listener := bufconn.Listen(1 << 20)
+	grpcServer := grpc.NewServer(grpc.UnaryInterceptor(UnaryInterceptor(nil, metrics)))
+	New(source).Register(grpcServer)
+	go grpcServer.Serve(listener)
+	t.Cleanup(grpcServer.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	return ratespb.NewRatesServiceClient(conn)
+}
+
+func TestRatesService(t *testing.T) {
+
+	metrics := server.NewMetrics()
+	client := newTestClient(t, metrics)
+	ctx := context.Background()
+
+	rate, err := client.GetDaily(ctx, &ratespb.GetDailyRequest{Currency: "usd"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rate.GetCurrency() != "USD" || rate.GetRate() != 1.0876 {
+		t.Errorf("got %v, want USD 1.0876", rate)
+	}
+
+	_, err = client.GetDaily(ctx, &ratespb.GetDailyRequest{Currency: "XXX"})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("got %v for an unknown currency, want InvalidArgument", err)
+	}
+
+	history, err := client.GetHistory(ctx, &ratespb.GetHistoryRequest{Currency: "GBP"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(history.GetRates()) != 10 {
+		t.Errorf("got %d historical rates, want 10", len(history.GetRates()))
+	}
+
+	// a discontinued currency until its last publication
+	_, err = client.GetHistory(ctx, &ratespb.GetHistoryRequest{Currency: "HRK",
+		From: timestamppb.New(eurofxref.PublicationDate(2022, 12, 1)),
+		To:   timestamppb.New(eurofxref.PublicationDate(2022, 12, 30))})
+	if status.Code(err) == codes.InvalidArgument {
+		t.Errorf("got %v for the last rates of HRK", err)
+	}
+	_, err = client.GetHistory(ctx, &ratespb.GetHistoryRequest{Currency: "HRK",
+		From: timestamppb.New(eurofxref.PublicationDate(2023, 1, 2))})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("got %v for HRK after its last publication, want InvalidArgument", err)
+	}
+
+	conversion, err := client.Convert(ctx, &ratespb.ConvertRequest{Amount: 100, From: "EUR", To: "USD"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if math.Abs(conversion.GetValue()-108.76) > 1e-9 {
+		t.Errorf("got converted value %v, want 108.76", conversion.GetValue())
+	}
+
+	stats := metrics.Snapshot()[ratespb.RatesService_GetDaily_FullMethodName]
+	if stats.Count != 2 || stats.Errors != 0 {
+		t.Errorf("got GetDaily stats %+v, want 2 calls and no errors", stats)
+	}
+}
+
+func TestAPIKeyInterceptor(t *testing.T) {
+
+	keys := server.NewAPIKeys(map[string]string{"k1": "billing"}, 0)
+	interceptor := APIKeyInterceptor(keys)
+	info := &grpc.UnaryServerInfo{FullMethod: ratespb.RatesService_GetDaily_FullMethodName}
+	handler := func(ctx context.Context, req any) (any, error) { return "ok", nil }
+
+	_, err := interceptor(context.Background(), nil, info, handler)
+	if status.Code(err) != codes.Unauthenticated {
+		t.Errorf("got %v without a key, want Unauthenticated", err)
+	}
+
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("x-api-key", "k1"))
+	if _, err := interceptor(ctx, nil, info, handler); err != nil {
+		t.Fatal(err)
+	}
+	if usage := keys.Usage(); usage[0].Requests != 1 {
+		t.Errorf("got %d requests, want 1", usage[0].Requests)
+	}
+}
@@ -0,0 +1,199 @@
+//
+// Copyright 2023 The GoEurofxref Authors. All rights reserved.
+// Use of this source code is governed by a MIT License
+// license that can be found in the LICENSE file.
+// Last Modification: 2026-10-14 19:32:56
+//
+
+package eurofxref
+
+import (
+	"time"
+	_ "time/tzdata" // the publication schedule is in Europe/Berlin time
+)
+
+// PublicationHour is the hour, in Central European Time, after which the
+// reference rates of the day are usually published by the ECB.
+const PublicationHour = 16
+
+// CET is the Central European Time zone (CET/CEST) of the publications.
+var CET = func() *time.Location {
+	location, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		return time.FixedZone("CET", 3600)
+	}
+	return location
+}()
+
+// PublicationDate returns the date of a publication, at midnight in CET
+// like the dates of the rates, so that it is the same calendar day in
+// the time zone of the ECB.
+func PublicationDate(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, CET)
+}
+
+// parseDate parses a publication date written as "2006-01-02".
+func parseDate(value string) (time.Time, error) {
+	return time.ParseInLocation("2006-01-02", value, CET)
+}
+
+// easter returns the date of Easter Sunday of a year in the Gregorian
+// calendar (anonymous Gregorian algorithm).
+func easter(year int) time.Time {
+
+	a := year % 19
+	b, c := year/100, year%100
+	d, e := b/4, b%4
+	f := (b + 8) / 25
+	g := (b - f + 1) / 3
+	h := (19*a + b - d - g + 15) % 30
+	i, k := c/4, c%4
+	l := (32 + 2*e + 2*i - h - k) % 7
+	m := (a + 11*h + 22*l) / 451
+	month := (h + l - 7*m + 114) / 31
+	day := (h+l-7*m+114)%31 + 1
+
+	return PublicationDate(year, time.Month(month), day)
+}
+
+// IsTargetHoliday reports whether the date is a closing day of the TARGET
+// system, when no reference rates are published: New Year's Day, Good
+// Friday, Easter Monday, Labour Day, Christmas Day and 26 December.
+func IsTargetHoliday(date time.Time) bool {
+
+	month, day := date.Month(), date.Day()
+	switch {
+	case month == time.January && day == 1,
+		month == time.May && day == 1,
+		month == time.December && (day == 25 || day == 26):
+		return true
+	}
+
+	sunday := easter(date.Year())
+	d := PublicationDate(date.Year(), month, day)
+
+	return d.Equal(sunday.AddDate(0, 0, -2)) || d.Equal(sunday.AddDate(0, 0, 1))
+}
+
+// IsPublicationDay reports whether reference rates are published on the
+// date: every weekday except the TARGET holidays.
+func IsPublicationDay(date time.Time) bool {
+
+	if weekday := date.Weekday(); weekday == time.Saturday || weekday == time.Sunday {
+		return false
+	}
+
+	return !IsTargetHoliday(date)
+}
+
+// PreviousPublicationDate returns the date of the most recent publication
+// that should be out at now: today after 16:00 CET on publication days,
+// the previous publication day otherwise. The date is at midnight CET,
+// like the publication dates of the rates.
+func PreviousPublicationDate(now time.Time) time.Time {
+
+	now = now.In(CET)
+	day := PublicationDate(now.Year(), now.Month(), now.Day())
+	if now.Hour() < PublicationHour {
+		day = day.AddDate(0, 0, -1)
+	}
+	for !IsPublicationDay(day) {
+		day = day.AddDate(0, 0, -1)
+	}
+
+	return day
+}
+
+// NextPublicationTime returns the time after now when the next
+// publication is expected, 16:00 CET of the next publication day.
+func NextPublicationTime(now time.Time) time.Time {
+
+	now = now.In(CET)
+	next := time.Date(now.Year(), now.Month(), now.Day(), PublicationHour, 0, 0, 0, CET)
+	if !next.After(now) {
+		next = next.AddDate(0, 0, 1)
+	}
+	for !IsPublicationDay(next) {
+		next = next.AddDate(0, 0, 1)
+	}
+
+	return next
+}
+
+// Freshness is the state of a publication relative to the schedule of
+// the ECB.
+type Freshness int
+
+const (
+	// Fresh is the most recent publication that should be out.
+	Fresh Freshness = iota
+	// AwaitingToday is the previous publication, as expected on a
+	// publication day before 16:00 CET, when the rates of the day are
+	// not out yet.
+	AwaitingToday
+	// Stale is older than the publication that should be out, e.g. a
+	// cached file that could not be refreshed.
+	Stale
+)
+
+func (freshness Freshness) String() string {
+
+	switch freshness {
+	case Fresh:
+		return "fresh"
+	case AwaitingToday:
+		return "awaiting today"
+	case Stale:
+		return "stale"
+	}
+
+	return "unknown"
+}
+
+// PublicationFreshness returns the freshness at now of the publication
+// of date.
+func PublicationFreshness(date, now time.Time) Freshness {
+
+	date = truncateDay(date)
+	if date.Before(PreviousPublicationDate(now)) {
+		return Stale
+	}
+
+	cet := now.In(CET)
+	today := PublicationDate(cet.Year(), cet.Month(), cet.Day())
+	if date.Before(today) && IsPublicationDay(today) && cet.Hour() < PublicationHour {
+		return AwaitingToday
+	}
+
+	return Fresh
+}
+
+// Freshness returns the freshness at now of the publication of the
+// result. Before 16:00 CET the daily file still carries the rates of the
+// previous publication day, which is AwaitingToday rather than Stale.
+func (result *QueryResult) Freshness(now time.Time) Freshness {
+	return PublicationFreshness(result.LastUpdate, now)
+}
+
+// Freshness returns the freshness at now of the publication of the
+// table.
+func (table *RateTable) Freshness(now time.Time) Freshness {
+	return PublicationFreshness(table.Date, now)
+}
+
+// NextUpdate returns the time when the next publication is expected at
+// the time of the Clock of the client, 16:00 CET of the next publication
+// day, for the schedulers to sleep until then rather than poll.
+func (efr EuroFxRef) NextUpdate() time.Time {
+	return NextPublicationTime(efr.Now())
+}
+
+// NextUpdate returns the time when the publication following the table
+// is expected. It is in the past once the table is superseded, when the
+// rates should be refreshed without waiting.
+func (table *RateTable) NextUpdate() time.Time {
+
+	date := truncateDay(table.Date)
+
+	return NextPublicationTime(time.Date(date.Year(), date.Month(), date.Day(), PublicationHour, 0, 0, 0, CET))
+}
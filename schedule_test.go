@@ -0,0 +1,168 @@
+//
+// Copyright 2023 The GoEurofxref Authors. All rights reserved.
+// Use of this source code is governed by a MIT License
+// license that can be found in the LICENSE file.
+// Last Modification: 2026-10-14 19:32:56
+//
+
+package eurofxref
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTargetHolidays(t *testing.T) {
+
+	holidays := []string{
+		"2024-01-01", "2024-03-29", "2024-04-01", "2024-05-01", "2024-12-25", "2024-12-26",
+		"2025-04-18", "2025-04-21", "2019-04-19", "2019-04-22",
+	}
+	for _, day := range holidays {
+		date, _ := parseDate(day)
+		if !IsTargetHoliday(date) || IsPublicationDay(date) {
+			t.Errorf("%s is a TARGET holiday", day)
+		}
+	}
+
+	for _, day := range []string{"2024-03-28", "2024-04-02", "2024-12-24", "2024-12-27"} {
+		date, _ := parseDate(day)
+		if IsTargetHoliday(date) || !IsPublicationDay(date) {
+			t.Errorf("%s is a publication day", day)
+		}
+	}
+}
+
+func TestPublicationSchedule(t *testing.T) {
+
+	tests := []struct {
+		now      string
+		previous string
+		next     string
+	}{
+		// Friday before and after the publication
+		{"2024-03-01T15:59:00+01:00", "2024-02-29", "2024-03-01T16:00:00+01:00"},
+		{"2024-03-01T16:00:00+01:00", "2024-03-01", "2024-03-04T16:00:00+01:00"},
+		// weekend
+		{"2024-03-03T12:00:00+01:00", "2024-03-01", "2024-03-04T16:00:00+01:00"},
+		// Monday morning, in UTC
+		{"2024-03-04T08:00:00Z", "2024-03-01", "2024-03-04T16:00:00+01:00"},
+		// summer time
+		{"2024-07-01T14:30:00Z", "2024-07-01", "2024-07-02T16:00:00+02:00"},
+		// Easter: Thursday evening to Tuesday, across the DST change
+		{"2024-03-28T17:00:00+01:00", "2024-03-28", "2024-04-02T16:00:00+02:00"},
+		{"2024-04-01T12:00:00+02:00", "2024-03-28", "2024-04-02T16:00:00+02:00"},
+		// Christmas and New Year
+		{"2024-12-24T18:00:00+01:00", "2024-12-24", "2024-12-27T16:00:00+01:00"},
+		{"2025-01-01T10:00:00+01:00", "2024-12-31", "2025-01-02T16:00:00+01:00"},
+		// just after midnight in Berlin, still the previous day in UTC
+		{"2024-03-04T23:30:00Z", "2024-03-04", "2024-03-05T16:00:00+01:00"},
+	}
+
+	for _, tt := range tests {
+		now, err := time.Parse(time.RFC3339, tt.now)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := PreviousPublicationDate(now).Format("2006-01-02"); got != tt.previous {
+			t.Errorf("PreviousPublicationDate(%s) = %s, want %s", tt.now, got, tt.previous)
+		}
+		if got := NextPublicationTime(now).Format(time.RFC3339); got != tt.next {
+			t.Errorf("NextPublicationTime(%s) = %s, want %s", tt.now, got, tt.next)
+		}
+	}
+}
+
+func TestPublicationFreshness(t *testing.T) {
+
+	tests := []struct {
+		date string
+		now  string
+		want Freshness
+	}{
+		// Friday morning: Thursday's rates are expected
+		{"2024-02-29", "2024-03-01T10:00:00+01:00", AwaitingToday},
+		{"2024-02-28", "2024-03-01T10:00:00+01:00", Stale},
+		// Friday evening
+		{"2024-02-29", "2024-03-01T16:30:00+01:00", Stale},
+		{"2024-03-01", "2024-03-01T16:30:00+01:00", Fresh},
+		// out a little early
+		{"2024-03-01", "2024-03-01T15:58:00+01:00", Fresh},
+		// weekend and Easter Monday mornings
+		{"2024-03-01", "2024-03-03T10:00:00+01:00", Fresh},
+		{"2024-03-28", "2024-04-01T10:00:00+02:00", Fresh},
+		// Tuesday after Easter, before the publication
+		{"2024-03-28", "2024-04-02T10:00:00+02:00", AwaitingToday},
+	}
+
+	for _, tt := range tests {
+		date, _ := parseDate(tt.date)
+		now, err := time.Parse(time.RFC3339, tt.now)
+		if err != nil {
+			t.Fatal(err)
+		}
+		result := &QueryResult{LastUpdate: date}
+		if got := result.Freshness(now); got != tt.want {
+			t.Errorf("publication of %s at %s is %v, want %v", tt.date, tt.now, got, tt.want)
+		}
+	}
+}
+
+func TestPublicationDateZone(t *testing.T) {
+
+	_, query := newTestServer(t)
+
+	table, err := query.DailyRates()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if table.Date.Location() != CET || !table.Date.Equal(PublicationDate(2024, 3, 1)) {
+		t.Errorf("got the publication date %v, want 2024-03-01 at midnight CET", table.Date)
+	}
+
+	// past 23:00 UTC it is already the next day in Frankfurt
+	now := time.Date(2024, 3, 4, 23, 30, 0, 0, time.UTC)
+	if today := truncateDay(now.In(CET)); !today.Equal(PublicationDate(2024, 3, 5)) {
+		t.Errorf("got today %v, want 2024-03-05", today)
+	}
+
+	// the dates given in another time zone are taken as calendar dates
+	if date := truncateDay(time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)); !date.Equal(table.Date) {
+		t.Errorf("got %v, want the date of the publication", date)
+	}
+}
+
+func TestNextUpdate(t *testing.T) {
+
+	tests := []struct {
+		now  string
+		want string
+	}{
+		{"2024-03-01T10:00:00+01:00", "2024-03-01T16:00:00+01:00"},
+		// Friday evening: Monday
+		{"2024-03-01T16:00:00+01:00", "2024-03-04T16:00:00+01:00"},
+		// Thursday before Easter: Tuesday, after the switch to summer time
+		{"2024-03-28T17:00:00+01:00", "2024-04-02T16:00:00+02:00"},
+		// just before midnight UTC, already Saturday in Frankfurt
+		{"2024-03-01T23:30:00Z", "2024-03-04T16:00:00+01:00"},
+	}
+
+	for _, tt := range tests {
+		now, _ := time.Parse(time.RFC3339, tt.now)
+		want, _ := time.Parse(time.RFC3339, tt.want)
+		query := EuroFxRef{Clock: FixedClock(now)}
+		if got := query.NextUpdate(); !got.Equal(want) {
+			t.Errorf("at %s got the next update at %v, want %s", tt.now, got, tt.want)
+		}
+	}
+
+	// the table of Thursday is superseded on Friday at 16:00
+	table := &RateTable{Date: PublicationDate(2024, 2, 29)}
+	if got, want := table.NextUpdate(), time.Date(2024, 3, 1, 16, 0, 0, 0, CET); !got.Equal(want) {
+		t.Errorf("got the next update of the table at %v, want %v", got, want)
+	}
+	table = &RateTable{Date: PublicationDate(2024, 3, 28)}
+	if got, want := table.NextUpdate(), time.Date(2024, 4, 2, 16, 0, 0, 0, CET); !got.Equal(want) {
+		t.Errorf("got the next update of the table at %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,192 @@
+//
+// Copyright 2023 The GoEurofxref Authors. All rights reserved.
+// Use of this source code is governed by a MIT License
+// license that can be found in the LICENSE file.
+// Last Modification: 2026-10-15 14:15:00
+//
+
+package eurofxref
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+// refreshRetryInterval is the wait between two refreshes while the
+// expected publication is not out yet or the download fails.
+const refreshRetryInterval = 5 * time.Minute
+
+// state is the mutable part of a client.
+type state struct {
+	mu     sync.RWMutex
+	table  *RateTable
+	cancel context.CancelFunc
+	done   chan struct{}
+
+	subMu       sync.Mutex
+	published   *RateTable // last publication observed
+	subscribers map[chan Publication]struct{}
+
+	mirrorMu sync.Mutex
+	mirrors  map[string]*mirrorHealth // by host
+
+	breakerMu sync.Mutex
+	breaker   breaker
+
+	budgetMu sync.Mutex
+	fetches  []time.Time // of the last day, the oldest first
+
+	memoMu sync.Mutex
+	memo   map[string]memoEntry // parsed daily files
+
+	cacheMu     sync.Mutex
+	cacheHits   int64
+	cacheMisses int64
+
+	transports transports // of the requests
+
+	currencyMu sync.RWMutex
+	currencies map[string]void // of the daily files read
+	overrides  map[string]bool // of AddCurrency (true) and RemoveCurrency
+	accepted   map[string]void // the currencies with the overrides
+}
+
+// warmTable returns the table kept by the background refresh, if it is
+// running and has loaded the rates.
+func (s *state) warmTable() (*RateTable, bool) {
+
+	if s == nil {
+		return nil, false
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.table, s.table != nil && s.cancel != nil
+}
+
+// dropTable discards the table kept by the background refresh, loaded
+// again by its next run.
+func (s *state) dropTable() {
+
+	if s == nil {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.table = nil
+}
+
+// Start loads the latest rates and keeps them refreshed in memory by a
+// background worker until ctx is done or Stop is called. While it runs,
+// Daily and DailyRates answer from memory without blocking on the
+// network.
+//
+// The worker sleeps until the next publication is expected and then
+// polls every few minutes until it is out. The error of the first load
+// is returned, but the worker keeps retrying.
+func (efr EuroFxRef) Start(ctx context.Context) error {
+
+	if efr.state == nil {
+		return errors.New("the client must be created with New to be started")
+	}
+
+	efr.state.mu.Lock()
+	if efr.state.cancel != nil {
+		efr.state.mu.Unlock()
+		return errors.New("the background refresh is already running")
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	done := make(chan struct{})
+	efr.state.cancel = cancel
+	efr.state.done = done
+	efr.state.mu.Unlock()
+
+	err := efr.refresh(ctx)
+
+	go func() {
+		defer close(done)
+		// when ctx is done rather than stopped, so the rates are fetched
+		// on demand again and the refresh can be started anew
+		defer func() {
+			efr.state.mu.Lock()
+			if efr.state.done == done {
+				efr.state.cancel, efr.state.done = nil, nil
+			}
+			if efr.state.cancel == nil {
+				efr.state.table = nil
+			}
+			efr.state.mu.Unlock()
+			cancel()
+		}()
+
+		for {
+			timer := time.NewTimer(efr.nextRefresh(efr.Now(), err == nil))
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return
+			case <-timer.C:
+			}
+			err = efr.refresh(ctx)
+		}
+	}()
+
+	return err
+}
+
+// Stop stops the background refresh started by Start and waits for it to
+// exit. The rates are fetched on demand again afterwards.
+func (efr EuroFxRef) Stop() {
+
+	if efr.state == nil {
+		return
+	}
+
+	efr.state.mu.Lock()
+	cancel, done := efr.state.cancel, efr.state.done
+	efr.state.cancel, efr.state.done = nil, nil
+	efr.state.mu.Unlock()
+
+	if cancel != nil {
+		cancel()
+		<-done
+	}
+}
+
+// refresh downloads the daily file and replaces the in-memory table.
+func (efr EuroFxRef) refresh(ctx context.Context) error {
+
+	table, err := efr.fetchDailyRates(ctx, true)
+	if err != nil {
+		efr.logger().Warn("refresh failed", slog.Any("error", err))
+		return err
+	}
+
+	efr.state.mu.Lock()
+	efr.state.table = table
+	efr.state.mu.Unlock()
+
+	efr.debug(DebugRequests, "refreshed", slog.String("publication", table.Date.Format("2006-01-02")))
+
+	return nil
+}
+
+// nextRefresh returns the wait before the next refresh.
+func (efr EuroFxRef) nextRefresh(now time.Time, lastOk bool) time.Duration {
+
+	efr.state.mu.RLock()
+	table := efr.state.table
+	efr.state.mu.RUnlock()
+
+	if !lastOk || table == nil || table.Date.Before(PreviousPublicationDate(now)) {
+		return refreshRetryInterval
+	}
+
+	return NextPublicationTime(now).Sub(now)
+}
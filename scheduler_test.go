@@ -0,0 +1,85 @@
+//
+// Copyright 2023 The GoEurofxref Authors. All rights reserved.
+// Use of this source code is governed by a MIT License
+// license that can be found in the LICENSE file.
+// Last Modification: 2026-10-15 10:05:00
+//
+
+package eurofxref
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestStartStop(t *testing.T) {
+
+	ts, query := newTestServer(t)
+
+	if err := query.Start(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if err := query.Start(context.Background()); err == nil {
+		t.Error("expected an error starting twice")
+	}
+
+	// the lookups are answered from memory
+	ts.Close()
+	result, err := query.Daily("USD")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.RateValue != 1.0876 {
+		t.Errorf("got %v, want 1.0876", result.RateValue)
+	}
+
+	query.Stop()
+	if _, err := query.Daily("USD"); err == nil {
+		t.Error("expected an error once stopped, as the server is closed")
+	}
+}
+
+func TestStartContextDone(t *testing.T) {
+
+	var requests atomic.Int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		http.ServeFile(w, r, "testdata/eurofxref-daily.xml")
+	}))
+	defer ts.Close()
+
+	query := New("", false)
+	query.CacheDir = ""
+	query.Url = ts.URL + "/eurofxref-daily.xml"
+
+	ctx, cancel := context.WithCancel(context.Background())
+	if err := query.Start(ctx); err != nil {
+		t.Fatal(err)
+	}
+	cancel()
+
+	// the worker exits in the background
+	deadline := time.Now().Add(5 * time.Second)
+	for _, running := query.state.warmTable(); running; _, running = query.state.warmTable() {
+		if time.Now().After(deadline) {
+			t.Fatal("the in-memory rates are still served after the context is done")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	if _, err := query.DailyRates(); err != nil {
+		t.Fatal(err)
+	}
+	if got := requests.Load(); got != 2 {
+		t.Errorf("got %d requests, want the rates fetched again", got)
+	}
+
+	if err := query.Start(context.Background()); err != nil {
+		t.Errorf("could not start again: %v", err)
+	}
+	query.Stop()
+}
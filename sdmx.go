@@ -0,0 +1,121 @@
+//
+// Copyright 2023 The GoEurofxref Authors. All rights reserved.
+// Use of this source code is governed by a MIT License
+// license that can be found in the LICENSE file.
+// Last Modification: 2026-10-14 19:32:21
+//
+
+package eurofxref
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+type sdmxValue struct {
+	Id    string `xml:"id,attr"`
+	Value string `xml:"value,attr"`
+}
+
+type sdmxObs struct {
+	Dimension sdmxValue `xml:"ObsDimension"`
+	Value     sdmxValue `xml:"ObsValue"`
+}
+
+type sdmxSeries struct {
+	Key []sdmxValue `xml:"SeriesKey>Value"`
+	Obs []sdmxObs   `xml:"Obs"`
+}
+
+func (series sdmxSeries) dimension(id string) string {
+
+	for _, value := range series.Key {
+		if value.Id == id {
+			return value.Value
+		}
+	}
+
+	return ""
+}
+
+// DecodeSDMX decodes an SDMX-ML 2.1 generic data message of the EXR
+// dataflow, as returned by the ECB Data API with format=genericdata, into
+// series indexed by currency code. Only the series quoted against the
+// euro are kept, and observations without a value are skipped. The
+// series are decoded one at a time from r.
+func DecodeSDMX(r io.Reader) (map[string]*Series, error) {
+
+	decoder := xml.NewDecoder(r)
+	found := false
+	result := map[string]*Series{}
+
+	for {
+		token, err := decoder.Token()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("error when decoding the SDMX-ML data: %v", err)
+		}
+
+		start, ok := token.(xml.StartElement)
+		if !ok {
+			continue
+		}
+		if start.Name.Local == "GenericData" {
+			found = true
+		}
+		if start.Name.Local != "Series" {
+			continue
+		}
+
+		var series sdmxSeries
+		if err := decoder.DecodeElement(&series, &start); err != nil {
+			return nil, fmt.Errorf("error when decoding the SDMX-ML data: %v", err)
+		}
+		if denom := series.dimension("CURRENCY_DENOM"); denom != "" && denom != "EUR" {
+			continue
+		}
+		currencyCode := strings.ToUpper(series.dimension("CURRENCY"))
+		if currencyCode == "" {
+			return nil, errors.New("error when decoding the SDMX-ML data: a series has no CURRENCY")
+		}
+
+		s, ok := result[currencyCode]
+		if !ok {
+			s = &Series{Currency: currencyCode, Points: []Point{}}
+			result[currencyCode] = s
+		}
+		for _, obs := range series.Obs {
+			if obs.Value.Value == "" || obs.Value.Value == "NaN" {
+				continue
+			}
+			rateValue, err := strconv.ParseFloat(obs.Value.Value, 64)
+			if err != nil {
+				return nil, fmt.Errorf("error when convert rate string from sdmx to float: %v", err)
+			}
+			date, err := parseDate(obs.Dimension.Value)
+			if err != nil {
+				return nil, fmt.Errorf("invalid time period \"%s\": %v", obs.Dimension.Value, err)
+			}
+			s.Points = append(s.Points, Point{Date: date, Rate: rateValue})
+		}
+	}
+
+	if !found {
+		return nil, errors.New("error when decoding the SDMX-ML data: no GenericData element")
+	}
+
+	for _, s := range result {
+		sort.Slice(s.Points, func(i, j int) bool {
+			return s.Points[i].Date.Before(s.Points[j].Date)
+		})
+	}
+
+	return result, nil
+}
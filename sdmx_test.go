@@ -0,0 +1,86 @@
+//
+// Copyright 2023 The GoEurofxref Authors. All rights reserved.
+// Use of this source code is governed by a MIT License
+// license that can be found in the LICENSE file.
+// Last Modification: 2026-10-14 18:25:12
+//
+
+package eurofxref
+
+import (
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestDecodeSDMX(t *testing.T) {
+
+	f, err := os.Open("testdata/exr-gbp-usd.xml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	series, err := DecodeSDMX(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	contentBytes, err := os.ReadFile("testdata/exr-gbp-usd.csv")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want, err := decodeDataAPI(contentBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(series) != 2 {
+		t.Fatalf("got %d series, want 2", len(series))
+	}
+	for currencyCode, s := range want {
+		got := series[currencyCode]
+		if got == nil || len(got.Points) != len(s.Points) {
+			t.Fatalf("%s: got %v, want %v", currencyCode, got, s)
+		}
+		for i := range s.Points {
+			if !got.Points[i].Date.Equal(s.Points[i].Date) || got.Points[i].Rate != s.Points[i].Rate {
+				t.Errorf("%s point %d: got %+v, want %+v", currencyCode, i, got.Points[i], s.Points[i])
+			}
+		}
+	}
+}
+
+func TestDecodeSDMXErrors(t *testing.T) {
+
+	tests := []string{
+		`<Envelope/>`,
+		`<message:GenericData xmlns:message="m" xmlns:generic="g"><generic:Series>` +
+			`<generic:Obs><generic:ObsDimension value="2024-03-01"/><generic:ObsValue value="1.08"/></generic:Obs>` +
+			`</generic:Series></message:GenericData>`,
+		`<message:GenericData xmlns:message="m" xmlns:generic="g"><generic:Series>` +
+			`<generic:SeriesKey><generic:Value id="CURRENCY" value="USD"/></generic:SeriesKey>` +
+			`<generic:Obs><generic:ObsDimension value="2024-03-01"/><generic:ObsValue value="x"/></generic:Obs>` +
+			`</generic:Series></message:GenericData>`,
+		`<message:GenericData`,
+	}
+
+	for _, data := range tests {
+		if _, err := DecodeSDMX(strings.NewReader(data)); err == nil {
+			t.Errorf("expected an error for %s", data)
+		}
+	}
+
+	series, err := DecodeSDMX(strings.NewReader(`<message:GenericData xmlns:message="m" xmlns:generic="g">` +
+		`<generic:Series><generic:SeriesKey><generic:Value id="CURRENCY" value="USD"/>` +
+		`<generic:Value id="CURRENCY_DENOM" value="GBP"/></generic:SeriesKey></generic:Series>` +
+		`<generic:Series><generic:SeriesKey><generic:Value id="CURRENCY" value="JPY"/></generic:SeriesKey>` +
+		`<generic:Obs><generic:ObsDimension value="2024-03-01"/><generic:ObsValue value="NaN"/></generic:Obs>` +
+		`</generic:Series></message:GenericData>`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := series["USD"]; ok || len(series["JPY"].Points) != 0 {
+		t.Errorf("unexpected series %v", series)
+	}
+}
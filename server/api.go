@@ -0,0 +1,116 @@
+//
+// Copyright 2023 The GoEurofxref Authors. All rights reserved.
+// Use of this source code is governed by a MIT License
+// license that can be found in the LICENSE file.
+// Last Modification: 2026-10-15 11:55:00
+//
+
+package server
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"strings"
+)
+
+type latestResponse struct {
+	Base  string             `json:"base"`
+	Date  string             `json:"date"`
+	Rates map[string]float64 `json:"rates"`
+}
+
+type rateResponse struct {
+	Base     string  `json:"base"`
+	Currency string  `json:"currency"`
+	Date     string  `json:"date"`
+	Rate     float64 `json:"rate"`
+}
+
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	// the status is sent, a failed write is the client's
+	json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, errorResponse{Error: message})
+}
+
+// handleRates routes the /rates/latest, /rates/{date}, /rates/{currency}
+// and /rates/{currency}/history endpoints.
+func (s *Server) handleRates(w http.ResponseWriter, r *http.Request) {
+
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		w.Header().Set("Allow", "GET, HEAD")
+		writeError(w, http.StatusMethodNotAllowed, http.StatusText(http.StatusMethodNotAllowed))
+		return
+	}
+
+	name := strings.TrimPrefix(r.URL.Path, "/rates/")
+	if currencyCode, ok := strings.CutSuffix(name, "/history"); ok && currencyCode != "" &&
+		!strings.Contains(currencyCode, "/") {
+		s.handleHistory(w, r, currencyCode)
+		return
+	}
+	if name == "" || strings.Contains(name, "/") {
+		writeError(w, http.StatusNotFound, http.StatusText(http.StatusNotFound))
+		return
+	}
+
+	if name == "latest" {
+		s.handleLatest(w, r)
+		return
+	}
+	if date, ok := parseDate(name); ok {
+		s.handleDate(w, r, date)
+		return
+	}
+
+	s.handleCurrency(w, r, name)
+}
+
+func (s *Server) handleLatest(w http.ResponseWriter, r *http.Request) {
+
+	table, err := s.Source.DailyRatesContext(r.Context())
+	if err != nil {
+		s.logger().Error("handling the request failed", slog.String("path", r.URL.Path), slog.Any("error", err))
+		writeError(w, http.StatusBadGateway, "could not get the reference rates")
+		return
+	}
+
+	s.writeCached(w, r, table.Date, false, latestResponse{
+		Base:  "EUR",
+		Date:  table.Date.Format("2006-01-02"),
+		Rates: table.Rates,
+	})
+}
+
+func (s *Server) handleCurrency(w http.ResponseWriter, r *http.Request, currencyCode string) {
+
+	currencyCode = strings.ToUpper(currencyCode)
+	if err := s.Source.ValidateCurrencyCode(currencyCode); err != nil && currencyCode != "EUR" {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	result, err := s.Source.DailyContext(r.Context(), currencyCode)
+	if err != nil {
+		s.logger().Error("handling the request failed", slog.String("path", r.URL.Path), slog.Any("error", err))
+		writeError(w, http.StatusBadGateway, "could not get the reference rate")
+		return
+	}
+
+	s.writeCached(w, r, result.LastUpdate, false, rateResponse{
+		Base:     "EUR",
+		Currency: currencyCode,
+		Date:     result.LastUpdate.Format("2006-01-02"),
+		Rate:     result.RateValue,
+	})
+}
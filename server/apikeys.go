@@ -0,0 +1,183 @@
+//
+// Copyright 2023 The GoEurofxref Authors. All rights reserved.
+// Use of this source code is governed by a MIT License
+// license that can be found in the LICENSE file.
+// Last Modification: 2026-10-14 17:57:51
+//
+
+package server
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// KeyUsage is the usage report of an API key.
+type KeyUsage struct {
+	Name      string    `json:"name"`
+	Requests  uint64    `json:"requests"`
+	Today     uint64    `json:"today"`
+	OverQuota uint64    `json:"over_quota"`
+	LastUsed  time.Time `json:"last_used"`
+	day       string
+}
+
+// APIKeys authenticates requests by API key and accounts their usage.
+//
+// Quota is a soft daily limit of requests per key: the requests above it
+// are still served but counted as over quota and flagged to the client,
+// which is enough for chargeback and for spotting the noisiest consumers.
+// A zero Quota disables the limit.
+type APIKeys struct {
+	Quota uint64
+	mu    sync.Mutex
+	names map[string]string
+	usage map[string]*KeyUsage
+	now   func() time.Time
+}
+
+// NewAPIKeys returns the accounting of the keys, a map from the key to
+// the name of its owner.
+func NewAPIKeys(keys map[string]string, quota uint64) *APIKeys {
+
+	apiKeys := &APIKeys{
+		Quota: quota,
+		names: make(map[string]string, len(keys)),
+		usage: make(map[string]*KeyUsage, len(keys)),
+		now:   time.Now,
+	}
+
+	for key, name := range keys {
+		apiKeys.names[key] = name
+		apiKeys.usage[key] = &KeyUsage{Name: name}
+	}
+
+	return apiKeys
+}
+
+// ReadAPIKeys reads the keys from r, one "name:key" pair per line. Blank
+// lines and lines starting with # are ignored.
+func ReadAPIKeys(r io.Reader) (map[string]string, error) {
+
+	keys := map[string]string{}
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		name, key, ok := strings.Cut(text, ":")
+		if !ok || strings.TrimSpace(key) == "" {
+			return nil, fmt.Errorf("line %d: expected name:key", line)
+		}
+		keys[strings.TrimSpace(key)] = strings.TrimSpace(name)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("error reading the api keys: %v", err)
+	}
+
+	return keys, nil
+}
+
+// Use records a request made with key. It reports whether the key is
+// known and the number of requests left in the daily quota, which is
+// negative once the quota is exceeded.
+func (k *APIKeys) Use(key string) (ok bool, remaining int64) {
+
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	usage, ok := k.usage[key]
+	if !ok {
+		return false, 0
+	}
+
+	now := k.now()
+	if day := now.UTC().Format("2006-01-02"); usage.day != day {
+		usage.day = day
+		usage.Today = 0
+	}
+
+	usage.Requests++
+	usage.Today++
+	usage.LastUsed = now
+
+	if k.Quota == 0 {
+		return true, 0
+	}
+
+	remaining = int64(k.Quota) - int64(usage.Today)
+	if remaining < 0 {
+		usage.OverQuota++
+	}
+
+	return true, remaining
+}
+
+// Usage returns the usage reports of all the keys, the noisiest first.
+func (k *APIKeys) Usage() []KeyUsage {
+
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	report := make([]KeyUsage, 0, len(k.usage))
+	for _, usage := range k.usage {
+		report = append(report, *usage)
+	}
+
+	sort.Slice(report, func(i, j int) bool {
+		if report[i].Requests != report[j].Requests {
+			return report[i].Requests > report[j].Requests
+		}
+		return report[i].Name < report[j].Name
+	})
+
+	return report
+}
+
+// requestKey returns the API key of the request, taken from the X-API-Key
+// header or the api_key query parameter.
+func requestKey(r *http.Request) string {
+
+	if key := r.Header.Get("X-API-Key"); key != "" {
+		return key
+	}
+
+	return r.URL.Query().Get("api_key")
+}
+
+// Authenticate wraps next to reject the requests without a known API key
+// and to account the usage of the others.
+func (k *APIKeys) Authenticate(next http.Handler) http.Handler {
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+
+		ok, remaining := k.Use(requestKey(r))
+		if !ok {
+			writeError(w, http.StatusUnauthorized, "missing or unknown api key")
+			return
+		}
+
+		if k.Quota > 0 {
+			w.Header().Set("X-Quota-Limit", strconv.FormatUint(k.Quota, 10))
+			w.Header().Set("X-Quota-Remaining", strconv.FormatInt(max(remaining, 0), 10))
+			if remaining < 0 {
+				w.Header().Set("Warning", `299 - "daily quota exceeded"`)
+			}
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+func (k *APIKeys) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, k.Usage())
+}
@@ -0,0 +1,79 @@
+//
+// Copyright 2023 The GoEurofxref Authors. All rights reserved.
+// Use of this source code is governed by a MIT License
+// license that can be found in the LICENSE file.
+// Last Modification: 2026-10-14 17:57:51
+//
+
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestReadAPIKeys(t *testing.T) {
+
+	keys, err := ReadAPIKeys(strings.NewReader("# owners\nbilling: k1\n\nshop:k2\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(keys) != 2 || keys["k1"] != "billing" || keys["k2"] != "shop" {
+		t.Errorf("got %v", keys)
+	}
+
+	if _, err := ReadAPIKeys(strings.NewReader("nokey\n")); err == nil {
+		t.Error("expected an error for a line without a key")
+	}
+}
+
+func TestAPIKeys(t *testing.T) {
+
+	srv := newTestServer(t, false)
+	srv.Config.Handler.(*Server).APIKeys = NewAPIKeys(map[string]string{
+		"k1": "billing",
+		"k2": "shop",
+	}, 2)
+
+	if status, _ := get(t, srv.URL+"/rates/USD"); status != http.StatusUnauthorized {
+		t.Errorf("got status %d without a key, want %d", status, http.StatusUnauthorized)
+	}
+	if status, _ := get(t, srv.URL+"/rates/USD?api_key=bad"); status != http.StatusUnauthorized {
+		t.Errorf("got status %d with an unknown key, want %d", status, http.StatusUnauthorized)
+	}
+
+	var last *http.Response
+	for i := 0; i < 3; i++ {
+		req, _ := http.NewRequest("GET", srv.URL+"/rates/USD", nil)
+		req.Header.Set("X-API-Key", "k1")
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			t.Fatalf("got status %d, the quota is soft", resp.StatusCode)
+		}
+		last = resp
+	}
+	if last.Header.Get("X-Quota-Remaining") != "0" || last.Header.Get("Warning") == "" {
+		t.Errorf("the response over quota is not flagged: %v", last.Header)
+	}
+
+	status, body := get(t, srv.URL+"/usage?api_key=k2")
+	if status != http.StatusOK {
+		t.Fatalf("got status %d, want %d", status, http.StatusOK)
+	}
+	var usage []KeyUsage
+	if err := json.Unmarshal([]byte(body), &usage); err != nil {
+		t.Fatal(err)
+	}
+	if len(usage) != 2 || usage[0].Name != "billing" || usage[0].Requests != 3 || usage[0].OverQuota != 1 {
+		t.Errorf("unexpected usage report %+v", usage)
+	}
+	if usage[1].Name != "shop" || usage[1].Requests != 1 {
+		t.Errorf("unexpected usage report %+v", usage[1])
+	}
+}
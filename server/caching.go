@@ -0,0 +1,78 @@
+//
+// Copyright 2023 The GoEurofxref Authors. All rights reserved.
+// Use of this source code is governed by a MIT License
+// license that can be found in the LICENSE file.
+// Last Modification: 2026-10-15 11:55:00
+//
+
+package server
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"time"
+
+	eurofxref "github.com/mrhdias/go-eurofxref"
+)
+
+const (
+	// staleMaxAge is the caching time of the latest rates while the next
+	// publication is late, so they are asked again soon.
+	staleMaxAge = 5 * time.Minute
+	// historicalMaxAge is the caching time of the rates of past dates,
+	// which the ECB only revises exceptionally.
+	historicalMaxAge = 24 * time.Hour
+)
+
+// publicationTime returns the time the rates of date were published.
+func publicationTime(date time.Time) time.Time {
+	return time.Date(date.Year(), date.Month(), date.Day(), eurofxref.PublicationHour, 0, 0, 0, eurofxref.CET)
+}
+
+// maxAge returns how long a response with the rates published on date
+// can be cached at now: the latest rates until the next publication, and
+// the historical ones, of before the previous publication, for a day.
+func maxAge(date time.Time, historical bool, now time.Time) time.Duration {
+
+	if historical {
+		return historicalMaxAge
+	}
+	if eurofxref.PublicationFreshness(date, now) == eurofxref.Stale {
+		return staleMaxAge
+	}
+
+	return eurofxref.NextPublicationTime(now).Sub(now)
+}
+
+// writeCached writes v as JSON with the ETag, Last-Modified and
+// Cache-Control headers of the rates published on date, and answers the
+// conditional requests that match them with 304 Not Modified.
+func (s *Server) writeCached(w http.ResponseWriter, r *http.Request, date time.Time, historical bool, v any) {
+
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(v); err != nil {
+		s.logger().Error("encoding the response failed", slog.String("path", r.URL.Path), slog.Any("error", err))
+		writeError(w, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		return
+	}
+
+	sum := sha256.Sum256(buf.Bytes())
+	visibility := "public"
+	if s.APIKeys != nil {
+		// not shared by the caches between the keys
+		visibility = "private"
+	}
+
+	header := w.Header()
+	header.Set("Content-Type", "application/json")
+	header.Set("ETag", `"`+hex.EncodeToString(sum[:8])+`"`)
+	header.Set("Cache-Control", fmt.Sprintf("%s, max-age=%d", visibility,
+		int(maxAge(date, historical, s.Source.Now()).Seconds())))
+
+	http.ServeContent(w, r, "", publicationTime(date), bytes.NewReader(buf.Bytes()))
+}
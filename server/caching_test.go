@@ -0,0 +1,98 @@
+//
+// Copyright 2023 The GoEurofxref Authors. All rights reserved.
+// Use of this source code is governed by a MIT License
+// license that can be found in the LICENSE file.
+// Last Modification: 2026-10-14 19:32:21
+//
+
+package server
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	eurofxref "github.com/mrhdias/go-eurofxref"
+)
+
+func TestCachingHeaders(t *testing.T) {
+
+	ts := newTestServer(t, false)
+
+	resp, err := http.Get(ts.URL + "/rates/USD")
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+
+	etag := resp.Header.Get("ETag")
+	if etag == "" {
+		t.Fatal("no ETag header")
+	}
+	if got := resp.Header.Get("Last-Modified"); got != "Fri, 01 Mar 2024 15:00:00 GMT" {
+		t.Errorf("got Last-Modified %s, want the publication time", got)
+	}
+	// the sample publication is stale
+	if got := resp.Header.Get("Cache-Control"); got != "public, max-age=300" {
+		t.Errorf("got Cache-Control %s", got)
+	}
+
+	for header, value := range map[string]string{
+		"If-None-Match":     etag,
+		"If-Modified-Since": "Fri, 01 Mar 2024 15:00:00 GMT",
+	} {
+		req, _ := http.NewRequest(http.MethodGet, ts.URL+"/rates/USD", nil)
+		req.Header.Set(header, value)
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusNotModified {
+			t.Errorf("%s: got status %d, want %d", header, resp.StatusCode, http.StatusNotModified)
+		}
+	}
+
+	req, _ := http.NewRequest(http.MethodGet, ts.URL+"/rates/GBP", nil)
+	req.Header.Set("If-None-Match", etag)
+	resp, err = http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("got status %d for another resource, want %d", resp.StatusCode, http.StatusOK)
+	}
+
+	resp, err = http.Get(ts.URL + "/rates/2024-02-23")
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if got := resp.Header.Get("Cache-Control"); got != "public, max-age=86400" {
+		t.Errorf("got Cache-Control %s for a past date", got)
+	}
+}
+
+func TestMaxAge(t *testing.T) {
+
+	// a Tuesday afternoon, after the publication of the day
+	now := time.Date(2024, 3, 5, 17, 0, 0, 0, eurofxref.CET)
+	today := eurofxref.PublicationDate(2024, 3, 5)
+	if got := maxAge(today, false, now); got != 23*time.Hour {
+		t.Errorf("got %v, want until the next publication", got)
+	}
+
+	// the morning after, the rates of the day before are still the latest
+	morning := time.Date(2024, 3, 6, 10, 0, 0, 0, eurofxref.CET)
+	if got := maxAge(today, false, morning); got != 6*time.Hour {
+		t.Errorf("got %v, want until the publication of the day", got)
+	}
+
+	if got := maxAge(today.AddDate(0, 0, -7), false, now); got != staleMaxAge {
+		t.Errorf("got %v for stale rates, want %v", got, staleMaxAge)
+	}
+	if got := maxAge(today.AddDate(0, 0, -7), true, now); got != historicalMaxAge {
+		t.Errorf("got %v for historical rates, want %v", got, historicalMaxAge)
+	}
+}
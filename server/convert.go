@@ -0,0 +1,90 @@
+//
+// Copyright 2023 The GoEurofxref Authors. All rights reserved.
+// Use of this source code is governed by a MIT License
+// license that can be found in the LICENSE file.
+// Last Modification: 2026-10-15 12:45:00
+//
+
+package server
+
+import (
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	eurofxref "github.com/mrhdias/go-eurofxref"
+)
+
+// handleConvert converts the amount query parameter from a currency to
+// another, /convert?amount=100&from=USD&to=GBP, with the rates of the
+// latest publication or of the date parameter (YYYY-MM-DD), and answers
+// the eurofxref.ConversionResult in JSON. The rounding parameter is
+// "none" (the default), "minor-units" or "cash".
+func (s *Server) handleConvert(w http.ResponseWriter, r *http.Request) {
+
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		w.Header().Set("Allow", "GET, HEAD")
+		writeError(w, http.StatusMethodNotAllowed, http.StatusText(http.StatusMethodNotAllowed))
+		return
+	}
+
+	query := r.URL.Query()
+
+	amount, err := strconv.ParseFloat(query.Get("amount"), 64)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("invalid amount \"%s\"", query.Get("amount")))
+		return
+	}
+
+	from, to := strings.ToUpper(query.Get("from")), strings.ToUpper(query.Get("to"))
+	for _, currencyCode := range []string{from, to} {
+		if currencyCode == "EUR" {
+			continue
+		}
+		if err := s.Source.ValidateCurrencyCode(currencyCode); err != nil {
+			writeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+	}
+
+	opts := eurofxref.ConvertOptions{}
+	switch query.Get("rounding") {
+	case "", "none":
+	case "minor-units":
+		opts.Rounding = true
+	case "cash":
+		opts.CashRounding = true
+	default:
+		writeError(w, http.StatusBadRequest,
+			fmt.Sprintf("invalid rounding \"%s\": want none, minor-units or cash", query.Get("rounding")))
+		return
+	}
+
+	var result *eurofxref.ConversionResult
+	historical := false
+	if date := query.Get("date"); date != "" {
+		var onDate time.Time
+		if onDate, err = time.ParseInLocation("2006-01-02", date, eurofxref.CET); err != nil {
+			writeError(w, http.StatusBadRequest, fmt.Sprintf("invalid date \"%s\": want YYYY-MM-DD", date))
+			return
+		}
+		if onDate.Before(firstPublication) || onDate.After(s.Source.Now()) {
+			writeError(w, http.StatusNotFound, fmt.Sprintf("no rates were published on %s", date))
+			return
+		}
+		historical = onDate.Before(eurofxref.PreviousPublicationDate(s.Source.Now()))
+		result, err = s.Source.ConvertOnContext(r.Context(), amount, from, to, onDate, opts)
+	} else {
+		result, err = s.Source.ConvertContext(r.Context(), amount, from, to, opts)
+	}
+	if err != nil {
+		s.logger().Error("handling the request failed", slog.String("path", r.URL.Path), slog.Any("error", err))
+		writeError(w, http.StatusBadGateway, "could not convert with the reference rates")
+		return
+	}
+
+	s.writeCached(w, r, result.LastUpdate, historical, result)
+}
@@ -0,0 +1,103 @@
+//
+// Copyright 2023 The GoEurofxref Authors. All rights reserved.
+// Use of this source code is governed by a MIT License
+// license that can be found in the LICENSE file.
+// Last Modification: 2026-10-15 11:55:00
+//
+
+package server
+
+import (
+	"bytes"
+	_ "embed"
+	"html/template"
+	"log/slog"
+	"net/http"
+	"strings"
+)
+
+//go:embed dashboard.html
+var dashboardHTML string
+
+var dashboardTemplate = template.Must(template.New("dashboard").Parse(dashboardHTML))
+
+type dashboardRate struct {
+	Currency string
+	Rate     float64
+}
+
+type dashboardPoint struct {
+	Date string  `json:"date"`
+	Rate float64 `json:"rate"`
+}
+
+type dashboardData struct {
+	Date     string
+	Currency string
+	Rates    []dashboardRate
+	Points   []dashboardPoint
+}
+
+// handleDashboard renders the table of the latest reference rates and the
+// chart of the last 90 days of the currency selected by the "currency"
+// query parameter.
+func (s *Server) handleDashboard(w http.ResponseWriter, r *http.Request) {
+
+	if !s.Dashboard || r.URL.Path != "/" {
+		http.NotFound(w, r)
+		return
+	}
+
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		w.Header().Set("Allow", "GET, HEAD")
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+
+	currencyCode := strings.ToUpper(r.URL.Query().Get("currency"))
+	if currencyCode == "" {
+		currencyCode = "USD"
+	}
+	if err := s.Source.ValidateCurrencyCode(currencyCode); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	table, err := s.Source.DailyRatesContext(r.Context())
+	if err != nil {
+		s.logger().Error("rendering the dashboard failed", slog.Any("error", err))
+		http.Error(w, "could not get the reference rates", http.StatusBadGateway)
+		return
+	}
+
+	series, err := s.Source.HistoryContext(r.Context(), currencyCode)
+	if err != nil {
+		s.logger().Error("rendering the dashboard failed", slog.Any("error", err))
+		http.Error(w, "could not get the historical rates", http.StatusBadGateway)
+		return
+	}
+
+	data := dashboardData{
+		Date:     table.Date.Format("2006-01-02"),
+		Currency: currencyCode,
+	}
+	for _, currency := range table.Currencies() {
+		data.Rates = append(data.Rates, dashboardRate{Currency: currency, Rate: table.Rates[currency]})
+	}
+	for _, point := range series.Points {
+		data.Points = append(data.Points, dashboardPoint{
+			Date: point.Date.Format("2006-01-02"),
+			Rate: point.Rate,
+		})
+	}
+
+	var buf bytes.Buffer
+	if err := dashboardTemplate.Execute(&buf, data); err != nil {
+		s.logger().Error("rendering the dashboard failed", slog.Any("error", err))
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Write(buf.Bytes())
+}
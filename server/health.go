@@ -0,0 +1,72 @@
+//
+// Copyright 2023 The GoEurofxref Authors. All rights reserved.
+// Use of this source code is governed by a MIT License
+// license that can be found in the LICENSE file.
+// Last Modification: 2026-10-14 19:10:05
+//
+
+package server
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	eurofxref "github.com/mrhdias/go-eurofxref"
+)
+
+// DefaultMaxStaleness is the MaxStaleness of a Server when zero.
+const DefaultMaxStaleness = 24 * time.Hour
+
+type probeResponse struct {
+	Status    string `json:"status"`
+	Date      string `json:"date,omitempty"`
+	Freshness string `json:"freshness,omitempty"`
+	Reason    string `json:"reason,omitempty"`
+}
+
+// isProbe reports whether the path is one of the probes, which answer
+// without an API key.
+func isProbe(path string) bool {
+	return path == "/healthz" || path == "/readyz"
+}
+
+// handleHealthz answers 200 while the server is running.
+func (s *Server) handleHealthz(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, probeResponse{Status: "ok"})
+}
+
+// handleReadyz answers 200 when the server has rates to serve without
+// waiting on the ECB, and they are not older than the publication
+// expected MaxStaleness ago, and 503 otherwise.
+func (s *Server) handleReadyz(w http.ResponseWriter, r *http.Request) {
+
+	table, ok := s.Source.CachedRates(r.Context())
+	if !ok {
+		writeJSON(w, http.StatusServiceUnavailable, probeResponse{
+			Status: "not ready",
+			Reason: "the rates are not loaded yet",
+		})
+		return
+	}
+
+	now := s.Source.Now()
+	maxStaleness := s.MaxStaleness
+	if maxStaleness <= 0 {
+		maxStaleness = DefaultMaxStaleness
+	}
+
+	response := probeResponse{
+		Status:    "ready",
+		Date:      table.Date.Format("2006-01-02"),
+		Freshness: table.Freshness(now).String(),
+	}
+	if expected := eurofxref.PreviousPublicationDate(now.Add(-maxStaleness)); table.Date.Before(expected) {
+		response.Status = "not ready"
+		response.Reason = fmt.Sprintf("the rates are older than the publication of %s", expected.Format("2006-01-02"))
+		writeJSON(w, http.StatusServiceUnavailable, response)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, response)
+}
@@ -0,0 +1,48 @@
+//
+// Copyright 2023 The GoEurofxref Authors. All rights reserved.
+// Use of this source code is governed by a MIT License
+// license that can be found in the LICENSE file.
+// Last Modification: 2026-10-14 19:04:11
+//
+
+package server
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestProbes(t *testing.T) {
+
+	ts := newTestServer(t, false)
+	handler := ts.Config.Handler.(*Server)
+	handler.Source.CacheDir = t.TempDir()
+	handler.APIKeys = NewAPIKeys(map[string]string{"k1": "billing"}, 0)
+
+	if status, body := get(t, ts.URL+"/healthz"); status != http.StatusOK || !strings.Contains(body, `"ok"`) {
+		t.Errorf("got status %d without a key: %s", status, body)
+	}
+
+	status, body := get(t, ts.URL+"/readyz")
+	if status != http.StatusServiceUnavailable || !strings.Contains(body, "not loaded") {
+		t.Errorf("got status %d before any download: %s", status, body)
+	}
+
+	if status, body := get(t, ts.URL+"/rates/latest?api_key=k1"); status != http.StatusOK {
+		t.Fatalf("got status %d: %s", status, body)
+	}
+
+	// the sample publication is years old
+	status, body = get(t, ts.URL+"/readyz")
+	if status != http.StatusServiceUnavailable || !strings.Contains(body, `"freshness":"stale"`) {
+		t.Errorf("got status %d with stale rates: %s", status, body)
+	}
+
+	handler.MaxStaleness = time.Since(time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)) + 7*24*time.Hour
+	status, body = get(t, ts.URL+"/readyz")
+	if status != http.StatusOK || !strings.Contains(body, `"date":"2024-03-01"`) {
+		t.Errorf("got status %d within the staleness allowed: %s", status, body)
+	}
+}
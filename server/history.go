@@ -0,0 +1,208 @@
+//
+// Copyright 2023 The GoEurofxref Authors. All rights reserved.
+// Use of this source code is governed by a MIT License
+// license that can be found in the LICENSE file.
+// Last Modification: 2026-10-15 11:55:00
+//
+
+package server
+
+import (
+	"fmt"
+	"log/slog"
+	"math"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	eurofxref "github.com/mrhdias/go-eurofxref"
+)
+
+const (
+	// defaultHistoryLimit and maxHistoryLimit bound the points of a page
+	// of /rates/{currency}/history.
+	defaultHistoryLimit = 100
+	maxHistoryLimit     = 1000
+	// defaultHistoryDays is the range of the history without a from
+	// parameter.
+	defaultHistoryDays = 90
+)
+
+// firstPublication is the date of the first reference rates of the ECB.
+var firstPublication = eurofxref.PublicationDate(1999, 1, 4)
+
+type dateResponse struct {
+	Base      string             `json:"base"`
+	Date      string             `json:"date"`
+	Requested string             `json:"requested"`
+	Rates     map[string]float64 `json:"rates"`
+}
+
+type historyPoint struct {
+	Date string  `json:"date"`
+	Rate float64 `json:"rate"`
+}
+
+type historyResponse struct {
+	Base     string         `json:"base"`
+	Currency string         `json:"currency"`
+	From     string         `json:"from"`
+	To       string         `json:"to"`
+	Total    int            `json:"total"`
+	Offset   int            `json:"offset"`
+	Limit    int            `json:"limit"`
+	Points   []historyPoint `json:"points"`
+	// Next is the path of the next page, empty on the last one.
+	Next string `json:"next,omitempty"`
+}
+
+func parseDate(value string) (time.Time, bool) {
+
+	if len(value) != len("2006-01-02") {
+		return time.Time{}, false
+	}
+	date, err := time.ParseInLocation("2006-01-02", value, eurofxref.CET)
+
+	return date, err == nil
+}
+
+// handleDate answers the rates published on the date of /rates/{date},
+// or on the dates without a publication the most recent ones before.
+func (s *Server) handleDate(w http.ResponseWriter, r *http.Request, date time.Time) {
+
+	if date.Before(firstPublication) || date.After(s.Source.Now()) {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("no rates were published on %s", date.Format("2006-01-02")))
+		return
+	}
+
+	var table *eurofxref.RateTable
+	if s.Store != nil {
+		var ok bool
+		if table, ok = s.Store.RatesOn(date); !ok {
+			writeError(w, http.StatusNotFound,
+				fmt.Sprintf("no rates were published on or before %s", date.Format("2006-01-02")))
+			return
+		}
+	} else {
+		var err error
+		if table, err = s.Source.RatesOnContext(r.Context(), date); err != nil {
+			s.logger().Error("handling the request failed", slog.String("path", r.URL.Path), slog.Any("error", err))
+			writeError(w, http.StatusBadGateway, "could not get the reference rates")
+			return
+		}
+	}
+
+	historical := date.Before(eurofxref.PreviousPublicationDate(s.Source.Now()))
+	s.writeCached(w, r, table.Date, historical, dateResponse{
+		Base:      "EUR",
+		Date:      table.Date.Format("2006-01-02"),
+		Requested: date.Format("2006-01-02"),
+		Rates:     table.Rates,
+	})
+}
+
+// handleHistory answers the rates of the currency of
+// /rates/{currency}/history published between the from and to parameters
+// (YYYY-MM-DD, the last 90 days by default), a page of at most limit
+// points after offset at a time.
+func (s *Server) handleHistory(w http.ResponseWriter, r *http.Request, currencyCode string) {
+
+	currencyCode = strings.ToUpper(currencyCode)
+	query := r.URL.Query()
+
+	to := s.Source.Now().In(eurofxref.CET)
+	if value := query.Get("to"); value != "" {
+		var ok bool
+		if to, ok = parseDate(value); !ok {
+			writeError(w, http.StatusBadRequest, fmt.Sprintf("invalid to \"%s\": want YYYY-MM-DD", value))
+			return
+		}
+	}
+	from := to.AddDate(0, 0, -defaultHistoryDays)
+	if value := query.Get("from"); value != "" {
+		var ok bool
+		if from, ok = parseDate(value); !ok {
+			writeError(w, http.StatusBadRequest, fmt.Sprintf("invalid from \"%s\": want YYYY-MM-DD", value))
+			return
+		}
+	}
+	if to.Before(from) {
+		writeError(w, http.StatusBadRequest, "the end of the range is before its start")
+		return
+	}
+	// a discontinued currency is answered until its last publication
+	if err := s.Source.ValidateHistoricalCurrency(currencyCode, from); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	offset, limit := 0, defaultHistoryLimit
+	for _, param := range []struct {
+		name  string
+		value *int
+		max   int
+	}{{"offset", &offset, -1}, {"limit", &limit, maxHistoryLimit}} {
+		value := query.Get(param.name)
+		if value == "" {
+			continue
+		}
+		n, err := strconv.Atoi(value)
+		if err != nil || n < 0 || (param.max > 0 && (n == 0 || n > param.max)) {
+			writeError(w, http.StatusBadRequest, fmt.Sprintf("invalid %s \"%s\"", param.name, value))
+			return
+		}
+		*param.value = n
+	}
+	// the end of the page must stay representable
+	if offset > math.MaxInt-limit {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("invalid offset \"%s\"", query.Get("offset")))
+		return
+	}
+
+	var series *eurofxref.Series
+	if s.Store != nil {
+		series = s.Store.HistoryRange(currencyCode, from, to)
+	} else {
+		var err error
+		if series, err = s.Source.HistoryRangeContext(r.Context(), currencyCode, from, to); err != nil {
+			s.logger().Error("handling the request failed", slog.String("path", r.URL.Path), slog.Any("error", err))
+			writeError(w, http.StatusBadGateway, "could not get the historical rates")
+			return
+		}
+	}
+
+	offset = min(offset, len(series.Points))
+	response := historyResponse{
+		Base:     "EUR",
+		Currency: currencyCode,
+		From:     from.Format("2006-01-02"),
+		To:       to.Format("2006-01-02"),
+		Total:    len(series.Points),
+		Offset:   offset,
+		Limit:    limit,
+		Points:   []historyPoint{},
+	}
+	end := offset + min(limit, len(series.Points)-offset)
+	for i := offset; i < end; i++ {
+		point := series.Points[i]
+		response.Points = append(response.Points, historyPoint{point.Date.Format("2006-01-02"), point.Rate})
+	}
+	if end < len(series.Points) {
+		next := url.Values{}
+		next.Set("from", response.From)
+		next.Set("to", response.To)
+		next.Set("offset", strconv.Itoa(end))
+		next.Set("limit", strconv.Itoa(limit))
+		response.Next = r.URL.Path + "?" + next.Encode()
+	}
+
+	// modified with the most recent publication of the range
+	published := from
+	if len(series.Points) > 0 {
+		published = series.Points[len(series.Points)-1].Date
+	}
+	historical := to.Before(eurofxref.PreviousPublicationDate(s.Source.Now()))
+	s.writeCached(w, r, published, historical, response)
+}
@@ -0,0 +1,197 @@
+//
+// Copyright 2023 The GoEurofxref Authors. All rights reserved.
+// Use of this source code is governed by a MIT License
+// license that can be found in the LICENSE file.
+// Last Modification: 2026-10-14 17:51:13
+//
+
+package server
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+)
+
+// EndpointStats are the request counters of an endpoint.
+type EndpointStats struct {
+	Count        uint64
+	Errors       uint64
+	TotalLatency time.Duration
+	MaxLatency   time.Duration
+}
+
+// Metrics records the number of requests, errors and latencies per
+// endpoint. It is safe for concurrent use and can be shared by several
+// servers.
+type Metrics struct {
+	mu        sync.Mutex
+	endpoints map[string]*EndpointStats
+}
+
+func NewMetrics() *Metrics {
+	return &Metrics{endpoints: map[string]*EndpointStats{}}
+}
+
+// Observe records a request served by the endpoint.
+func (m *Metrics) Observe(endpoint string, latency time.Duration, failed bool) {
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	stats, ok := m.endpoints[endpoint]
+	if !ok {
+		stats = &EndpointStats{}
+		m.endpoints[endpoint] = stats
+	}
+
+	stats.Count++
+	if failed {
+		stats.Errors++
+	}
+	stats.TotalLatency += latency
+	if latency > stats.MaxLatency {
+		stats.MaxLatency = latency
+	}
+}
+
+// Snapshot returns a copy of the counters indexed by endpoint.
+func (m *Metrics) Snapshot() map[string]EndpointStats {
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	snapshot := make(map[string]EndpointStats, len(m.endpoints))
+	for endpoint, stats := range m.endpoints {
+		snapshot[endpoint] = *stats
+	}
+
+	return snapshot
+}
+
+// WriteTo writes the counters in the Prometheus text exposition format.
+func (m *Metrics) WriteTo(w io.Writer) (int64, error) {
+
+	snapshot := m.Snapshot()
+	endpoints := make([]string, 0, len(snapshot))
+	for endpoint := range snapshot {
+		endpoints = append(endpoints, endpoint)
+	}
+	sort.Strings(endpoints)
+
+	var written int64
+	printf := func(format string, a ...any) error {
+		n, err := fmt.Fprintf(w, format, a...)
+		written += int64(n)
+		return err
+	}
+
+	families := []struct {
+		name, kind, help string
+		value            func(EndpointStats) string
+	}{
+		{"eurofxref_requests_total", "counter", "Number of requests served.",
+			func(s EndpointStats) string { return fmt.Sprint(s.Count) }},
+		{"eurofxref_request_errors_total", "counter", "Number of requests that failed.",
+			func(s EndpointStats) string { return fmt.Sprint(s.Errors) }},
+		{"eurofxref_request_duration_seconds_sum", "counter", "Total time spent serving requests.",
+			func(s EndpointStats) string { return fmt.Sprint(s.TotalLatency.Seconds()) }},
+		{"eurofxref_request_duration_seconds_max", "gauge", "Slowest request served.",
+			func(s EndpointStats) string { return fmt.Sprint(s.MaxLatency.Seconds()) }},
+	}
+
+	for _, family := range families {
+		if err := printf("# HELP %s %s\n# TYPE %s %s\n",
+			family.name, family.help, family.name, family.kind); err != nil {
+			return written, err
+		}
+		for _, endpoint := range endpoints {
+			if err := printf("%s{endpoint=%q} %s\n",
+				family.name, endpoint, family.value(snapshot[endpoint])); err != nil {
+				return written, err
+			}
+		}
+	}
+
+	return written, nil
+}
+
+// ServeHTTP serves the counters in the Prometheus text exposition format.
+func (m *Metrics) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	m.WriteTo(w)
+}
+
+// statusRecorder captures the status code and size of a response.
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+	size   int
+}
+
+func (rec *statusRecorder) WriteHeader(status int) {
+	rec.status = status
+	rec.ResponseWriter.WriteHeader(status)
+}
+
+func (rec *statusRecorder) Write(b []byte) (int, error) {
+
+	if rec.status == 0 {
+		rec.status = http.StatusOK
+	}
+	n, err := rec.ResponseWriter.Write(b)
+	rec.size += n
+
+	return n, err
+}
+
+// Instrument wraps next to record the requests in metrics and to write an
+// access log entry per request to logger. Either of them can be nil. The
+// endpoint function maps a request to the name it is recorded under.
+func Instrument(next http.Handler, logger *slog.Logger, metrics *Metrics,
+	endpoint func(*http.Request) string) http.Handler {
+
+	if logger == nil && metrics == nil {
+		return next
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+
+		start := time.Now()
+		rec := &statusRecorder{ResponseWriter: w}
+
+		next.ServeHTTP(rec, r)
+
+		if rec.status == 0 {
+			rec.status = http.StatusOK
+		}
+		latency := time.Since(start)
+		name := endpoint(r)
+
+		if metrics != nil {
+			metrics.Observe(name, latency, rec.status >= http.StatusInternalServerError)
+		}
+
+		if logger != nil {
+			level := slog.LevelInfo
+			if rec.status >= http.StatusInternalServerError {
+				level = slog.LevelError
+			}
+			logger.LogAttrs(r.Context(), level, "request",
+				slog.String("method", r.Method),
+				slog.String("path", r.URL.Path),
+				slog.String("endpoint", name),
+				slog.Int("status", rec.status),
+				slog.Int("size", rec.size),
+				slog.Duration("duration", latency),
+				slog.String("remote", r.RemoteAddr),
+				slog.String("user_agent", r.UserAgent()),
+			)
+		}
+	})
+}
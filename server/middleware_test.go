@@ -0,0 +1,94 @@
+//
+// Copyright 2023 The GoEurofxref Authors. All rights reserved.
+// Use of this source code is governed by a MIT License
+// license that can be found in the LICENSE file.
+// Last Modification: 2026-10-14 17:51:13
+//
+
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestInstrument(t *testing.T) {
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/fail" {
+			http.Error(w, "boom", http.StatusBadGateway)
+			return
+		}
+		w.Write([]byte("ok"))
+	})
+
+	var logs bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&logs, nil))
+	metrics := NewMetrics()
+
+	instrumented := Instrument(handler, logger, metrics, func(r *http.Request) string {
+		return r.URL.Path
+	})
+
+	for _, path := range []string{"/ok", "/ok", "/fail"} {
+		instrumented.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", path, nil))
+	}
+
+	snapshot := metrics.Snapshot()
+	if got := snapshot["/ok"]; got.Count != 2 || got.Errors != 0 {
+		t.Errorf("got /ok stats %+v, want 2 requests and no errors", got)
+	}
+	if got := snapshot["/fail"]; got.Count != 1 || got.Errors != 1 {
+		t.Errorf("got /fail stats %+v, want 1 request and 1 error", got)
+	}
+
+	lines := strings.Split(strings.TrimSpace(logs.String()), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("got %d access log entries, want 3", len(lines))
+	}
+	var entry map[string]any
+	if err := json.Unmarshal([]byte(lines[2]), &entry); err != nil {
+		t.Fatal(err)
+	}
+	if entry["level"] != "ERROR" || entry["status"] != float64(http.StatusBadGateway) || entry["path"] != "/fail" {
+		t.Errorf("unexpected access log entry %v", entry)
+	}
+
+	var exposition bytes.Buffer
+	if _, err := metrics.WriteTo(&exposition); err != nil {
+		t.Fatal(err)
+	}
+	want := `eurofxref_requests_total{endpoint="/ok"} 2`
+	if !strings.Contains(exposition.String(), want) {
+		t.Errorf("metrics do not contain %s:\n%s", want, exposition.String())
+	}
+}
+
+func TestServerMetrics(t *testing.T) {
+
+	srv := newTestServer(t, false)
+	handler := srv.Config.Handler.(*Server)
+	handler.Metrics = NewMetrics()
+
+	get(t, srv.URL+"/rates/USD")
+	get(t, srv.URL+"/rates/GBP")
+	get(t, srv.URL+"/rates/latest")
+
+	status, body := get(t, srv.URL+"/metrics")
+	if status != http.StatusOK {
+		t.Fatalf("got status %d, want %d", status, http.StatusOK)
+	}
+	for _, want := range []string{
+		`eurofxref_requests_total{endpoint="/rates/{currency}"} 2`,
+		`eurofxref_requests_total{endpoint="/rates/latest"} 1`,
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("metrics do not contain %s:\n%s", want, body)
+		}
+	}
+}
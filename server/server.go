@@ -0,0 +1,118 @@
+//
+// Copyright 2023 The GoEurofxref Authors. All rights reserved.
+// Use of this source code is governed by a MIT License
+// license that can be found in the LICENSE file.
+// Last Modification: 2026-10-15 11:55:00
+//
+
+// Package server serves the euro foreign exchange reference rates over
+// HTTP, backed by the cache of the eurofxref package.
+//
+// The endpoints are:
+//
+//	GET /rates/latest        all the rates of the latest publication
+//	GET /rates/{currency}    the latest rate of a currency
+//	GET /                    the rates dashboard (when enabled)
+//	GET /metrics             the request metrics (when enabled)
+//	GET /usage               the usage report of the API keys (when enabled)
+package server
+
+import (
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	eurofxref "github.com/mrhdias/go-eurofxref"
+)
+
+// Server is an http.Handler serving the reference rates of Source.
+//
+// When Logger is set an access log entry is written for every request;
+// the errors of the handlers are logged to it, or to slog.Default when it
+// is not set. When Metrics is set the requests are counted per endpoint
+// and the counters are served at /metrics. When APIKeys is set every other
+// endpoint requires a known API key and the usage of the keys is served
+// at /usage. When Store is set, it answers /rates/{date} and
+// /rates/{currency}/history instead of the historical files of Source; it
+// is kept in sync by its owner.
+//
+// The /healthz and /readyz probes answer without an API key; /readyz
+// fails until the rates are loaded, and when they are older than the
+// publication expected MaxStaleness ago (DefaultMaxStaleness when zero).
+type Server struct {
+	Source       eurofxref.EuroFxRef
+	Dashboard    bool
+	Logger       *slog.Logger
+	Metrics      *Metrics
+	APIKeys      *APIKeys
+	Store        *eurofxref.HistoryStore
+	MaxStaleness time.Duration
+	mux          *http.ServeMux
+}
+
+func New(source eurofxref.EuroFxRef, dashboard bool) *Server {
+
+	server := &Server{
+		Source:    source,
+		Dashboard: dashboard,
+		mux:       http.NewServeMux(),
+	}
+
+	server.mux.HandleFunc("/", server.handleDashboard)
+	server.mux.HandleFunc("/rates/", server.handleRates)
+	server.mux.HandleFunc("/convert", server.handleConvert)
+	server.mux.HandleFunc("/healthz", server.handleHealthz)
+	server.mux.HandleFunc("/readyz", server.handleReadyz)
+
+	return server
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+
+	if s.Metrics != nil && r.URL.Path == "/metrics" {
+		s.Metrics.ServeHTTP(w, r)
+		return
+	}
+
+	var handler http.Handler = s.mux
+	if s.APIKeys != nil && !isProbe(r.URL.Path) {
+		if r.URL.Path == "/usage" {
+			handler = s.APIKeys
+		}
+		handler = s.APIKeys.Authenticate(handler)
+	}
+
+	Instrument(handler, s.Logger, s.Metrics, endpoint).ServeHTTP(w, r)
+}
+
+// logger returns the logger of the errors of the handlers.
+func (s *Server) logger() *slog.Logger {
+
+	if s.Logger != nil {
+		return s.Logger
+	}
+
+	return slog.Default()
+}
+
+// endpoint returns the route of the request, used to label the access
+// log entries and metrics without one series per currency.
+func endpoint(r *http.Request) string {
+
+	switch {
+	case r.URL.Path == "/", r.URL.Path == "/usage", r.URL.Path == "/convert", isProbe(r.URL.Path):
+		return r.URL.Path
+	case r.URL.Path == "/rates/latest":
+		return "/rates/latest"
+	case strings.HasPrefix(r.URL.Path, "/rates/") && strings.HasSuffix(r.URL.Path, "/history"):
+		return "/rates/{currency}/history"
+	case strings.HasPrefix(r.URL.Path, "/rates/"):
+		if _, ok := parseDate(strings.TrimPrefix(r.URL.Path, "/rates/")); ok {
+			return "/rates/{date}"
+		}
+		return "/rates/{currency}"
+	}
+
+	return "other"
+}
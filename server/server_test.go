@@ -0,0 +1,297 @@
+//
+// Copyright 2023 The GoEurofxref Authors. All rights reserved.
+// Use of this source code is governed by a MIT License
+// license that can be found in the LICENSE file.
+// Last Modification: 2026-10-15 12:45:00
+//
+
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	eurofxref "github.com/mrhdias/go-eurofxref"
+)
+
+// newTestServer returns a Server backed by the ECB sample files of the
+// eurofxref package.
+func newTestServer(t *testing.T, dashboard bool) *httptest.Server {
+
+	ecb := httptest.NewServer(http.FileServer(http.Dir("../testdata")))
+	t.Cleanup(ecb.Close)
+
+	source := eurofxref.New("", false)
+	source.CacheDir = ""
+	source.Url = ecb.URL + "/eurofxref-daily.xml"
+	source.HistUrl = ecb.URL + "/eurofxref-hist.xml"
+	source.Hist90Url = ecb.URL + "/eurofxref-hist-90d.xml"
+
+	ts := httptest.NewServer(New(source, dashboard))
+	t.Cleanup(ts.Close)
+
+	return ts
+}
+
+func get(t *testing.T, url string) (int, string) {
+
+	resp, err := http.Get(url)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return resp.StatusCode, string(body)
+}
+
+func TestDashboard(t *testing.T) {
+
+	ts := newTestServer(t, true)
+
+	status, body := get(t, ts.URL+"/?currency=gbp")
+	if status != http.StatusOK {
+		t.Fatalf("got status %d, want %d: %s", status, http.StatusOK, body)
+	}
+	for _, want := range []string{"Publication of 2024-03-01", "EUR/GBP", "0.85578", `"date":"2024-02-19"`} {
+		if !strings.Contains(body, want) {
+			t.Errorf("dashboard does not contain %q", want)
+		}
+	}
+
+	if status, _ := get(t, ts.URL+"/?currency=XXX"); status != http.StatusBadRequest {
+		t.Errorf("got status %d for an unknown currency, want %d", status, http.StatusBadRequest)
+	}
+
+	disabled := newTestServer(t, false)
+	if status, _ := get(t, disabled.URL+"/"); status != http.StatusNotFound {
+		t.Errorf("got status %d with the dashboard disabled, want %d", status, http.StatusNotFound)
+	}
+}
+
+func TestRates(t *testing.T) {
+
+	ts := newTestServer(t, false)
+
+	status, body := get(t, ts.URL+"/rates/latest")
+	if status != http.StatusOK {
+		t.Fatalf("got status %d, want %d: %s", status, http.StatusOK, body)
+	}
+	for _, want := range []string{`"base":"EUR"`, `"date":"2024-03-01"`, `"USD":1.0876`} {
+		if !strings.Contains(body, want) {
+			t.Errorf("latest rates %s do not contain %s", body, want)
+		}
+	}
+
+	status, body = get(t, ts.URL+"/rates/usd")
+	if status != http.StatusOK {
+		t.Fatalf("got status %d, want %d: %s", status, http.StatusOK, body)
+	}
+	want := `{"base":"EUR","currency":"USD","date":"2024-03-01","rate":1.0876}`
+	if strings.TrimSpace(body) != want {
+		t.Errorf("got %s, want %s", body, want)
+	}
+
+	for path, want := range map[string]int{
+		"/rates/XXX":       http.StatusBadRequest,
+		"/rates/":          http.StatusNotFound,
+		"/rates/USD/extra": http.StatusNotFound,
+	} {
+		if status, _ := get(t, ts.URL+path); status != want {
+			t.Errorf("%s: got status %d, want %d", path, status, want)
+		}
+	}
+}
+
+func TestConvert(t *testing.T) {
+
+	ts := newTestServer(t, false)
+
+	status, body := get(t, ts.URL+"/convert?amount=100&from=EUR&to=usd&rounding=minor-units")
+	if status != http.StatusOK {
+		t.Fatalf("got status %d, want %d: %s", status, http.StatusOK, body)
+	}
+
+	var result eurofxref.ConversionResult
+	if err := json.Unmarshal([]byte(body), &result); err != nil {
+		t.Fatal(err)
+	}
+	if result.Value != 108.76 || result.To != "USD" || !result.Options.Rounding ||
+		result.LastUpdate.Format("2006-01-02") != "2024-03-01" {
+		t.Errorf("unexpected conversion %s", body)
+	}
+
+	status, body = get(t, ts.URL+"/convert?amount=100&from=EUR&to=USD&date=2024-02-24")
+	if status != http.StatusOK {
+		t.Fatalf("got status %d, want %d: %s", status, http.StatusOK, body)
+	}
+	if !strings.Contains(body, `"date":"2024-02-23"`) || !strings.Contains(body, `"value":"108.23"`) {
+		t.Errorf("unexpected conversion on a Saturday %s", body)
+	}
+
+	for _, query := range []string{
+		"amount=abc&from=EUR&to=USD",
+		"amount=1&from=EUR&to=XXX",
+		"amount=1&from=EUR&to=USD&date=24-02-2024",
+		"amount=1&from=EUR&to=USD&rounding=up",
+	} {
+		if status, _ := get(t, ts.URL+"/convert?"+query); status != http.StatusBadRequest {
+			t.Errorf("%s: got status %d, want %d", query, status, http.StatusBadRequest)
+		}
+	}
+
+	// no publication on the date, answered without converting
+	for _, date := range []string{"1990-01-01", "2099-01-04"} {
+		if status, body := get(t, ts.URL+"/convert?amount=1&from=EUR&to=USD&date="+date); status != http.StatusNotFound {
+			t.Errorf("%s: got status %d, want %d: %s", date, status, http.StatusNotFound, body)
+		}
+	}
+	// the conversion errors are reported, not dereferenced
+	if status, body := get(t, ts.URL+"/convert?amount=1&from=EUR&to=USD&date=2000-01-04"); status != http.StatusBadGateway {
+		t.Errorf("got status %d, want %d: %s", status, http.StatusBadGateway, body)
+	}
+}
+
+func TestHistoricalRates(t *testing.T) {
+
+	ts := newTestServer(t, false)
+
+	status, body := get(t, ts.URL+"/rates/2024-02-24")
+	if status != http.StatusOK {
+		t.Fatalf("got status %d, want %d: %s", status, http.StatusOK, body)
+	}
+	for _, want := range []string{`"date":"2024-02-23"`, `"requested":"2024-02-24"`, `"USD":1.0823`} {
+		if !strings.Contains(body, want) {
+			t.Errorf("rates %s do not contain %s", body, want)
+		}
+	}
+
+	var page struct {
+		Total  int `json:"total"`
+		Points []struct {
+			Date string `json:"date"`
+		} `json:"points"`
+		Next string `json:"next"`
+	}
+	status, body = get(t, ts.URL+"/rates/usd/history?from=2024-02-01&to=2024-03-01&limit=15")
+	if status != http.StatusOK {
+		t.Fatalf("got status %d, want %d: %s", status, http.StatusOK, body)
+	}
+	if err := json.Unmarshal([]byte(body), &page); err != nil {
+		t.Fatal(err)
+	}
+	if page.Total != 20 || len(page.Points) != 15 || page.Points[0].Date != "2024-02-05" || page.Next == "" {
+		t.Fatalf("unexpected first page %s", body)
+	}
+
+	status, body = get(t, ts.URL+page.Next)
+	if status != http.StatusOK {
+		t.Fatalf("got status %d, want %d: %s", status, http.StatusOK, body)
+	}
+	page.Next = ""
+	if err := json.Unmarshal([]byte(body), &page); err != nil {
+		t.Fatal(err)
+	}
+	if len(page.Points) != 5 || page.Points[4].Date != "2024-03-01" || page.Next != "" {
+		t.Errorf("unexpected last page %s", body)
+	}
+
+	// past the last point, an empty page
+	status, body = get(t, ts.URL+"/rates/usd/history?from=2024-02-01&to=2024-03-01&offset=1000")
+	if status != http.StatusOK {
+		t.Fatalf("got status %d, want %d: %s", status, http.StatusOK, body)
+	}
+	page.Next = ""
+	if err := json.Unmarshal([]byte(body), &page); err != nil {
+		t.Fatal(err)
+	}
+	if page.Total != 20 || len(page.Points) != 0 || page.Next != "" {
+		t.Errorf("unexpected page past the end %s", body)
+	}
+
+	for path, want := range map[string]int{
+		"/rates/1998-12-31":  http.StatusNotFound,
+		"/rates/XXX/history": http.StatusBadRequest,
+		"/rates/USD/history?from=2024-03-01&to=2024-02-01": http.StatusBadRequest,
+		"/rates/USD/history?limit=0":                       http.StatusBadRequest,
+		"/rates/USD/history?offset=-1":                     http.StatusBadRequest,
+		"/rates/USD/history?offset=9223372036854775807":    http.StatusBadRequest,
+		"/rates/USD/history?offset=99999999999999999999":   http.StatusBadRequest,
+	} {
+		if status, _ := get(t, ts.URL+path); status != want {
+			t.Errorf("%s: got status %d, want %d", path, status, want)
+		}
+	}
+}
+
+func TestHistoricalRatesFromStore(t *testing.T) {
+
+	ts := newTestServer(t, false)
+	handler := ts.Config.Handler.(*Server)
+
+	store, err := eurofxref.OpenHistoryStore("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := store.Sync(handler.Source); err != nil {
+		t.Fatal(err)
+	}
+	handler.Store = store
+
+	// the historical files are not downloaded anymore
+	handler.Source.HistUrl += ".missing"
+	handler.Source.Hist90Url += ".missing"
+
+	status, body := get(t, ts.URL+"/rates/2024-02-24")
+	if status != http.StatusOK || !strings.Contains(body, `"date":"2024-02-23"`) {
+		t.Errorf("got status %d: %s", status, body)
+	}
+	status, body = get(t, ts.URL+"/rates/GBP/history?from=2024-02-27&to=2024-03-01")
+	if status != http.StatusOK || !strings.Contains(body, `"total":4`) {
+		t.Errorf("got status %d: %s", status, body)
+	}
+}
+
+func TestErrorLog(t *testing.T) {
+
+	ecb := httptest.NewServer(http.NotFoundHandler())
+	ecb.Close()
+
+	source := eurofxref.New("", false)
+	source.CacheDir = ""
+	source.Url = ecb.URL + "/eurofxref-daily.xml"
+
+	var buf bytes.Buffer
+	handler := New(source, false)
+	handler.Logger = slog.New(slog.NewJSONHandler(&buf, nil))
+	ts := httptest.NewServer(handler)
+	defer ts.Close()
+
+	if status, _ := get(t, ts.URL+"/rates/latest"); status != http.StatusBadGateway {
+		t.Fatalf("got status %d, want %d", status, http.StatusBadGateway)
+	}
+
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		var entry map[string]any
+		if err := json.Unmarshal([]byte(line), &entry); err != nil {
+			t.Fatal(err)
+		}
+		if entry["msg"] == "handling the request failed" {
+			if entry["level"] != "ERROR" || entry["path"] != "/rates/latest" || entry["error"] == nil {
+				t.Errorf("unexpected error log entry %v", entry)
+			}
+			return
+		}
+	}
+	t.Errorf("the error was not logged to the Logger: %s", buf.String())
+}
@@ -0,0 +1,25 @@
+//
+// Copyright 2023 The GoEurofxref Authors. All rights reserved.
+// Use of this source code is governed by a MIT License
+// license that can be found in the LICENSE file.
+// Last Modification: 2026-10-14 18:36:03
+//
+
+package eurofxref
+
+import (
+	"context"
+	"time"
+)
+
+// RateSource is the part of EuroFxRef used by most applications to look
+// up rates. Depending on it instead of EuroFxRef lets the tests of an
+// application replace the ECB with eurofxreftest.Source.
+type RateSource interface {
+	DailyContext(ctx context.Context, currencyCode string) (*QueryResult, error)
+	HistoryRangeContext(ctx context.Context, currencyCode string, from, to time.Time) (*Series, error)
+	ConvertContext(ctx context.Context, amount float64, from, to string,
+		options ...ConvertOptions) (*ConversionResult, error)
+}
+
+var _ RateSource = EuroFxRef{}
@@ -0,0 +1,56 @@
+//
+// Copyright 2023 The GoEurofxref Authors. All rights reserved.
+// Use of this source code is governed by a MIT License
+// license that can be found in the LICENSE file.
+// Last Modification: 2026-10-14 19:10:05
+//
+
+package eurofxref
+
+import (
+	"context"
+	"io/fs"
+	"log/slog"
+)
+
+// staleRates returns the rates of the cached daily file, whatever its
+// age, in place of the daily rates that could not be refreshed, when
+// ServeStale is set. They are flagged as Stale, with the time since they
+// were downloaded as Age.
+func (efr EuroFxRef) staleRates(ctx context.Context, err error) (*RateTable, bool) {
+
+	if !efr.ServeStale || efr.Provider != nil {
+		return nil, false
+	}
+
+	fileUrl := efr.Url
+	if efr.UseZip {
+		fileUrl = efr.ZipUrl
+	}
+	cache := efr.cacheFS()
+	if cache == nil {
+		return nil, false
+	}
+	fileStat, statErr := fs.Stat(cache, cacheName(fileUrl))
+	if statErr != nil {
+		return nil, false
+	}
+
+	offline := efr
+	offline.Offline = true
+	table, readErr := offline.fetchDailyRates(ctx, false)
+	// the next request tries the download again
+	efr.state.forget(efr.memoKey())
+	if readErr != nil {
+		return nil, false
+	}
+	table.Stale = true
+	table.Age = efr.Now().Sub(fileStat.ModTime())
+
+	efr.logger().Warn("serving the stale rates",
+		slog.String("publication", table.Date.Format("2006-01-02")),
+		slog.Duration("age", table.Age),
+		slog.Any("reason", err))
+
+	return table, true
+}
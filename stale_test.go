@@ -0,0 +1,77 @@
+//
+// Copyright 2023 The GoEurofxref Authors. All rights reserved.
+// Use of this source code is governed by a MIT License
+// license that can be found in the LICENSE file.
+// Last Modification: 2026-10-14 19:08:53
+//
+
+package eurofxref
+
+import (
+	"encoding/json"
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestServeStale(t *testing.T) {
+
+	ts, query := newTestServer(t)
+	query.CacheDir = t.TempDir()
+
+	if _, err := query.Daily("USD"); err != nil {
+		t.Fatal(err)
+	}
+
+	// the cached file expires, and the ECB cannot be reached
+	downloaded := time.Now().Add(-48 * time.Hour)
+	if err := os.Chtimes(query.CachePath(query.Url), downloaded, downloaded); err != nil {
+		t.Fatal(err)
+	}
+	ts.Close()
+	query.state = &state{}
+
+	if _, err := query.Daily("USD"); err == nil {
+		t.Fatal("expected an error without ServeStale")
+	}
+
+	query.ServeStale = true
+	query.EmbeddedFallback = true
+	for i := 0; i < 2; i++ {
+		result, err := query.Daily("USD")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !result.Stale || result.Embedded || result.Age < 48*time.Hour || result.Age > 49*time.Hour {
+			t.Fatalf("got %+v, want the stale rate of the cache", result)
+		}
+	}
+
+	result, _ := query.Daily("USD")
+	data, err := json.Marshal(result)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), `"stale":true`) || !strings.Contains(string(data), `"age":"48h`) {
+		t.Errorf("the stale rate is not flagged in %s", data)
+	}
+
+	var decoded QueryResult
+	if err := json.Unmarshal(data, &decoded); err != nil || !decoded.Stale ||
+		decoded.Age.Round(time.Second) != result.Age.Round(time.Second) {
+		t.Errorf("got %+v (%v), want %+v", decoded, err, result)
+	}
+
+	conversion, err := query.Convert(100, "EUR", "USD")
+	if err != nil || !conversion.Stale {
+		t.Errorf("got %+v (%v), want a conversion with the stale rates", conversion, err)
+	}
+
+	// without a cached file, the embedded rates are the last resort
+	query.CacheDir = t.TempDir()
+	result, err = query.Daily("USD")
+	if err != nil || !result.Embedded || result.Stale {
+		t.Errorf("got %+v (%v), want the embedded rate", result, err)
+	}
+}
@@ -0,0 +1,134 @@
+//
+// Copyright 2023 The GoEurofxref Authors. All rights reserved.
+// Use of this source code is governed by a MIT License
+// license that can be found in the LICENSE file.
+// Last Modification: 2026-10-14 18:14:37
+//
+
+package eurofxref
+
+import (
+	"fmt"
+	"math"
+	"sort"
+)
+
+// Stats summarizes the rates of a series. Min and Max carry the
+// publication date they were first reached on.
+type Stats struct {
+	Count  int
+	Min    Point
+	Max    Point
+	Mean   float64
+	Median float64
+	// StdDev is the population standard deviation of the rates.
+	StdDev float64
+}
+
+// Stats returns the statistics of the rates of the series, typically
+// the result of HistoryRange for the period of interest.
+func (series *Series) Stats() (*Stats, error) {
+
+	if len(series.Points) == 0 {
+		return nil, fmt.Errorf("no rates to summarize for \"%s\" currency code", series.Currency)
+	}
+
+	stats := &Stats{
+		Count: len(series.Points),
+		Min:   series.Points[0],
+		Max:   series.Points[0],
+	}
+
+	rates := make([]float64, len(series.Points))
+	sum := 0.0
+	for i, point := range series.Points {
+		rates[i] = point.Rate
+		sum += point.Rate
+		if point.Rate < stats.Min.Rate {
+			stats.Min = point
+		}
+		if point.Rate > stats.Max.Rate {
+			stats.Max = point
+		}
+	}
+	stats.Mean = sum / float64(stats.Count)
+
+	sort.Float64s(rates)
+	if middle := stats.Count / 2; stats.Count%2 == 1 {
+		stats.Median = rates[middle]
+	} else {
+		stats.Median = (rates[middle-1] + rates[middle]) / 2
+	}
+
+	squares := 0.0
+	for _, rate := range rates {
+		squares += (rate - stats.Mean) * (rate - stats.Mean)
+	}
+	stats.StdDev = math.Sqrt(squares / float64(stats.Count))
+
+	return stats, nil
+}
+
+// SMA returns the simple moving average of the rates over window
+// publications. The first average is dated on the window-th point, so
+// the result has len(series.Points)-window+1 points.
+func (series *Series) SMA(window int) ([]Point, error) {
+
+	if err := series.checkWindow(window); err != nil {
+		return nil, err
+	}
+
+	averages := make([]Point, 0, len(series.Points)-window+1)
+	sum := 0.0
+	for i, point := range series.Points {
+		sum += point.Rate
+		if i >= window {
+			sum -= series.Points[i-window].Rate
+		}
+		if i >= window-1 {
+			averages = append(averages, Point{Date: point.Date, Rate: sum / float64(window)})
+		}
+	}
+
+	return averages, nil
+}
+
+// EMA returns the exponential moving average of the rates with the
+// smoothing factor 2/(window+1). It is seeded with the simple average of
+// the first window points and aligned like SMA.
+func (series *Series) EMA(window int) ([]Point, error) {
+
+	if err := series.checkWindow(window); err != nil {
+		return nil, err
+	}
+
+	alpha := 2 / float64(window+1)
+
+	seed := 0.0
+	for _, point := range series.Points[:window] {
+		seed += point.Rate
+	}
+	value := seed / float64(window)
+
+	averages := make([]Point, 0, len(series.Points)-window+1)
+	averages = append(averages, Point{Date: series.Points[window-1].Date, Rate: value})
+	for _, point := range series.Points[window:] {
+		value = alpha*point.Rate + (1-alpha)*value
+		averages = append(averages, Point{Date: point.Date, Rate: value})
+	}
+
+	return averages, nil
+}
+
+func (series *Series) checkWindow(window int) error {
+
+	if window < 1 {
+		return fmt.Errorf("invalid moving average window %d", window)
+	}
+	if window > len(series.Points) {
+		return fmt.Errorf("the window of %d points is larger than the %d rates of \"%s\"",
+			window, len(series.Points), series.Currency)
+	}
+
+	return nil
+}
@@ -0,0 +1,105 @@
+//
+// Copyright 2023 The GoEurofxref Authors. All rights reserved.
+// Use of this source code is governed by a MIT License
+// license that can be found in the LICENSE file.
+// Last Modification: 2026-10-14 19:32:21
+//
+
+package eurofxref
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func march(d int) time.Time {
+	return PublicationDate(2024, 3, d)
+}
+
+func TestSeriesStats(t *testing.T) {
+
+	series := &Series{Currency: "USD", Points: []Point{
+		{march(1), 2}, {march(4), 4}, {march(5), 4}, {march(6), 4},
+		{march(7), 5}, {march(8), 5}, {march(11), 7}, {march(12), 9},
+	}}
+
+	stats, err := series.Stats()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if stats.Count != 8 || stats.Mean != 5 || stats.Median != 4.5 || stats.StdDev != 2 {
+		t.Errorf("unexpected stats %+v", stats)
+	}
+	if !stats.Min.Date.Equal(march(1)) || stats.Max.Rate != 9 || !stats.Max.Date.Equal(march(12)) {
+		t.Errorf("unexpected extremes %+v %+v", stats.Min, stats.Max)
+	}
+
+	series.Points = series.Points[:3]
+	if stats, _ = series.Stats(); stats.Median != 4 {
+		t.Errorf("median of an odd series is %v, want 4", stats.Median)
+	}
+
+	if _, err := (&Series{Currency: "USD"}).Stats(); err == nil {
+		t.Error("expected an error for an empty series")
+	}
+}
+
+func TestHistoryStats(t *testing.T) {
+
+	_, query := newTestServer(t)
+
+	series, err := query.History("USD")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	stats, err := series.Stats()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stats.Count != len(series.Points) || stats.Min.Rate > stats.Mean || stats.Mean > stats.Max.Rate {
+		t.Errorf("inconsistent stats %+v", stats)
+	}
+	if math.IsNaN(stats.StdDev) || stats.StdDev < 0 {
+		t.Errorf("invalid standard deviation %v", stats.StdDev)
+	}
+}
+
+func TestMovingAverages(t *testing.T) {
+
+	series := &Series{Currency: "USD", Points: []Point{
+		{march(1), 1}, {march(4), 2}, {march(5), 3}, {march(6), 4}, {march(7), 5},
+	}}
+
+	sma, err := series.SMA(3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(sma) != 3 || !sma[0].Date.Equal(march(5)) || sma[0].Rate != 2 || sma[2].Rate != 4 {
+		t.Errorf("unexpected SMA %v", sma)
+	}
+
+	ema, err := series.EMA(3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// alpha is 0.5: 2, then 0.5*4+0.5*2 = 3, then 0.5*5+0.5*3 = 4.
+	if len(ema) != 3 || !ema[2].Date.Equal(march(7)) || ema[0].Rate != 2 || ema[1].Rate != 3 || ema[2].Rate != 4 {
+		t.Errorf("unexpected EMA %v", ema)
+	}
+
+	if sma, _ := series.SMA(1); len(sma) != 5 || sma[4].Rate != 5 {
+		t.Errorf("a window of one should return the rates, got %v", sma)
+	}
+
+	for _, window := range []int{0, 6} {
+		if _, err := series.SMA(window); err == nil {
+			t.Errorf("expected an error for a window of %d", window)
+		}
+		if _, err := series.EMA(window); err == nil {
+			t.Errorf("expected an error for a window of %d", window)
+		}
+	}
+}
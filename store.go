@@ -0,0 +1,437 @@
+//
+// Copyright 2023 The GoEurofxref Authors. All rights reserved.
+// Use of this source code is governed by a MIT License
+// license that can be found in the LICENSE file.
+// Last Modification: 2026-10-14 19:32:21
+//
+
+package eurofxref
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// SyncReport describes the changes and the anomalies found by a sync.
+type SyncReport struct {
+	Added   []time.Time // publications new to the store
+	Revised []time.Time // publications whose rates changed in the feed
+	// Duplicates are the publication dates listed more than once in the
+	// feed. The first listing wins, as the ECB writes the most recent
+	// publication first.
+	Duplicates []time.Time
+	// OutOfOrder are the publication dates not listed in descending order
+	// in the feed.
+	OutOfOrder []time.Time
+	// Incremental reports whether only the 90-day file was read.
+	Incremental bool
+}
+
+// Anomalies reports whether the feed had duplicate or out of order dates.
+func (report *SyncReport) Anomalies() bool {
+	return len(report.Duplicates) > 0 || len(report.OutOfOrder) > 0
+}
+
+// HistoryStore is a local store of historical reference rates, kept in
+// memory and persisted to a file in the ECB XML format, compressed with
+// gzip when the name of the file ends with ".gz". It is safe for
+// concurrent use.
+//
+// The rates are indexed by date, and by currency in date order, so a
+// lookup takes constant time and a range the time of a binary search.
+type HistoryStore struct {
+	mu   sync.RWMutex
+	path string
+	days map[string]map[string]float64
+
+	// the index, rebuilt when days change
+	dates  []time.Time        // of the publications, the oldest first
+	series map[string][]Point // by currency, the oldest first
+}
+
+// OpenHistoryStore opens the store persisted at path, which is created by
+// the first sync if it does not exist. An empty path keeps the store only
+// in memory.
+func OpenHistoryStore(path string) (*HistoryStore, error) {
+
+	store := &HistoryStore{
+		path: path,
+		days: map[string]map[string]float64{},
+	}
+
+	if path == "" {
+		return store, nil
+	}
+
+	contentBytes, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return store, nil
+	}
+	if err == nil {
+		contentBytes, err = gunzip(contentBytes)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error reading the history store: %v", err)
+	}
+
+	if err := decodeCubes(bytes.NewReader(contentBytes), func(cube TimeCube) error {
+		date, rates, err := cube.rates()
+		if err != nil {
+			return err
+		}
+		store.days[date.Format("2006-01-02")] = rates
+		return nil
+	}); err != nil {
+		return nil, fmt.Errorf("error parsing the history store: %v", err)
+	}
+	store.reindex()
+
+	return store, nil
+}
+
+// Len returns the number of publications in the store.
+func (store *HistoryStore) Len() int {
+
+	store.mu.RLock()
+	defer store.mu.RUnlock()
+
+	return len(store.days)
+}
+
+// Rates returns the rates published on date, if the store has them.
+func (store *HistoryStore) Rates(date time.Time) (map[string]float64, bool) {
+
+	store.mu.RLock()
+	defer store.mu.RUnlock()
+
+	rates, ok := store.days[date.Format("2006-01-02")]
+	if !ok {
+		return nil, false
+	}
+
+	copied := make(map[string]float64, len(rates))
+	for currency, rate := range rates {
+		copied[currency] = rate
+	}
+
+	return copied, true
+}
+
+// RatesOn returns the table of the rates published on date or, on the
+// dates without a publication, the most recent one before, as
+// EuroFxRef.RatesOn does, if the store has it.
+func (store *HistoryStore) RatesOn(date time.Time) (*RateTable, bool) {
+
+	date = truncateDay(date)
+
+	store.mu.RLock()
+	// the first publication after date follows the one wanted
+	i := sort.Search(len(store.dates), func(i int) bool { return store.dates[i].After(date) })
+	var day time.Time
+	if i > 0 {
+		day = store.dates[i-1]
+	}
+	store.mu.RUnlock()
+
+	if day.IsZero() || day.Before(date.AddDate(0, 0, -rateOnLookback)) {
+		return nil, false
+	}
+	rates, ok := store.Rates(day)
+	if !ok {
+		return nil, false
+	}
+
+	return &RateTable{Date: day, Rates: rates}, true
+}
+
+// HistoryRange returns the rates of the currency in the store published
+// between from and to, both inclusive, from the oldest to the most
+// recent, as EuroFxRef.HistoryRange does.
+func (store *HistoryStore) HistoryRange(currencyCode string, from, to time.Time) *Series {
+
+	cc := strings.ToUpper(currencyCode)
+	from, to = truncateDay(from), truncateDay(to)
+
+	store.mu.RLock()
+	defer store.mu.RUnlock()
+
+	points := store.series[cc]
+	first := sort.Search(len(points), func(i int) bool { return !points[i].Date.Before(from) })
+	last := sort.Search(len(points), func(i int) bool { return points[i].Date.After(to) })
+	if first > last {
+		first = last
+	}
+
+	return &Series{Currency: cc, Points: append([]Point{}, points[first:last]...)}
+}
+
+// reindex rebuilds the index of the publications, with the lock held for
+// writing.
+func (store *HistoryStore) reindex() {
+
+	store.dates = make([]time.Time, 0, len(store.days))
+	for key := range store.days {
+		date, err := parseDate(key)
+		if err != nil {
+			continue
+		}
+		store.dates = append(store.dates, date)
+	}
+	sortDates(store.dates)
+
+	store.series = map[string][]Point{}
+	for _, date := range store.dates {
+		for currency, rate := range store.days[date.Format("2006-01-02")] {
+			store.series[currency] = append(store.series[currency], Point{Date: date, Rate: rate})
+		}
+	}
+}
+
+// incrementalWindow is the age under which the most recent publication of
+// the store is still listed in the 90-day file, with a week to spare.
+const incrementalWindow = hist90Days - 7*24*time.Hour
+
+// Sync merges the historical feed of source into the store and persists
+// it. Syncing the same feed again changes nothing, and duplicate or out
+// of order dates in the feed are reconciled deterministically and
+// reported instead of corrupting the store.
+//
+// The full history is read by the first sync only: while the most recent
+// publication of the store is listed in the 90-day file, the new dates
+// are merged from that much smaller file.
+func (store *HistoryStore) Sync(source EuroFxRef) (*SyncReport, error) {
+
+	fileUrl := source.HistUrl
+	latest, ok := store.latest()
+	incremental := ok && source.Now().Sub(latest) < incrementalWindow
+	if incremental {
+		fileUrl = source.Hist90Url
+	}
+
+	publications := []publication{}
+	if err := source.eachPublication(context.Background(), fileUrl,
+		func(date time.Time, rates map[string]float64) error {
+			publications = append(publications, publication{date, rates})
+			return nil
+		}); err != nil {
+		return nil, err
+	}
+
+	report := store.merge(publications)
+	report.Incremental = incremental
+
+	if len(report.Added) > 0 || len(report.Revised) > 0 {
+		if err := store.save(); err != nil {
+			return nil, err
+		}
+	}
+
+	logger := source.logger()
+	logger.Info("history synced",
+		slog.Int("added", len(report.Added)),
+		slog.Int("revised", len(report.Revised)),
+		slog.Bool("incremental", incremental))
+	if report.Anomalies() {
+		logger.Warn("history feed anomalies",
+			slog.Int("duplicates", len(report.Duplicates)),
+			slog.Int("out_of_order", len(report.OutOfOrder)))
+	}
+
+	return report, nil
+}
+
+// latest returns the date of the most recent publication in the store.
+func (store *HistoryStore) latest() (time.Time, bool) {
+
+	store.mu.RLock()
+	defer store.mu.RUnlock()
+
+	if len(store.dates) == 0 {
+		return time.Time{}, false
+	}
+
+	return store.dates[len(store.dates)-1], true
+}
+
+// publication is the date and rates of a publication in a feed.
+type publication struct {
+	date  time.Time
+	rates map[string]float64
+}
+
+// merge adds the publications of a feed, in the feed order, to the store.
+func (store *HistoryStore) merge(feed []publication) *SyncReport {
+
+	report := &SyncReport{}
+	seen := map[string]bool{}
+	var previous time.Time
+
+	publications := make([]publication, 0, len(feed))
+	for _, p := range feed {
+		key := p.date.Format("2006-01-02")
+		if seen[key] {
+			report.Duplicates = append(report.Duplicates, p.date)
+			continue
+		}
+		seen[key] = true
+
+		if !previous.IsZero() && !p.date.Before(previous) {
+			report.OutOfOrder = append(report.OutOfOrder, p.date)
+		}
+		previous = p.date
+
+		publications = append(publications, p)
+	}
+
+	store.mu.Lock()
+	defer store.mu.Unlock()
+
+	for _, p := range publications {
+		key := p.date.Format("2006-01-02")
+		current, ok := store.days[key]
+		switch {
+		case !ok:
+			report.Added = append(report.Added, p.date)
+		case !equalRates(current, p.rates):
+			report.Revised = append(report.Revised, p.date)
+		default:
+			continue
+		}
+		store.days[key] = p.rates
+	}
+	if len(report.Added) > 0 || len(report.Revised) > 0 {
+		store.reindex()
+	}
+
+	sortDates(report.Added)
+	sortDates(report.Revised)
+	sortDates(report.Duplicates)
+	sortDates(report.OutOfOrder)
+
+	return report
+}
+
+func equalRates(a, b map[string]float64) bool {
+
+	if len(a) != len(b) {
+		return false
+	}
+	for currency, rate := range a {
+		if other, ok := b[currency]; !ok || other != rate {
+			return false
+		}
+	}
+
+	return true
+}
+
+func sortDates(dates []time.Time) {
+	sort.Slice(dates, func(i, j int) bool { return dates[i].Before(dates[j]) })
+}
+
+// save writes the store to its file through a temporary file, so a
+// failed write never leaves a truncated store behind.
+func (store *HistoryStore) save() error {
+
+	if store.path == "" {
+		return nil
+	}
+
+	store.mu.RLock()
+	defer store.mu.RUnlock()
+
+	tmp, err := os.CreateTemp(filepath.Dir(store.path), filepath.Base(store.path)+".*")
+	if err != nil {
+		return fmt.Errorf("error creating the history store: %v", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	w := bufio.NewWriter(tmp)
+	var zw *gzip.Writer
+	var out io.Writer = w
+	if strings.HasSuffix(store.path, ".gz") {
+		zw = gzip.NewWriter(w)
+		out = zw
+	}
+	writeErr := writeEnvelope(out, store.days)
+	if writeErr == nil && zw != nil {
+		writeErr = zw.Close()
+	}
+	if writeErr == nil {
+		writeErr = w.Flush()
+	}
+	if err := tmp.Close(); writeErr == nil {
+		writeErr = err
+	}
+	if writeErr != nil {
+		return fmt.Errorf("error writing the history store: %v", writeErr)
+	}
+
+	if err := os.Rename(tmp.Name(), store.path); err != nil {
+		return fmt.Errorf("error writing the history store: %v", err)
+	}
+
+	return nil
+}
+
+// writeEnvelope writes the publications in the ECB XML format, the most
+// recent first and the currencies sorted, so equal data always produces
+// the same file.
+func writeEnvelope(w io.Writer, days map[string]map[string]float64) error {
+
+	dates := make([]string, 0, len(days))
+	for date := range days {
+		dates = append(dates, date)
+	}
+	sort.Sort(sort.Reverse(sort.StringSlice(dates)))
+
+	if _, err := io.WriteString(w, `<?xml version="1.0" encoding="UTF-8"?>
+<gesmes:Envelope xmlns:gesmes="http://www.gesmes.org/xml/2002-08-01" xmlns="http://www.ecb.int/vocabulary/2002-08-01/eurofxref">
+	<gesmes:subject>Reference rates</gesmes:subject>
+	<gesmes:Sender>
+		<gesmes:name>European Central Bank</gesmes:name>
+	</gesmes:Sender>
+	<Cube>
+`); err != nil {
+		return err
+	}
+
+	for _, date := range dates {
+		rates := days[date]
+		currencies := make([]string, 0, len(rates))
+		for currency := range rates {
+			currencies = append(currencies, currency)
+		}
+		sort.Strings(currencies)
+
+		if _, err := fmt.Fprintf(w, "\t\t<Cube time='%s'>\n", date); err != nil {
+			return err
+		}
+		for _, currency := range currencies {
+			if _, err := fmt.Fprintf(w, "\t\t\t<Cube currency='%s' rate='%s'/>\n",
+				currency, strconv.FormatFloat(rates[currency], 'f', -1, 64)); err != nil {
+				return err
+			}
+		}
+		if _, err := io.WriteString(w, "\t\t</Cube>\n"); err != nil {
+			return err
+		}
+	}
+
+	_, err := io.WriteString(w, "\t</Cube>\n</gesmes:Envelope>\n")
+
+	return err
+}
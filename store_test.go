@@ -0,0 +1,296 @@
+//
+// Copyright 2023 The GoEurofxref Authors. All rights reserved.
+// Use of this source code is governed by a MIT License
+// license that can be found in the LICENSE file.
+// Last Modification: 2026-10-14 19:32:21
+//
+
+package eurofxref
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestHistoryStoreSync(t *testing.T) {
+
+	_, query := newTestServer(t)
+	path := filepath.Join(t.TempDir(), "history.xml")
+
+	store, err := OpenHistoryStore(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	report, err := store.Sync(query)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(report.Added) != 20 || len(report.Revised) != 0 || report.Anomalies() {
+		t.Fatalf("unexpected first sync report %+v", report)
+	}
+
+	saved, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// syncing the same feed again is a no-op
+	report, err = store.Sync(query)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(report.Added) != 0 || len(report.Revised) != 0 {
+		t.Errorf("the second sync changed the store: %+v", report)
+	}
+
+	reopened, err := OpenHistoryStore(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if reopened.Len() != 20 {
+		t.Errorf("got %d publications after reopening, want 20", reopened.Len())
+	}
+	if _, err := reopened.Sync(query); err != nil {
+		t.Fatal(err)
+	}
+	if again, _ := os.ReadFile(path); string(again) != string(saved) {
+		t.Error("the store file changed after syncing the same feed")
+	}
+
+	rates, ok := reopened.Rates(PublicationDate(2024, 3, 1))
+	if !ok || rates["USD"] != 1.0876 {
+		t.Errorf("got %v, %v for 2024-03-01, want USD 1.0876", rates["USD"], ok)
+	}
+}
+
+const anomalousFeed = `<?xml version="1.0" encoding="UTF-8"?>
+<gesmes:Envelope xmlns:gesmes="http://www.gesmes.org/xml/2002-08-01" xmlns="http://www.ecb.int/vocabulary/2002-08-01/eurofxref">
+	<Cube>
+		<Cube time='2024-03-01'><Cube currency='USD' rate='1.0876'/></Cube>
+		<Cube time='2024-02-27'><Cube currency='USD' rate='1.0852'/></Cube>
+		<Cube time='2024-02-29'><Cube currency='USD' rate='1.0813'/></Cube>
+		<Cube time='2024-03-01'><Cube currency='USD' rate='9.9999'/></Cube>
+	</Cube>
+</gesmes:Envelope>`
+
+func TestHistoryStoreSyncAnomalies(t *testing.T) {
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(anomalousFeed))
+	}))
+	defer ts.Close()
+
+	query := New("", false)
+	query.CacheDir = ""
+	query.HistUrl = ts.URL
+
+	store, err := OpenHistoryStore("")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i := 0; i < 2; i++ {
+		report, err := store.Sync(query)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(report.Duplicates) != 1 || len(report.OutOfOrder) != 1 {
+			t.Errorf("sync %d: got %+v, want one duplicate and one out of order date", i, report)
+		}
+		if store.Len() != 3 {
+			t.Errorf("sync %d: got %d publications, want 3", i, store.Len())
+		}
+	}
+
+	rates, _ := store.Rates(PublicationDate(2024, 3, 1))
+	if rates["USD"] != 1.0876 {
+		t.Errorf("got USD %v for the duplicate date, want the first listing 1.0876", rates["USD"])
+	}
+}
+
+// recentFeed returns a feed of the publications of the days between
+// first and last days ago.
+func recentFeed(first, last int) string {
+
+	var b strings.Builder
+	b.WriteString("<?xml version=\"1.0\" encoding=\"UTF-8\"?>\n<gesmes:Envelope xmlns:gesmes=\"http://www.gesmes.org/xml/2002-08-01\" xmlns=\"http://www.ecb.int/vocabulary/2002-08-01/eurofxref\">\n\t<Cube>\n")
+	today := truncateDay(time.Now())
+	for days := last; days <= first; days++ {
+		fmt.Fprintf(&b, "\t\t<Cube time='%s'><Cube currency='USD' rate='1.08'/></Cube>\n",
+			today.AddDate(0, 0, -days).Format("2006-01-02"))
+	}
+	b.WriteString("\t</Cube>\n</gesmes:Envelope>\n")
+
+	return b.String()
+}
+
+func TestHistoryStoreSyncIncremental(t *testing.T) {
+
+	requests := map[string]int{}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests[r.URL.Path]++
+		switch r.URL.Path {
+		case "/hist.xml":
+			w.Write([]byte(recentFeed(400, 20)))
+		case "/hist-90d.xml":
+			w.Write([]byte(recentFeed(85, 0)))
+		}
+	}))
+	defer ts.Close()
+
+	query := New("", false)
+	query.CacheDir = ""
+	query.HistUrl = ts.URL + "/hist.xml"
+	query.Hist90Url = ts.URL + "/hist-90d.xml"
+
+	store, err := OpenHistoryStore("")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	report, err := store.Sync(query)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report.Incremental || len(report.Added) != 381 {
+		t.Errorf("unexpected first sync report: %d added, incremental %v", len(report.Added), report.Incremental)
+	}
+
+	report, err = store.Sync(query)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !report.Incremental || len(report.Added) != 20 {
+		t.Errorf("unexpected second sync report: %d added, incremental %v", len(report.Added), report.Incremental)
+	}
+	if requests["/hist.xml"] != 1 || requests["/hist-90d.xml"] != 1 || store.Len() != 401 {
+		t.Errorf("got requests %v and %d publications", requests, store.Len())
+	}
+}
+
+func TestHistoryStoreCompressed(t *testing.T) {
+
+	_, query := newTestServer(t)
+	path := filepath.Join(t.TempDir(), "history.xml.gz")
+
+	store, err := OpenHistoryStore(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := store.Sync(query); err != nil {
+		t.Fatal(err)
+	}
+
+	saved, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(saved) < 2 || saved[0] != 0x1f || saved[1] != 0x8b {
+		t.Fatal("the store is not compressed")
+	}
+
+	reopened, err := OpenHistoryStore(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if reopened.Len() != 20 {
+		t.Errorf("got %d publications after reopening, want 20", reopened.Len())
+	}
+}
+
+func TestHistoryStoreLookups(t *testing.T) {
+
+	_, query := newTestServer(t)
+
+	store, err := OpenHistoryStore("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := store.Sync(query); err != nil {
+		t.Fatal(err)
+	}
+
+	saturday := PublicationDate(2024, 2, 24)
+	table, ok := store.RatesOn(saturday)
+	if !ok || table.Date.Format("2006-01-02") != "2024-02-23" || table.Rates["USD"] != 1.0823 {
+		t.Errorf("got %+v (%v), want the publication of 2024-02-23", table, ok)
+	}
+	if _, ok := store.RatesOn(saturday.AddDate(-1, 0, 0)); ok {
+		t.Error("got rates a year before the oldest publication")
+	}
+
+	series := store.HistoryRange("usd", saturday.AddDate(0, 0, -7), saturday)
+	want, err := query.HistoryRange("USD", saturday.AddDate(0, 0, -7), saturday)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if series.Currency != "USD" || len(series.Points) != len(want.Points) ||
+		series.Points[len(series.Points)-1] != want.Points[len(want.Points)-1] {
+		t.Errorf("got %v, want %v", series.Points, want.Points)
+	}
+}
+
+func TestHistoryStoreIndex(t *testing.T) {
+
+	store, err := OpenHistoryStore("")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	day := func(d int) time.Time { return PublicationDate(2024, 3, d) }
+	store.merge([]publication{
+		{day(8), map[string]float64{"USD": 1.3}},
+		{day(4), map[string]float64{"USD": 1.1, "JPY": 160}},
+	})
+	if latest, ok := store.latest(); !ok || !latest.Equal(day(8)) {
+		t.Errorf("got the latest publication %v, want %v", latest, day(8))
+	}
+
+	// a later sync adds a publication in between
+	store.merge([]publication{{day(6), map[string]float64{"USD": 1.2}}})
+
+	tests := []struct {
+		from, to time.Time
+		want     []float64
+	}{
+		{day(1), day(31), []float64{1.1, 1.2, 1.3}},
+		{day(5), day(7), []float64{1.2}},
+		{day(6), day(8), []float64{1.2, 1.3}},
+		{day(9), day(31), []float64{}},
+		{day(8), day(4), []float64{}},
+	}
+	for _, test := range tests {
+		series := store.HistoryRange("USD", test.from, test.to)
+		got := []float64{}
+		for _, point := range series.Points {
+			got = append(got, point.Rate)
+		}
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("%v to %v: got %v, want %v", test.from, test.to, got, test.want)
+		}
+	}
+	if series := store.HistoryRange("JPY", day(5), day(31)); len(series.Points) != 0 {
+		t.Errorf("got %v, want no JPY rate after the 4th", series.Points)
+	}
+
+	if table, ok := store.RatesOn(day(7)); !ok || !table.Date.Equal(day(6)) {
+		t.Errorf("got %+v (%v), want the publication of the 6th", table, ok)
+	}
+	if table, ok := store.RatesOn(day(8).AddDate(0, 0, rateOnLookback)); !ok || !table.Date.Equal(day(8)) {
+		t.Errorf("got %+v (%v), want the publication of the 8th", table, ok)
+	}
+	if _, ok := store.RatesOn(day(8).AddDate(0, 0, rateOnLookback+1)); ok {
+		t.Error("got rates beyond the lookback")
+	}
+	if _, ok := store.RatesOn(day(3)); ok {
+		t.Error("got rates before the oldest publication")
+	}
+}
@@ -0,0 +1,169 @@
+//
+// Copyright 2023 The GoEurofxref Authors. All rights reserved.
+// Use of this source code is governed by a MIT License
+// license that can be found in the LICENSE file.
+// Last Modification: 2026-10-15 14:00:00
+//
+
+package eurofxref
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"time"
+)
+
+// decodeCubes decodes the ECB XML read from r token by token, calling fn
+// for each publication (the Cube elements with a time attribute) in
+// document order. Only one publication is decoded at a time, so the
+// memory used does not grow with the size of the file. As with scanXML,
+// the files declaring a DTD are rejected.
+func decodeCubes(r io.Reader, fn func(cube TimeCube) error) error {
+
+	decoder := xml.NewDecoder(r)
+	found := false
+
+	for {
+		token, err := decoder.Token()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("error when decoding the XML-encoded data: %v", err)
+		}
+
+		if _, ok := token.(xml.Directive); ok {
+			return errors.New("the XML-encoded data declares a DTD")
+		}
+		start, ok := token.(xml.StartElement)
+		if !ok {
+			continue
+		}
+		if start.Name.Local == "Envelope" {
+			found = true
+		}
+		if start.Name.Local != "Cube" || !hasAttr(start, "time") {
+			continue
+		}
+
+		var cube TimeCube
+		if err := decoder.DecodeElement(&cube, &start); err != nil {
+			return fmt.Errorf("error when decoding the XML-encoded data: %v", err)
+		}
+		if err := fn(cube); err != nil {
+			return err
+		}
+	}
+
+	if !found {
+		return errors.New("error when decoding the XML-encoded data: no Envelope element")
+	}
+
+	return nil
+}
+
+func hasAttr(start xml.StartElement, name string) bool {
+
+	for _, attr := range start.Attr {
+		if attr.Name.Local == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+// eachPublication fetches the ECB file at fileUrl and calls fn with the
+// date and rates of each of its publications, streaming them from the
+// content instead of unmarshalling the whole file. With UseZip, the full
+// history is read from HistZipUrl instead, and with a Provider the whole
+// history of the provider is read.
+func (efr EuroFxRef) eachPublication(ctx context.Context, fileUrl string,
+	fn func(date time.Time, rates map[string]float64) error) error {
+
+	if efr.Provider != nil {
+		return efr.Provider.Historical(ctx, time.Time{}, time.Time{}, fn)
+	}
+	if efr.UseZip && fileUrl == efr.HistUrl {
+		return efr.eachZipPublication(ctx, efr.HistZipUrl, false, fn)
+	}
+
+	contentBytes, err := efr.fetch(ctx, fileUrl, false)
+	if err != nil {
+		return err
+	}
+
+	cache := efr.cacheFS()
+	binaryName := efr.binaryCacheName(fileUrl)
+	if binaryName != "" {
+		if ok, err := readBinaryCache(cache, binaryName, contentBytes, fn); ok {
+			efr.debug(DebugCache, "binary cache hit", slog.String("file", binaryName))
+			return err
+		}
+	}
+
+	_, span := efr.startSpan(ctx, "eurofxref.parse", attrUrl.String(fileUrl))
+	if efr.Strict {
+		if err := validateStrict(bytes.NewReader(contentBytes), false); err != nil {
+			endSpan(span, err)
+			efr.logger().Error("parse failed", slog.String("url", fileUrl), slog.Any("error", err))
+			return err
+		}
+	}
+	if err := scanXML(contentBytes); err != nil {
+		endSpan(span, err)
+		efr.logger().Error("parse failed", slog.String("url", fileUrl), slog.Any("error", err))
+		return err
+	}
+	publications := 0
+	var binary *binaryCacheWriter
+	if binaryName != "" {
+		binary = newBinaryCacheWriter(contentBytes)
+	}
+	err = decodeCubes(bytes.NewReader(contentBytes), func(cube TimeCube) error {
+		date, rates, err := cube.rates()
+		if err != nil {
+			return err
+		}
+		if publications == 0 {
+			span.SetAttributes(attrPublicationDate.String(cube.Time))
+		}
+		publications++
+		if binary != nil {
+			binary.add(date, rates)
+		}
+		return fn(date, rates)
+	})
+	span.SetAttributes(attrPublications.Int(publications))
+	if errors.Is(err, errStopIteration) {
+		// the consumer of an iterator is done, not a parse failure
+		endSpan(span, nil)
+		return err
+	}
+	endSpan(span, err)
+
+	if err != nil {
+		efr.logger().Error("parse failed", slog.String("url", fileUrl), slog.Any("error", err))
+		return err
+	}
+
+	efr.debug(DebugParse, "parsed", slog.String("url", fileUrl), slog.Int("publications", publications))
+
+	// a file read only in part, by an iterator stopped early, is not
+	// written
+	if binary != nil {
+		if err := efr.writeBinaryCache(cache, binaryName, binary); err != nil {
+			efr.logger().Warn("error writing the binary cache", slog.String("file", binaryName),
+				slog.Any("error", err))
+		} else {
+			efr.debug(DebugCache, "cached", slog.String("file", binaryName))
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,48 @@
+//
+// Copyright 2023 The GoEurofxref Authors. All rights reserved.
+// Use of this source code is governed by a MIT License
+// license that can be found in the LICENSE file.
+// Last Modification: 2026-10-14 18:11:15
+//
+
+package eurofxref
+
+import (
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestDecodeCubes(t *testing.T) {
+
+	file, err := os.Open("testdata/eurofxref-hist.xml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+
+	dates := []string{}
+	if err := decodeCubes(file, func(cube TimeCube) error {
+		if len(cube.Cube) != 30 {
+			t.Errorf("%s: got %d rates, want 30", cube.Time, len(cube.Cube))
+		}
+		dates = append(dates, cube.Time)
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(dates) != 20 || dates[0] != "2024-03-01" || dates[19] != "2024-02-05" {
+		t.Errorf("got the publications %v", dates)
+	}
+
+	for _, malformed := range []string{
+		"<html><body>Service Unavailable</body></html>",
+		"<gesmes:Envelope><Cube><Cube time='2024-03-01'><Cube currency='USD'",
+	} {
+		err := decodeCubes(strings.NewReader(malformed), func(cube TimeCube) error { return nil })
+		if err == nil {
+			t.Errorf("expected an error decoding %q", malformed)
+		}
+	}
+}
@@ -0,0 +1,129 @@
+//
+// Copyright 2023 The GoEurofxref Authors. All rights reserved.
+// Use of this source code is governed by a MIT License
+// license that can be found in the LICENSE file.
+// Last Modification: 2026-10-14 18:28:59
+//
+
+package eurofxref
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// The namespace and sender of the envelopes published by the ECB.
+const (
+	gesmesNamespace = "http://www.gesmes.org/xml/2002-08-01"
+	ecbSenderName   = "European Central Bank"
+)
+
+// validateStrict checks that the XML read from r is an ECB envelope: a
+// gesmes Envelope sent by the European Central Bank, whose dated Cube
+// elements have a single valid time attribute and at least one rate, all
+// of them with a currency and a rate. The daily file must have exactly
+// one dated Cube, the historical ones at least one.
+func validateStrict(r io.Reader, daily bool) error {
+
+	decoder := xml.NewDecoder(r)
+
+	var stack []string
+	sender := ""
+	publications := 0
+	rates := 0
+
+	for {
+		token, err := decoder.Token()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("strict mode: %v", err)
+		}
+
+		switch element := token.(type) {
+		case xml.StartElement:
+			if len(stack) == 0 {
+				if element.Name.Local != "Envelope" || element.Name.Space != gesmesNamespace {
+					return fmt.Errorf("strict mode: the root element is %s:%s, want a gesmes Envelope",
+						element.Name.Space, element.Name.Local)
+				}
+			}
+			parent := ""
+			if len(stack) > 0 {
+				parent = stack[len(stack)-1]
+			}
+			stack = append(stack, element.Name.Local)
+
+			if element.Name.Local == "name" && parent == "Sender" {
+				var name struct {
+					Text string `xml:",chardata"`
+				}
+				if err := decoder.DecodeElement(&name, &element); err != nil {
+					return fmt.Errorf("strict mode: %v", err)
+				}
+				sender = strings.TrimSpace(name.Text)
+				stack = stack[:len(stack)-1]
+				continue
+			}
+			if element.Name.Local != "Cube" {
+				continue
+			}
+
+			switch len(stack) {
+			case 2: // the Cube wrapping the publications
+				if len(element.Attr) != 0 {
+					return errors.New("strict mode: the outer Cube has attributes")
+				}
+			case 3: // a publication
+				if len(element.Attr) != 1 || element.Attr[0].Name.Local != "time" {
+					return errors.New("strict mode: a dated Cube must have exactly one time attribute")
+				}
+				if _, err := time.Parse("2006-01-02", element.Attr[0].Value); err != nil {
+					return fmt.Errorf("strict mode: invalid time \"%s\"", element.Attr[0].Value)
+				}
+				if publications > 0 && rates == 0 {
+					return errors.New("strict mode: a dated Cube has no rates")
+				}
+				publications++
+				rates = 0
+			case 4: // a rate
+				currency, rate := "", ""
+				for _, attr := range element.Attr {
+					switch attr.Name.Local {
+					case "currency":
+						currency = strings.TrimSpace(attr.Value)
+					case "rate":
+						rate = strings.TrimSpace(attr.Value)
+					}
+				}
+				if currency == "" || rate == "" {
+					return errors.New("strict mode: a rate Cube has an empty currency or rate")
+				}
+				rates++
+			default:
+				return errors.New("strict mode: unexpected nesting of the Cube elements")
+			}
+
+		case xml.EndElement:
+			if len(stack) > 0 {
+				stack = stack[:len(stack)-1]
+			}
+		}
+	}
+
+	switch {
+	case sender != ecbSenderName:
+		return fmt.Errorf("strict mode: the sender is \"%s\", want \"%s\"", sender, ecbSenderName)
+	case publications == 0 || rates == 0:
+		return errors.New("strict mode: the envelope has no reference rates")
+	case daily && publications != 1:
+		return fmt.Errorf("strict mode: the daily envelope has %d dated Cube elements, want 1", publications)
+	}
+
+	return nil
+}
@@ -0,0 +1,99 @@
+//
+// Copyright 2023 The GoEurofxref Authors. All rights reserved.
+// Use of this source code is governed by a MIT License
+// license that can be found in the LICENSE file.
+// Last Modification: 2026-10-14 18:31:28
+//
+
+package eurofxref
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+)
+
+const strictEnvelope = `<?xml version="1.0" encoding="UTF-8"?>
+<gesmes:Envelope xmlns:gesmes="%s" xmlns="http://www.ecb.int/vocabulary/2002-08-01/eurofxref">
+	<gesmes:subject>Reference rates</gesmes:subject>
+	<gesmes:Sender><gesmes:name>%s</gesmes:name></gesmes:Sender>
+	<Cube>%s</Cube>
+</gesmes:Envelope>`
+
+func TestValidateStrict(t *testing.T) {
+
+	for _, test := range []struct {
+		file  string
+		daily bool
+	}{
+		{"testdata/eurofxref-daily.xml", true},
+		{"testdata/eurofxref-hist-90d.xml", false},
+		{"testdata/eurofxref-hist.xml", false},
+	} {
+		contentBytes, err := os.ReadFile(test.file)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := validateStrict(bytes.NewReader(contentBytes), test.daily); err != nil {
+			t.Errorf("%s: %v", test.file, err)
+		}
+	}
+
+	cube := `<Cube time='2024-03-01'><Cube currency='USD' rate='1.0876'/></Cube>`
+	tests := []struct {
+		name  string
+		xml   string
+		daily bool
+	}{
+		{"html page", `<html><body>Service Unavailable</body></html>`, false},
+		{"namespace", fmt.Sprintf(strictEnvelope, "http://example.com/gesmes", ecbSenderName, cube), false},
+		{"sender", fmt.Sprintf(strictEnvelope, gesmesNamespace, "CDN", cube), false},
+		{"no rates", fmt.Sprintf(strictEnvelope, gesmesNamespace, ecbSenderName, ""), false},
+		{"empty publication", fmt.Sprintf(strictEnvelope, gesmesNamespace, ecbSenderName,
+			`<Cube time='2024-03-01'></Cube>`+cube), false},
+		{"two attributes", fmt.Sprintf(strictEnvelope, gesmesNamespace, ecbSenderName,
+			`<Cube time='2024-03-01' id='1'><Cube currency='USD' rate='1.0876'/></Cube>`), false},
+		{"bad time", fmt.Sprintf(strictEnvelope, gesmesNamespace, ecbSenderName,
+			`<Cube time='01/03/2024'><Cube currency='USD' rate='1.0876'/></Cube>`), false},
+		{"empty rate", fmt.Sprintf(strictEnvelope, gesmesNamespace, ecbSenderName,
+			`<Cube time='2024-03-01'><Cube currency='USD' rate=''/></Cube>`), false},
+		{"two daily publications", fmt.Sprintf(strictEnvelope, gesmesNamespace, ecbSenderName,
+			cube+strings.Replace(cube, "03-01", "02-29", 1)), true},
+	}
+
+	for _, tt := range tests {
+		if err := validateStrict(strings.NewReader(tt.xml), tt.daily); err == nil {
+			t.Errorf("%s: expected an error", tt.name)
+		}
+	}
+}
+
+func TestStrictMode(t *testing.T) {
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, strictEnvelope, gesmesNamespace, "Edge Cache", "")
+	}))
+	defer ts.Close()
+
+	query := New("", false)
+	query.CacheDir = ""
+	query.Url = ts.URL + "/eurofxref-daily.xml"
+	query.Strict = true
+
+	if _, err := query.Daily("USD"); err == nil || !strings.Contains(err.Error(), "strict mode") {
+		t.Errorf("got %v, want a strict mode error", err)
+	}
+
+	_, query = newTestServer(t)
+	query.Strict = true
+	if _, err := query.Daily("USD"); err != nil {
+		t.Error(err)
+	}
+	if _, err := query.History("USD"); err != nil {
+		t.Error(err)
+	}
+}
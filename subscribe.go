@@ -0,0 +1,114 @@
+//
+// Copyright 2023 The GoEurofxref Authors. All rights reserved.
+// Use of this source code is governed by a MIT License
+// license that can be found in the LICENSE file.
+// Last Modification: 2026-10-14 19:36:44
+//
+
+package eurofxref
+
+import (
+	"context"
+	"log/slog"
+	"sort"
+	"time"
+)
+
+// subscriptionBuffer is the number of publications a subscriber can fall
+// behind before new ones are dropped for it.
+const subscriptionBuffer = 16
+
+// Publication is a new ECB publication delivered to the subscribers.
+type Publication struct {
+	Date     time.Time
+	Previous time.Time // date of the publication it replaces
+	Rates    map[string]float64
+	Changed  []string // currencies whose rate differs from Previous
+	// PreviousRates are the rates of the publication of Previous.
+	PreviousRates map[string]float64
+}
+
+// Subscribe returns a channel receiving every new publication with
+// changed rates detected by the client, either by the background refresh
+// of Start or by an on-demand lookup. The first publication seen by the
+// client is the baseline and is not delivered. The channel is closed
+// when ctx is done.
+//
+// Slow subscribers do not block the client: when a subscriber falls more
+// than 16 publications behind, the new ones are dropped for it.
+func (efr EuroFxRef) Subscribe(ctx context.Context) <-chan Publication {
+
+	ch := make(chan Publication, subscriptionBuffer)
+	if efr.state == nil {
+		close(ch)
+		return ch
+	}
+
+	s := efr.state
+	s.subMu.Lock()
+	if s.subscribers == nil {
+		s.subscribers = map[chan Publication]struct{}{}
+	}
+	s.subscribers[ch] = struct{}{}
+	s.subMu.Unlock()
+
+	go func() {
+		<-ctx.Done()
+		s.subMu.Lock()
+		delete(s.subscribers, ch)
+		close(ch)
+		s.subMu.Unlock()
+	}()
+
+	return ch
+}
+
+// observe records a publication seen by the client and notifies the
+// subscribers when it is newer than the last one and its rates changed.
+func (efr EuroFxRef) observe(table *RateTable) {
+
+	if efr.state == nil {
+		return
+	}
+
+	s := efr.state
+	s.subMu.Lock()
+	defer s.subMu.Unlock()
+
+	previous := s.published
+	if previous != nil && !table.Date.After(previous.Date) {
+		return
+	}
+	s.published = table.clone()
+	if previous == nil {
+		return
+	}
+
+	changed := []string{}
+	for currency, rate := range table.Rates {
+		if old, ok := previous.Rates[currency]; !ok || old != rate {
+			changed = append(changed, currency)
+		}
+	}
+	if len(changed) == 0 {
+		return
+	}
+	sort.Strings(changed)
+
+	for ch := range s.subscribers {
+		publication := Publication{
+			Date:     table.Date,
+			Previous: previous.Date,
+			Rates:    s.published.clone().Rates,
+			Changed:  changed,
+
+			PreviousRates: previous.clone().Rates,
+		}
+		select {
+		case ch <- publication:
+		default:
+			efr.logger().Warn("subscriber is not keeping up, publication dropped",
+				slog.String("publication", table.Date.Format("2006-01-02")))
+		}
+	}
+}
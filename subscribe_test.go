@@ -0,0 +1,81 @@
+//
+// Copyright 2023 The GoEurofxref Authors. All rights reserved.
+// Use of this source code is governed by a MIT License
+// license that can be found in the LICENSE file.
+// Last Modification: 2026-10-14 18:31:28
+//
+
+package eurofxref
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestSubscribe(t *testing.T) {
+
+	daily := `<gesmes:Envelope xmlns:gesmes="http://www.gesmes.org/xml/2002-08-01" xmlns="http://www.ecb.int/vocabulary/2002-08-01/eurofxref">
+	<Cube><Cube time='%s'><Cube currency='USD' rate='%s'/><Cube currency='GBP' rate='0.85578'/></Cube></Cube>
+</gesmes:Envelope>`
+	var publication atomic.Value
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(publication.Load().(string)))
+	}))
+	defer ts.Close()
+
+	query := New("", false)
+	query.CacheDir = ""
+	query.Url = ts.URL
+
+	ctx, cancel := context.WithCancel(context.Background())
+	updates := query.Subscribe(ctx)
+
+	// baseline
+	publication.Store(strings.Replace(strings.Replace(daily, "%s", "2024-02-29", 1), "%s", "1.0812", 1))
+	if _, err := query.Daily("USD"); err != nil {
+		t.Fatal(err)
+	}
+	// same publication again
+	if _, err := query.Daily("USD"); err != nil {
+		t.Fatal(err)
+	}
+	// new publication
+	publication.Store(strings.Replace(strings.Replace(daily, "%s", "2024-03-01", 1), "%s", "1.0876", 1))
+	if _, err := query.Daily("USD"); err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case p := <-updates:
+		if p.Date.Format("2006-01-02") != "2024-03-01" || p.Previous.Format("2006-01-02") != "2024-02-29" {
+			t.Errorf("got publication %s replacing %s", p.Date, p.Previous)
+		}
+		if len(p.Changed) != 1 || p.Changed[0] != "USD" || p.Rates["USD"] != 1.0876 {
+			t.Errorf("unexpected publication %+v", p)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("no publication was delivered")
+	}
+
+	select {
+	case p := <-updates:
+		t.Errorf("unexpected second publication %+v", p)
+	default:
+	}
+
+	cancel()
+	select {
+	case _, ok := <-updates:
+		if ok {
+			t.Error("the channel received a publication after the cancellation")
+		}
+	case <-time.After(time.Second):
+		t.Error("the channel was not closed")
+	}
+}
@@ -0,0 +1,86 @@
+//
+// Copyright 2023 The GoEurofxref Authors. All rights reserved.
+// Use of this source code is governed by a MIT License
+// license that can be found in the LICENSE file.
+// Last Modification: 2026-10-14 19:32:21
+//
+
+package eurofxref
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// RateTable is a publication of the ECB: the reference rates of the
+// quoted currencies against the euro on a date, at midnight CET like
+// every publication date of the package. Rates is indexed by the
+// upper case currency codes and does not hold the euro itself, which the
+// methods quote as 1.00.
+type RateTable struct {
+	Date  time.Time
+	Rates map[string]float64
+	// Embedded reports the rates embedded in the package, served when the
+	// daily file could not be read at all.
+	Embedded bool
+	// Stale reports the cached rates served with ServeStale when they
+	// could not be refreshed, and Age the time since their download.
+	Stale bool
+	Age   time.Duration
+}
+
+// Get returns the rate of the currency, if the table quotes it.
+func (table *RateTable) Get(currencyCode string) (float64, bool) {
+
+	currencyCode = strings.ToUpper(currencyCode)
+	if currencyCode == "EUR" {
+		return 1.00, true
+	}
+
+	rate, ok := table.Rates[currencyCode]
+
+	return rate, ok
+}
+
+// Has reports whether the table quotes the currency.
+func (table *RateTable) Has(currencyCode string) bool {
+
+	_, ok := table.Get(currencyCode)
+
+	return ok
+}
+
+// Currencies returns the codes of the quoted currencies in alphabetical
+// order.
+func (table *RateTable) Currencies() []string {
+	return sortedCurrencies(table.Rates)
+}
+
+// Convert converts an amount of the from currency into the to currency
+// through their rates against the euro.
+func (table *RateTable) Convert(amount float64, from, to string) (float64, error) {
+
+	fromRate, ok := table.Get(from)
+	if !ok {
+		return 0, fmt.Errorf("no conversion rate value was returned for \"%s\" currency code", from)
+	}
+	toRate, ok := table.Get(to)
+	if !ok {
+		return 0, fmt.Errorf("no conversion rate value was returned for \"%s\" currency code", to)
+	}
+
+	return amount * toRate / fromRate, nil
+}
+
+// clone returns a copy of the table that does not share its rates.
+func (table *RateTable) clone() *RateTable {
+
+	rates := make(map[string]float64, len(table.Rates))
+	for currency, rate := range table.Rates {
+		rates[currency] = rate
+	}
+
+	return &RateTable{Date: table.Date, Rates: rates, Embedded: table.Embedded,
+		Stale: table.Stale, Age: table.Age}
+}
@@ -0,0 +1,85 @@
+//
+// Copyright 2023 The GoEurofxref Authors. All rights reserved.
+// Use of this source code is governed by a MIT License
+// license that can be found in the LICENSE file.
+// Last Modification: 2026-10-14 19:32:21
+//
+
+package eurofxref
+
+import (
+	"encoding/json"
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestRateTable(t *testing.T) {
+
+	table := &RateTable{
+		Date:  PublicationDate(2024, 3, 1),
+		Rates: map[string]float64{"USD": 1.0876, "GBP": 0.85578, "JPY": 162.53},
+	}
+
+	if rate, ok := table.Get("usd"); !ok || rate != 1.0876 {
+		t.Errorf("Get(usd) = %v, %v", rate, ok)
+	}
+	if rate, ok := table.Get("EUR"); !ok || rate != 1 {
+		t.Errorf("Get(EUR) = %v, %v", rate, ok)
+	}
+	if table.Has("CHF") || !table.Has("GBP") {
+		t.Error("unexpected Has")
+	}
+	if got := table.Currencies(); !reflect.DeepEqual(got, []string{"GBP", "JPY", "USD"}) {
+		t.Errorf("Currencies() = %v", got)
+	}
+
+	value, err := table.Convert(100, "GBP", "USD")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if math.Abs(value-100*1.0876/0.85578) > 1e-9 {
+		t.Errorf("got %v", value)
+	}
+	if value, _ := table.Convert(10, "EUR", "JPY"); value != 1625.3 {
+		t.Errorf("got %v, want 1625.3", value)
+	}
+	if _, err := table.Convert(1, "CHF", "USD"); err == nil {
+		t.Error("expected an error for a currency missing from the table")
+	}
+
+	data, err := json.Marshal(table)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"base":"EUR","date":"2024-03-01","rates":{"GBP":"0.85578","JPY":"162.53","USD":"1.0876"}}`
+	if string(data) != want {
+		t.Errorf("got %s, want %s", data, want)
+	}
+	var decoded RateTable
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if !decoded.Date.Equal(table.Date) || !reflect.DeepEqual(decoded.Rates, table.Rates) {
+		t.Errorf("got %+v after a round trip", decoded)
+	}
+}
+
+func TestDailyRatesCopy(t *testing.T) {
+
+	_, query := newTestServer(t)
+
+	table, err := query.DailyRates()
+	if err != nil {
+		t.Fatal(err)
+	}
+	table.Rates["USD"] = 2
+
+	result, err := query.Convert(1, "EUR", "USD")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Rate != 1.0876 {
+		t.Errorf("the table returned was shared, got %v", result.Rate)
+	}
+}
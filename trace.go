@@ -0,0 +1,57 @@
+//
+// Copyright 2023 The GoEurofxref Authors. All rights reserved.
+// Use of this source code is governed by a MIT License
+// license that can be found in the LICENSE file.
+// Last Modification: 2026-10-14 18:01:58
+//
+
+package eurofxref
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+const tracerName = "github.com/mrhdias/go-eurofxref"
+
+// Attribute keys of the spans.
+const (
+	attrCurrency        = attribute.Key("eurofxref.currency")
+	attrCacheHit        = attribute.Key("eurofxref.cache.hit")
+	attrCacheFile       = attribute.Key("eurofxref.cache.file")
+	attrPublicationDate = attribute.Key("eurofxref.publication.date")
+	attrPublications    = attribute.Key("eurofxref.publications")
+	attrUrl             = attribute.Key("url.full")
+	attrStatusCode      = attribute.Key("http.response.status_code")
+)
+
+// tracer returns the tracer of the spans, a no-op one when no
+// TracerProvider is set.
+func (efr EuroFxRef) tracer() trace.Tracer {
+
+	if efr.TracerProvider == nil {
+		return noop.NewTracerProvider().Tracer(tracerName)
+	}
+
+	return efr.TracerProvider.Tracer(tracerName)
+}
+
+func (efr EuroFxRef) startSpan(ctx context.Context, name string,
+	attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+
+	return efr.tracer().Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// endSpan records err, if any, and ends the span.
+func endSpan(span trace.Span, err error) {
+
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
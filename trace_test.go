@@ -0,0 +1,70 @@
+//
+// Copyright 2023 The GoEurofxref Authors. All rights reserved.
+// Use of this source code is governed by a MIT License
+// license that can be found in the LICENSE file.
+// Last Modification: 2026-10-14 18:52:42
+//
+
+package eurofxref
+
+import (
+	"context"
+	"testing"
+
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestTracing(t *testing.T) {
+
+	_, query := newTestServer(t)
+	query.CacheDir = t.TempDir()
+
+	recorder := tracetest.NewSpanRecorder()
+	query.TracerProvider = sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+
+	for i := 0; i < 2; i++ {
+		// a new state each time, so the second query reads the cache
+		// instead of the rates kept in memory
+		query.state = &state{}
+		if _, err := query.DailyContext(context.Background(), "usd"); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	count := map[string]int{}
+	cacheHits := 0
+	for _, span := range recorder.Ended() {
+		count[span.Name()]++
+		for _, attr := range span.Attributes() {
+			switch {
+			case attr.Key == attrCacheHit && attr.Value.AsBool():
+				cacheHits++
+			case span.Name() == "eurofxref.Daily" && attr.Key == attrCurrency && attr.Value.AsString() != "USD":
+				t.Errorf("got currency attribute %s, want USD", attr.Value.AsString())
+			case span.Name() == "eurofxref.Daily" && attr.Key == attrPublicationDate && attr.Value.AsString() != "2024-03-01":
+				t.Errorf("got publication date attribute %s, want 2024-03-01", attr.Value.AsString())
+			}
+		}
+		if span.Name() != "eurofxref.Daily" && !span.Parent().IsValid() {
+			t.Errorf("span %s has no parent", span.Name())
+		}
+	}
+
+	want := map[string]int{
+		"eurofxref.Daily":       2,
+		"eurofxref.fetch":       2,
+		"eurofxref.http.get":    1,
+		"eurofxref.cache.write": 1,
+		"eurofxref.cache.read":  1,
+		"eurofxref.parse":       2,
+	}
+	for name, n := range want {
+		if count[name] != n {
+			t.Errorf("got %d %s spans, want %d", count[name], name, n)
+		}
+	}
+	if cacheHits != 1 {
+		t.Errorf("got %d cache hits, want 1", cacheHits)
+	}
+}
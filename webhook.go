@@ -0,0 +1,203 @@
+//
+// Copyright 2023 The GoEurofxref Authors. All rights reserved.
+// Use of this source code is governed by a MIT License
+// license that can be found in the LICENSE file.
+// Last Modification: 2026-10-14 19:38:01
+//
+
+package eurofxref
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const (
+	// SignatureHeader is the header of the HMAC-SHA256 signature of the
+	// body of a webhook request, "sha256=" followed by its hex encoding.
+	SignatureHeader = "X-Eurofxref-Signature"
+	// DefaultWebhookRetries is the number of retries of a failed webhook
+	// request when Retries is zero.
+	DefaultWebhookRetries = 3
+)
+
+// webhookRetryDelay is the wait before the first retry of a webhook
+// request, doubled for each of the next ones.
+var webhookRetryDelay = time.Second
+
+// Webhook is a Notifier receiving a POST request with a JSON
+// WebhookPayload for each event. With a Secret, the body is signed in the
+// SignatureHeader.
+type Webhook struct {
+	URL    string `yaml:"url" toml:"url"`
+	Secret string `yaml:"secret" toml:"secret"`
+	// Retries is the number of retries of a failed request, on an error
+	// or a status other than 2xx, DefaultWebhookRetries when zero and
+	// none when negative.
+	Retries int `yaml:"retries" toml:"retries"`
+	// Client sends the requests, a client with a 30 seconds timeout when
+	// nil.
+	Client *http.Client `yaml:"-" toml:"-"`
+}
+
+// WebhookChange is the change of the rate of a currency in a
+// WebhookPayload, Previous being zero for a newly quoted currency.
+type WebhookChange struct {
+	Currency string  `json:"currency"`
+	Previous float64 `json:"previous"`
+	Rate     float64 `json:"rate"`
+}
+
+// WebhookPayload is the body of the webhook requests. Event is the kind
+// of the event, "publication" or "alert", and Alerts the alerts of the
+// latter, as "USD above 1.1".
+type WebhookPayload struct {
+	Event    string          `json:"event"`
+	Date     string          `json:"date"`
+	Previous string          `json:"previous"`
+	Changes  []WebhookChange `json:"changes"`
+	Alerts   []string        `json:"alerts,omitempty"`
+}
+
+// NewWebhookPayload returns the payload of the event.
+func NewWebhookPayload(event Event) WebhookPayload {
+
+	publication := event.Publication
+	payload := WebhookPayload{
+		Event:    event.Kind.String(),
+		Date:     publication.Date.Format("2006-01-02"),
+		Previous: publication.Previous.Format("2006-01-02"),
+		Changes:  make([]WebhookChange, 0, len(publication.Changed)),
+	}
+	for _, currency := range publication.Changed {
+		payload.Changes = append(payload.Changes, WebhookChange{
+			Currency: currency,
+			Previous: publication.PreviousRates[currency],
+			Rate:     publication.Rates[currency],
+		})
+	}
+	for _, alert := range event.Alerts {
+		payload.Alerts = append(payload.Alerts, alert.String())
+	}
+
+	return payload
+}
+
+// Sign returns the signature of body with secret, as sent in the
+// SignatureHeader.
+func Sign(secret string, body []byte) string {
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// VerifySignature reports whether signature is the signature of body
+// with secret, in constant time, for the receivers of the webhooks.
+func VerifySignature(secret string, body []byte, signature string) bool {
+	return hmac.Equal([]byte(Sign(secret, body)), []byte(signature))
+}
+
+// Send posts the payload of a publication event to the webhook.
+func (hook *Webhook) Send(ctx context.Context, publication Publication) error {
+	return hook.Notify(ctx, Event{Kind: EventPublication, Publication: publication})
+}
+
+// Notify posts the payload of the event to the webhook, retrying with a
+// doubling delay until it is accepted or the retries are spent.
+func (hook *Webhook) Notify(ctx context.Context, event Event) error {
+
+	body, err := json.Marshal(NewWebhookPayload(event))
+	if err != nil {
+		return err
+	}
+
+	retries := hook.Retries
+	if retries == 0 {
+		retries = DefaultWebhookRetries
+	}
+	delay := webhookRetryDelay
+
+	for attempt := 0; ; attempt++ {
+		if err = hook.post(ctx, body); err == nil || attempt >= retries {
+			return err
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+		delay *= 2
+	}
+}
+
+// post sends a single request of body to the webhook.
+func (hook *Webhook) post(ctx context.Context, body []byte) error {
+
+	req, err := http.NewRequestWithContext(ctx, "POST", hook.URL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("client could not create request: %v", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", DefaultUserAgent)
+	if hook.Secret != "" {
+		req.Header.Set(SignatureHeader, Sign(hook.Secret, body))
+	}
+
+	client := hook.Client
+	if client == nil {
+		client = &http.Client{Timeout: 30 * time.Second}
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("error making http request: %v", err)
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 1<<16))
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return &statusError{Url: hook.URL, StatusCode: resp.StatusCode}
+	}
+
+	return nil
+}
+
+// validate checks the URL of the webhook.
+func (hook *Webhook) validate() error {
+
+	if !strings.HasPrefix(hook.URL, "http://") && !strings.HasPrefix(hook.URL, "https://") {
+		return fmt.Errorf("invalid webhook url \"%s\"", hook.URL)
+	}
+
+	return nil
+}
+
+// NotifyWebhooks posts every new publication detected by the client to
+// the webhooks, as Notify does without alert rules.
+func (efr EuroFxRef) NotifyWebhooks(ctx context.Context, hooks ...*Webhook) error {
+
+	notifiers := make([]Notifier, 0, len(hooks))
+	for _, hook := range hooks {
+		if err := hook.validate(); err != nil {
+			return err
+		}
+		notifiers = append(notifiers, hook)
+	}
+
+	return efr.Notify(ctx, nil, notifiers...)
+}
@@ -0,0 +1,128 @@
+//
+// Copyright 2023 The GoEurofxref Authors. All rights reserved.
+// Use of this source code is governed by a MIT License
+// license that can be found in the LICENSE file.
+// Last Modification: 2026-10-14 19:38:01
+//
+
+package eurofxref
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestWebhookSend(t *testing.T) {
+
+	defer func(delay time.Duration) { webhookRetryDelay = delay }(webhookRetryDelay)
+	webhookRetryDelay = time.Millisecond
+
+	var attempts atomic.Int32
+	received := make(chan WebhookPayload, 1)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/gone" {
+			http.NotFound(w, r)
+			return
+		}
+		body, _ := io.ReadAll(r.Body)
+		if !VerifySignature("s3cret", body, r.Header.Get(SignatureHeader)) {
+			t.Errorf("invalid signature %q", r.Header.Get(SignatureHeader))
+		}
+		// the first attempt fails
+		if attempts.Add(1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		var payload WebhookPayload
+		if err := json.Unmarshal(body, &payload); err != nil {
+			t.Error(err)
+		}
+		received <- payload
+	}))
+	defer ts.Close()
+
+	publication := Publication{
+		Date:          PublicationDate(2024, 3, 1),
+		Previous:      PublicationDate(2024, 2, 29),
+		Rates:         map[string]float64{"USD": 1.0876, "GBP": 0.85578},
+		Changed:       []string{"USD"},
+		PreviousRates: map[string]float64{"USD": 1.0796, "GBP": 0.85578},
+	}
+
+	hook := &Webhook{URL: ts.URL, Secret: "s3cret"}
+	if err := hook.Send(context.Background(), publication); err != nil {
+		t.Fatal(err)
+	}
+
+	payload := <-received
+	if payload.Event != "publication" || payload.Date != "2024-03-01" || payload.Previous != "2024-02-29" || len(payload.Changes) != 1 ||
+		payload.Changes[0] != (WebhookChange{Currency: "USD", Previous: 1.0796, Rate: 1.0876}) {
+		t.Errorf("unexpected payload %+v", payload)
+	}
+	if attempts.Load() != 2 {
+		t.Errorf("got %d attempts, want 2", attempts.Load())
+	}
+
+	// the retries are spent
+	hook = &Webhook{URL: ts.URL + "/gone", Retries: -1}
+	if err := hook.Send(context.Background(), publication); err == nil || !strings.Contains(err.Error(), "404") {
+		t.Errorf("got %v, want the status of the response", err)
+	}
+}
+
+func TestNotifyWebhooks(t *testing.T) {
+
+	received := make(chan WebhookPayload, 1)
+	hookServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var payload WebhookPayload
+		json.NewDecoder(r.Body).Decode(&payload)
+		received <- payload
+	}))
+	defer hookServer.Close()
+
+	daily := `<gesmes:Envelope xmlns:gesmes="http://www.gesmes.org/xml/2002-08-01" xmlns="http://www.ecb.int/vocabulary/2002-08-01/eurofxref">
+	<Cube><Cube time='DATE'><Cube currency='USD' rate='RATE'/></Cube></Cube>
+</gesmes:Envelope>`
+	var publication atomic.Value
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(publication.Load().(string)))
+	}))
+	defer ts.Close()
+
+	query := New("", false)
+	query.CacheDir = ""
+	query.Url = ts.URL
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	if err := query.NotifyWebhooks(ctx, &Webhook{URL: "ftp://example.com"}); err == nil {
+		t.Error("expected an error for an invalid url")
+	}
+	if err := query.NotifyWebhooks(ctx, &Webhook{URL: hookServer.URL}); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, rates := range [][2]string{{"2024-02-29", "1.0796"}, {"2024-03-01", "1.0876"}} {
+		publication.Store(strings.NewReplacer("DATE", rates[0], "RATE", rates[1]).Replace(daily))
+		if _, err := query.DailyRates(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	select {
+	case payload := <-received:
+		if payload.Date != "2024-03-01" || len(payload.Changes) != 1 || payload.Changes[0].Previous != 1.0796 {
+			t.Errorf("unexpected payload %+v", payload)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("the webhook was not notified")
+	}
+}
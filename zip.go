@@ -0,0 +1,163 @@
+//
+// Copyright 2023 The GoEurofxref Authors. All rights reserved.
+// Use of this source code is governed by a MIT License
+// license that can be found in the LICENSE file.
+// Last Modification: 2026-10-14 19:32:21
+//
+
+package eurofxref
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// decodeZipCSV reads the CSV file inside an ECB zip download and calls fn
+// for each of its rows in file order. The daily file dates its row as
+// "01 March 2024" and the historical one as "2024-03-01"; the header and
+// every row end with a comma, and missing rates are "N/A".
+func decodeZipCSV(data []byte, fn func(date time.Time, rates map[string]float64) error) error {
+
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return fmt.Errorf("error when opening the zip file: %v", err)
+	}
+
+	var csvFile *zip.File
+	for _, file := range zr.File {
+		if strings.EqualFold(path.Ext(file.Name), ".csv") {
+			csvFile = file
+			break
+		}
+	}
+	if csvFile == nil {
+		return errors.New("the zip file has no CSV file")
+	}
+
+	rc, err := csvFile.Open()
+	if err != nil {
+		return fmt.Errorf("error when opening \"%s\": %v", csvFile.Name, err)
+	}
+	defer rc.Close()
+
+	cr := csv.NewReader(limitReader(rc, maxDecompressedSize))
+	cr.TrimLeadingSpace = true
+	cr.FieldsPerRecord = -1
+
+	header, err := cr.Read()
+	if err != nil {
+		return fmt.Errorf("error when reading the CSV header: %v", err)
+	}
+	if len(header) == 0 || strings.TrimSpace(header[0]) != "Date" {
+		return errors.New("the CSV file does not start with a Date column")
+	}
+
+	for {
+		record, err := cr.Read()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("error when reading the CSV file: %v", err)
+		}
+
+		date, err := parseCSVDate(record[0])
+		if err != nil {
+			return err
+		}
+
+		rates := make(map[string]float64, len(header)-1)
+		for i := 1; i < len(header) && i < len(record); i++ {
+			currencyCode := strings.ToUpper(strings.TrimSpace(header[i]))
+			value := strings.TrimSpace(record[i])
+			if currencyCode == "" || value == "" || value == "N/A" {
+				continue
+			}
+			rateValue, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				return fmt.Errorf("error when convert rate string from csv to float: %v", err)
+			}
+			rates[currencyCode] = rateValue
+		}
+
+		if err := fn(date, rates); err != nil {
+			return err
+		}
+	}
+}
+
+func parseCSVDate(value string) (time.Time, error) {
+
+	value = strings.TrimSpace(value)
+	for _, layout := range []string{"2006-01-02", "02 January 2006"} {
+		if date, err := time.ParseInLocation(layout, value, CET); err == nil {
+			return date, nil
+		}
+	}
+
+	return time.Time{}, fmt.Errorf("invalid date \"%s\" in the CSV file", value)
+}
+
+// eachZipPublication is like eachPublication for the zip downloads.
+func (efr EuroFxRef) eachZipPublication(ctx context.Context, fileUrl string, refresh bool,
+	fn func(date time.Time, rates map[string]float64) error) error {
+
+	contentBytes, err := efr.fetch(ctx, fileUrl, refresh)
+	if err != nil {
+		return err
+	}
+
+	_, span := efr.startSpan(ctx, "eurofxref.parse", attrUrl.String(fileUrl))
+	publications := 0
+	err = decodeZipCSV(contentBytes, func(date time.Time, rates map[string]float64) error {
+		if publications == 0 {
+			span.SetAttributes(attrPublicationDate.String(date.Format("2006-01-02")))
+		}
+		publications++
+		return fn(date, rates)
+	})
+	span.SetAttributes(attrPublications.Int(publications))
+	if errors.Is(err, errStopIteration) {
+		endSpan(span, nil)
+		return err
+	}
+	endSpan(span, err)
+
+	if err != nil {
+		efr.logger().Error("parse failed", slog.String("url", fileUrl), slog.Any("error", err))
+		return err
+	}
+
+	efr.debug(DebugParse, "parsed", slog.String("url", fileUrl), slog.Int("publications", publications))
+
+	return nil
+}
+
+// fetchZipDailyRates is fetchDailyRates for the zip download.
+func (efr EuroFxRef) fetchZipDailyRates(ctx context.Context, refresh bool) (map[string]float64, time.Time, error) {
+
+	var lastUpdate time.Time
+	var rates map[string]float64
+	if err := efr.eachZipPublication(ctx, efr.ZipUrl, refresh, func(date time.Time, r map[string]float64) error {
+		lastUpdate, rates = date, r
+		return errStopIteration
+	}); err != nil && !errors.Is(err, errStopIteration) {
+		return nil, time.Time{}, err
+	}
+
+	if rates == nil {
+		return nil, time.Time{}, errors.New("the CSV file has no reference rates")
+	}
+
+	return rates, lastUpdate, nil
+}
@@ -0,0 +1,86 @@
+//
+// Copyright 2023 The GoEurofxref Authors. All rights reserved.
+// Use of this source code is governed by a MIT License
+// license that can be found in the LICENSE file.
+// Last Modification: 2026-10-14 19:32:21
+//
+
+package eurofxref
+
+import (
+	"archive/zip"
+	"bytes"
+	"testing"
+	"time"
+)
+
+func TestZipDailyRates(t *testing.T) {
+
+	_, query := newTestServer(t)
+
+	xmlTable, err := query.DailyRates()
+	if err != nil {
+		t.Fatal(err)
+	}
+	xmlRates, xmlDate := xmlTable.Rates, xmlTable.Date
+
+	query.UseZip = true
+	zipTable, err := query.DailyRates()
+	if err != nil {
+		t.Fatal(err)
+	}
+	zipRates, zipDate := zipTable.Rates, zipTable.Date
+
+	if !zipDate.Equal(xmlDate) || len(zipRates) != len(xmlRates) {
+		t.Fatalf("zip %v with %d rates, xml %v with %d rates", zipDate, len(zipRates), xmlDate, len(xmlRates))
+	}
+	for currencyCode, rate := range xmlRates {
+		if zipRates[currencyCode] != rate {
+			t.Errorf("%s: zip %v, xml %v", currencyCode, zipRates[currencyCode], rate)
+		}
+	}
+}
+
+func TestZipHistory(t *testing.T) {
+
+	_, query := newTestServer(t)
+	query.UseZip = true
+
+	from, _ := parseDate("2024-02-05")
+	to, _ := parseDate("2024-03-01")
+	series, err := query.HistoryRange("GBP", from, to)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(series.Points) != 20 || series.Points[19].Rate != 0.85578 {
+		t.Errorf("unexpected series from the zip download %v", series.Points)
+	}
+}
+
+func TestDecodeZipCSV(t *testing.T) {
+
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	w, _ := zw.Create("eurofxref-hist.csv")
+	w.Write([]byte("Date,USD,CYP,\n2024-03-01,1.0876,N/A,\n2007-12-31,1.4721,0.585274,\n"))
+	zw.Close()
+
+	var dates []string
+	var counts []int
+	if err := decodeZipCSV(buf.Bytes(), func(date time.Time, rates map[string]float64) error {
+		dates = append(dates, date.Format("2006-01-02"))
+		counts = append(counts, len(rates))
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if len(dates) != 2 || dates[1] != "2007-12-31" || counts[0] != 1 || counts[1] != 2 {
+		t.Errorf("got dates %v with %v rates", dates, counts)
+	}
+
+	if err := decodeZipCSV([]byte("not a zip"), func(time.Time, map[string]float64) error {
+		return nil
+	}); err == nil {
+		t.Error("expected an error for a file that is not a zip")
+	}
+}